@@ -8,6 +8,10 @@ const (
 	AnnotationPolicyCategory = "policies.kyverno.io/category"
 	AnnotationPolicySeverity = "policies.kyverno.io/severity"
 	AnnotationPolicyScored   = "policies.kyverno.io/scored"
+	// AnnotationPolicyShadow marks a policy as a shadow policy when set to true
+	AnnotationPolicyShadow = "policies.kyverno.io/shadow"
+	// AnnotationPolicyShadowOf references the active policy a shadow policy is compared with, name or namespace/name
+	AnnotationPolicyShadowOf = "policies.kyverno.io/shadow-of"
 	// ValueKyvernoApp defines the kyverno application value
 	ValueKyvernoApp = "kyverno"
 )
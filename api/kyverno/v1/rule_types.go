@@ -92,6 +92,29 @@ type Rule struct {
 	// VerifyImages is used to verify image signatures and mutate them to add a digest
 	// +optional
 	VerifyImages []ImageVerification `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+
+	// ReportMetadata is added to the policy report results of the rule.
+	// +optional
+	ReportMetadata *ReportMetadata `json:"reportMetadata,omitempty" yaml:"reportMetadata,omitempty"`
+}
+
+// ReportMetadata holds rule level information carried to policy report results.
+// It is emitted as result fields and properties only, no report labels are derived from it as
+// a report holds the results of many rules.
+type ReportMetadata struct {
+	// Severity of the rule results, overrides the policies.kyverno.io/severity policy annotation.
+	// +kubebuilder:validation:Enum=low;medium;high
+	// +optional
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// Category of the rule results, overrides the policies.kyverno.io/category policy annotation.
+	// +optional
+	Category string `json:"category,omitempty" yaml:"category,omitempty"`
+
+	// Properties are added to the properties of the rule results, for example an owner team,
+	// a remediation link or compliance control IDs. Properties set by Kyverno take precedence.
+	// +optional
+	Properties map[string]string `json:"properties,omitempty" yaml:"properties,omitempty"`
 }
 
 // HasMutate checks for mutate rule
@@ -46,6 +46,12 @@ func ValidatePolicyName(path *field.Path, name string) (errs field.ErrorList) {
 	return errs
 }
 
+// IsShadowPolicy returns true if the policy is a shadow policy, shadow policies are only evaluated on a sample
+// of admission requests, off the request path, and never affect admission responses
+func IsShadowPolicy(policy PolicyInterface) bool {
+	return policy.GetAnnotations()[AnnotationPolicyShadow] == "true"
+}
+
 func containsString(list []string, key string) bool {
 	for _, val := range list {
 		if val == key {
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"testing"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"gotest.tools/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -16,7 +19,8 @@ func Test_CleanupPolicy_Name(t *testing.T) {
 			Name: "this-is-a-way-too-long-policy-name-that-should-trigger-an-error-when-calling-the-policy-validation-method",
 		},
 		Spec: CleanupPolicySpec{
-			Schedule: "* * * * *",
+			ServiceAccountName: "cleanup",
+			Schedule:           "* * * * *",
 		},
 	}
 	errs := subject.Validate(nil)
@@ -33,7 +37,8 @@ func Test_CleanupPolicy_Schedule(t *testing.T) {
 			Name: "test-policy",
 		},
 		Spec: CleanupPolicySpec{
-			Schedule: "schedule-not-in-proper-cron-format",
+			ServiceAccountName: "cleanup",
+			Schedule:           "schedule-not-in-proper-cron-format",
 		},
 	}
 	errs := subject.Validate(nil)
@@ -710,3 +715,183 @@ func Test_doesMatchExcludeConflict(t *testing.T) {
 		})
 	}
 }
+
+func Test_CleanupPolicy_DeletionOptions(t *testing.T) {
+	propagation := metav1.DeletionPropagation("Never")
+	zero := 0
+	pageSize := int64(-1)
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			ServiceAccountName:        "cleanup",
+			Schedule:                  "* * * * *",
+			DeletionPropagationPolicy: &propagation,
+			MaxDeletionsPerRun:        &zero,
+			DeletionRateLimit:         &zero,
+			PageSize:                  &pageSize,
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 4)
+	assert.Equal(t, errs[0].Field, "spec.deletionPropagationPolicy")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeNotSupported)
+	assert.Equal(t, errs[1].Field, "spec.maxDeletionsPerRun")
+	assert.Equal(t, errs[1].Type, field.ErrorTypeInvalid)
+	assert.Equal(t, errs[2].Field, "spec.deletionRateLimit")
+	assert.Equal(t, errs[2].Type, field.ErrorTypeInvalid)
+	assert.Equal(t, errs[3].Field, "spec.pageSize")
+	assert.Equal(t, errs[3].Type, field.ErrorTypeInvalid)
+}
+
+func Test_ClusterCleanupPolicy_ServiceAccountName(t *testing.T) {
+	subject := ClusterCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule:           "* * * * *",
+			ServiceAccountName: "cleanup",
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.serviceAccountName")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+}
+
+func Test_ClusterCleanupPolicy_UserInfo(t *testing.T) {
+	subject := ClusterCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule: "* * * * *",
+			MatchResources: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+					UserInfo:            kyvernov1.UserInfo{Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "someone"}}},
+				}},
+			},
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 1, errs)
+	assert.Equal(t, errs[0].Field, "spec.match.any[0].subjects")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+}
+
+func Test_CleanupPolicy_UserInfo(t *testing.T) {
+	match := func(userInfo kyvernov1.UserInfo) kyvernov2beta1.MatchResources {
+		return kyvernov2beta1.MatchResources{
+			Any: kyvernov1.ResourceFilters{{
+				ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"Pod"}},
+				UserInfo:            userInfo,
+			}},
+		}
+	}
+	tests := []struct {
+		name               string
+		serviceAccountName string
+		userInfo           kyvernov1.UserInfo
+		wantField          string
+		wantType           field.ErrorType
+	}{{
+		name:      "no service account",
+		wantField: "spec.serviceAccountName",
+		wantType:  field.ErrorTypeRequired,
+	}, {
+		name:               "roles",
+		serviceAccountName: "cleanup",
+		userInfo:           kyvernov1.UserInfo{Roles: []string{"default:admin"}},
+		wantField:          "spec.match.any[0].roles",
+		wantType:           field.ErrorTypeForbidden,
+	}, {
+		name:               "cluster roles",
+		serviceAccountName: "cleanup",
+		userInfo:           kyvernov1.UserInfo{ClusterRoles: []string{"admin"}},
+		wantField:          "spec.match.any[0].clusterRoles",
+		wantType:           field.ErrorTypeForbidden,
+	}, {
+		name:               "subjects not matching service account",
+		serviceAccountName: "cleanup",
+		userInfo:           kyvernov1.UserInfo{Subjects: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "cleanup", Namespace: "other"}}},
+		wantField:          "spec.match.any[0].subjects",
+		wantType:           field.ErrorTypeInvalid,
+	}, {
+		name:               "service account subject",
+		serviceAccountName: "cleanup",
+		userInfo:           kyvernov1.UserInfo{Subjects: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "cleanup", Namespace: "default"}}},
+	}, {
+		name:               "user subject",
+		serviceAccountName: "cleanup",
+		userInfo:           kyvernov1.UserInfo{Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "system:serviceaccount:default:cleanup"}}},
+	}, {
+		name:               "group subject",
+		serviceAccountName: "cleanup",
+		userInfo:           kyvernov1.UserInfo{Subjects: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:default"}}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject := CleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-policy",
+					Namespace: "default",
+				},
+				Spec: CleanupPolicySpec{
+					Schedule:           "* * * * *",
+					ServiceAccountName: tt.serviceAccountName,
+					MatchResources:     match(tt.userInfo),
+				},
+			}
+			errs := subject.Validate(nil)
+			if tt.wantField == "" {
+				assert.Assert(t, len(errs) == 0, errs)
+			} else {
+				assert.Assert(t, len(errs) == 1, errs)
+				assert.Equal(t, errs[0].Field, tt.wantField)
+				assert.Equal(t, errs[0].Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func Test_CleanupPolicy_Context(t *testing.T) {
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			ServiceAccountName: "cleanup",
+			Schedule:           "* * * * *",
+			Context: []CleanupContextEntry{{
+				Name:       "pods",
+				References: &ReferencesLookup{Kind: "Pod"},
+			}, {
+				Name:       "pods",
+				References: &ReferencesLookup{},
+			}, {
+				Name: "empty",
+			}},
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 3, errs)
+	assert.Equal(t, errs[0].Field, "spec.context[1].name")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeDuplicate)
+	assert.Equal(t, errs[1].Field, "spec.context[1].references.kind")
+	assert.Equal(t, errs[1].Type, field.ErrorTypeRequired)
+	assert.Equal(t, errs[2].Field, "spec.context[2]")
+	assert.Equal(t, errs[2].Type, field.ErrorTypeInvalid)
+}
+
+func Test_CleanupPolicyStatus_GetResumePosition(t *testing.T) {
+	var status CleanupPolicyStatus
+	assert.Assert(t, status.GetResumePosition(1) == nil)
+	status.SetComplete(1, CleanupReasonDeletionLimitReached, "")
+	status.ResumeAfter = &CleanupResumePosition{Kind: "Pod", Key: "default/pod"}
+	assert.Equal(t, *status.GetResumePosition(1), CleanupResumePosition{Kind: "Pod", Key: "default/pod"})
+	// the position of a previous generation is ignored
+	assert.Assert(t, status.GetResumePosition(2) == nil)
+}
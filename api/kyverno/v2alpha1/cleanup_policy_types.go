@@ -17,13 +17,18 @@ limitations under the License.
 package v2alpha1
 
 import (
+	"fmt"
 	"reflect"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/robfig/cron"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -62,7 +67,7 @@ func (p *CleanupPolicy) GetStatus() *CleanupPolicyStatus {
 // Validate implements programmatic validation
 func (p *CleanupPolicy) Validate(clusterResources sets.Set[string]) (errs field.ErrorList) {
 	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), p.Name)...)
-	errs = append(errs, p.Spec.Validate(field.NewPath("spec"), clusterResources, true)...)
+	errs = append(errs, p.Spec.Validate(field.NewPath("spec"), clusterResources, true, p.Namespace)...)
 	return errs
 }
 
@@ -132,7 +137,7 @@ func (p *ClusterCleanupPolicy) GetAPIVersion() string {
 // Validate implements programmatic validation
 func (p *ClusterCleanupPolicy) Validate(clusterResources sets.Set[string]) (errs field.ErrorList) {
 	errs = append(errs, kyvernov1.ValidatePolicyName(field.NewPath("metadata").Child("name"), p.Name)...)
-	errs = append(errs, p.Spec.Validate(field.NewPath("spec"), clusterResources, false)...)
+	errs = append(errs, p.Spec.Validate(field.NewPath("spec"), clusterResources, false, "")...)
 	return errs
 }
 
@@ -164,32 +169,341 @@ type CleanupPolicySpec struct {
 	// The schedule in Cron format
 	Schedule string `json:"schedule"`
 
+	// Context defines variables and data sources that can be used in conditions,
+	// entries are evaluated in order for each candidate resource.
+	// +optional
+	Context []CleanupContextEntry `json:"context,omitempty"`
+
 	// Conditions defines the conditions used to select the resources which will be cleaned up.
 	// +optional
 	Conditions *kyvernov2beta1.AnyAllConditions `json:"conditions,omitempty"`
+
+	// DryRun evaluates the policy without deleting anything. The resources that would
+	// have been deleted are recorded in the policy status, as events and in a policy report.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DeletionPropagationPolicy defines how the garbage collector handles the dependents of
+	// the deleted resources. Defaults to the default policy of each resource kind.
+	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
+	// +optional
+	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// MaxDeletionsPerRun is the maximum number of resources deleted in a single execution.
+	// The remaining resources are deleted during the next executions.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxDeletionsPerRun *int `json:"maxDeletionsPerRun,omitempty"`
+
+	// DeletionRateLimit is the maximum number of resources deleted per second.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DeletionRateLimit *int `json:"deletionRateLimit,omitempty"`
+
+	// PageSize is the maximum number of resources returned by a single list request when
+	// selecting the resources to delete. Defaults to listing all resources at once.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PageSize *int64 `json:"pageSize,omitempty"`
+
+	// ServiceAccountName is the name of the service account the policy executes with, the service
+	// account must live in the namespace of the policy. Resources are listed and deleted by impersonating
+	// the service account and deletions are authorized with SubjectAccessReviews.
+	// Required by namespaced cleanup policies and not supported by cluster cleanup policies.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// CleanupContextEntry adds a variable or data source to the cleanup policy context.
+type CleanupContextEntry struct {
+	// Name is the variable name.
+	Name string `json:"name"`
+
+	// ConfigMap is the ConfigMap reference.
+	// +optional
+	ConfigMap *kyvernov1.ConfigMapReference `json:"configMap,omitempty"`
+
+	// APICall is an HTTP request to the Kubernetes API server, or other JSON web service.
+	// The data returned is stored in the context with the name for the context entry.
+	// +optional
+	APICall *kyvernov1.APICall `json:"apiCall,omitempty"`
+
+	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
+	// +optional
+	Variable *kyvernov1.Variable `json:"variable,omitempty"`
+
+	// References looks up the resources referencing the target resource.
+	// The list of referencing resources is stored in the context with the name for the context entry.
+	// +optional
+	References *ReferencesLookup `json:"references,omitempty"`
+}
+
+// ContextEntry returns the equivalent policy context entry, references lookups have no equivalent
+func (e CleanupContextEntry) ContextEntry() kyvernov1.ContextEntry {
+	return kyvernov1.ContextEntry{
+		Name:      e.Name,
+		ConfigMap: e.ConfigMap,
+		APICall:   e.APICall,
+		Variable:  e.Variable,
+	}
 }
 
+// ReferencesLookup looks up the resources of a kind referencing the target resource. A resource references
+// the target when one of its owner references points to the target, or when the pod spec of a pod or pod
+// controller uses the target as a config map, secret, persistent volume claim or service account.
+type ReferencesLookup struct {
+	// Kind is the kind of the referencing resources (e.g. Pod or apps/v1/Deployment).
+	Kind string `json:"kind"`
+}
+
+// ServiceAccountUserInfo returns the user info of a service account, as seen by the API server
+func ServiceAccountUserInfo(namespace, name string) authenticationv1.UserInfo {
+	return authenticationv1.UserInfo{
+		Username: fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+		Groups: []string{
+			"system:serviceaccounts",
+			"system:serviceaccounts:" + namespace,
+			"system:authenticated",
+		},
+	}
+}
+
+const (
+	// CleanupConditionComplete means that the last execution deleted all the selected resources
+	CleanupConditionComplete = "Complete"
+)
+
+const (
+	// CleanupReasonSucceeded is the reason set when all the selected resources were deleted
+	CleanupReasonSucceeded = "Succeeded"
+	// CleanupReasonDeletionLimitReached is the reason set when the execution stopped after deleting
+	// the maximum number of resources per run, the next execution resumes with the remaining resources
+	CleanupReasonDeletionLimitReached = "DeletionLimitReached"
+	// CleanupReasonFailed is the reason set when some resources could not be selected or deleted
+	CleanupReasonFailed = "Failed"
+)
+
 // CleanupPolicyStatus stores the status of the policy.
 type CleanupPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// LastExecutionTime is the time of the last scheduled execution of the policy.
+	// +optional
+	LastExecutionTime *metav1.Time `json:"lastExecutionTime,omitempty"`
+
+	// NextExecutionTime is the time of the next scheduled execution of the policy.
+	// +optional
+	NextExecutionTime *metav1.Time `json:"nextExecutionTime,omitempty"`
+
+	// DryRun contains the outcome of the last dry run execution.
+	// +optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
+
+	// ResumeAfter is the last resource processed by an execution stopped by the deletion limit,
+	// the next execution resumes with the resources listed after it.
+	// +optional
+	ResumeAfter *CleanupResumePosition `json:"resumeAfter,omitempty"`
+}
+
+// CleanupResumePosition identifies a resource processed by a cleanup policy execution.
+// Resources are processed kind by kind, in the order of their keys.
+type CleanupResumePosition struct {
+	// Kind is the kind of the resource, as declared in the match statement of the policy.
+	Kind string `json:"kind"`
+
+	// Key is the namespace/name of the resource, or its name for cluster wide resources.
+	Key string `json:"key"`
+}
+
+// SetComplete records the outcome of an execution in the Complete condition
+func (status *CleanupPolicyStatus) SetComplete(generation int64, reason string, message string) {
+	condition := metav1.Condition{
+		Type:               CleanupConditionComplete,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}
+	if reason == CleanupReasonSucceeded {
+		condition.Status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// GetResumePosition returns the position the next execution resumes after, or nil if the last execution
+// of the current policy generation was not stopped by the deletion limit
+func (status *CleanupPolicyStatus) GetResumePosition(generation int64) *CleanupResumePosition {
+	condition := meta.FindStatusCondition(status.Conditions, CleanupConditionComplete)
+	if status.ResumeAfter == nil || condition == nil || condition.ObservedGeneration != generation {
+		return nil
+	}
+	return status.ResumeAfter
+}
+
+// DryRunStatus stores the resources a cleanup policy would have deleted.
+type DryRunStatus struct {
+	// LastExecutionTime is the time of the last dry run execution.
+	LastExecutionTime metav1.Time `json:"lastExecutionTime,omitempty"`
+
+	// Count is the number of resources that would have been deleted.
+	Count int `json:"count"`
+
+	// Resources lists the resources that would have been deleted, the list may be truncated.
+	// +optional
+	Resources []kyvernov1.ResourceSpec `json:"resources,omitempty"`
 }
 
 // Validate implements programmatic validation
-func (p *CleanupPolicySpec) Validate(path *field.Path, clusterResources sets.Set[string], namespaced bool) (errs field.ErrorList) {
+func (p *CleanupPolicySpec) Validate(path *field.Path, clusterResources sets.Set[string], namespaced bool, namespace string) (errs field.ErrorList) {
 	errs = append(errs, ValidateSchedule(path.Child("schedule"), p.Schedule)...)
-	if userInfoErrs := p.MatchResources.ValidateNoUserInfo(path.Child("match")); len(userInfoErrs) != 0 {
+	errs = append(errs, p.ValidateServiceAccountName(path.Child("serviceAccountName"), namespaced)...)
+	if userInfoErrs := p.ValidateUserInfo(path.Child("match"), p.MatchResources, namespace); len(userInfoErrs) != 0 {
 		errs = append(errs, userInfoErrs...)
 	} else {
 		errs = append(errs, p.MatchResources.Validate(path.Child("match"), namespaced, clusterResources)...)
 	}
 	if p.ExcludeResources != nil {
-		if userInfoErrs := p.ExcludeResources.ValidateNoUserInfo(path.Child("exclude")); len(userInfoErrs) != 0 {
+		if userInfoErrs := p.ValidateUserInfo(path.Child("exclude"), *p.ExcludeResources, namespace); len(userInfoErrs) != 0 {
 			errs = append(errs, userInfoErrs...)
 		} else {
 			errs = append(errs, p.ExcludeResources.Validate(path.Child("exclude"), namespaced, clusterResources)...)
 		}
 	}
 	errs = append(errs, p.ValidateMatchExcludeConflict(path)...)
+	errs = append(errs, p.ValidateContext(path.Child("context"))...)
+	errs = append(errs, p.ValidateDeletionOptions(path)...)
+	return errs
+}
+
+// ValidateContext checks each context entry has a unique name and declares exactly one data source
+func (p *CleanupPolicySpec) ValidateContext(path *field.Path) (errs field.ErrorList) {
+	names := sets.New[string]()
+	for i, entry := range p.Context {
+		entryPath := path.Index(i)
+		if entry.Name == "" {
+			errs = append(errs, field.Required(entryPath.Child("name"), ""))
+		} else if names.Has(entry.Name) {
+			errs = append(errs, field.Duplicate(entryPath.Child("name"), entry.Name))
+		}
+		names.Insert(entry.Name)
+		count := 0
+		if entry.ConfigMap != nil {
+			count++
+		}
+		if entry.APICall != nil {
+			count++
+		}
+		if entry.Variable != nil {
+			count++
+		}
+		if entry.References != nil {
+			count++
+			if entry.References.Kind == "" {
+				errs = append(errs, field.Required(entryPath.Child("references", "kind"), ""))
+			}
+		}
+		if count != 1 {
+			errs = append(errs, field.Invalid(entryPath, entry.Name, "exactly one of configMap, apiCall, variable or references must be specified"))
+		}
+	}
+	return errs
+}
+
+// ValidateServiceAccountName checks the service account name, namespaced policies must declare one
+// and cluster policies can't
+func (p *CleanupPolicySpec) ValidateServiceAccountName(path *field.Path, namespaced bool) (errs field.ErrorList) {
+	if p.ServiceAccountName == "" {
+		if namespaced {
+			errs = append(errs, field.Required(path, "namespaced cleanup policies must declare the service account they execute with"))
+		}
+		return errs
+	}
+	if !namespaced {
+		return append(errs, field.Forbidden(path, "service account is only supported by namespaced cleanup policies"))
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(p.ServiceAccountName) {
+		errs = append(errs, field.Invalid(path, p.ServiceAccountName, msg))
+	}
+	return errs
+}
+
+// ValidateUserInfo checks the user info clauses can match the user the policy executes with.
+// Roles and cluster roles can't be resolved when executing cleanup policies, subjects can only
+// match the service account of the policy.
+func (p *CleanupPolicySpec) ValidateUserInfo(path *field.Path, m kyvernov2beta1.MatchResources, namespace string) (errs field.ErrorList) {
+	validate := func(path *field.Path, userInfo kyvernov1.UserInfo) (errs field.ErrorList) {
+		if len(userInfo.Roles) != 0 {
+			errs = append(errs, field.Forbidden(path.Child("roles"), "roles can't be resolved when executing cleanup policies, the clause can never match"))
+		}
+		if len(userInfo.ClusterRoles) != 0 {
+			errs = append(errs, field.Forbidden(path.Child("clusterRoles"), "cluster roles can't be resolved when executing cleanup policies, the clause can never match"))
+		}
+		if len(userInfo.Subjects) != 0 {
+			if p.ServiceAccountName == "" || namespace == "" {
+				errs = append(errs, field.Forbidden(path.Child("subjects"), "the policy executes without a service account, the clause can never match"))
+			} else if !subjectsMatch(userInfo.Subjects, namespace, p.ServiceAccountName) {
+				errs = append(errs, field.Invalid(path.Child("subjects"), userInfo.Subjects, fmt.Sprintf("the policy executes with service account %s/%s, the clause can never match", namespace, p.ServiceAccountName)))
+			}
+		}
+		return errs
+	}
+	anyPath := path.Child("any")
+	for i, filter := range m.Any {
+		errs = append(errs, validate(anyPath.Index(i), filter.UserInfo)...)
+	}
+	allPath := path.Child("all")
+	for i, filter := range m.All {
+		errs = append(errs, validate(allPath.Index(i), filter.UserInfo)...)
+	}
+	return errs
+}
+
+// subjectsMatch returns true if one of the subjects matches the given service account
+func subjectsMatch(subjects []rbacv1.Subject, namespace, name string) bool {
+	userInfo := ServiceAccountUserInfo(namespace, name)
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case rbacv1.ServiceAccountKind:
+			if subject.Namespace == namespace && subject.Name == name {
+				return true
+			}
+		case rbacv1.UserKind:
+			if subject.Name == userInfo.Username {
+				return true
+			}
+		case rbacv1.GroupKind:
+			for _, group := range userInfo.Groups {
+				if subject.Name == group {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// ValidateDeletionOptions checks the deletion propagation policy, limits and page size
+func (p *CleanupPolicySpec) ValidateDeletionOptions(path *field.Path) (errs field.ErrorList) {
+	if p.DeletionPropagationPolicy != nil {
+		switch *p.DeletionPropagationPolicy {
+		case metav1.DeletePropagationForeground, metav1.DeletePropagationBackground, metav1.DeletePropagationOrphan:
+		default:
+			errs = append(errs, field.NotSupported(path.Child("deletionPropagationPolicy"), *p.DeletionPropagationPolicy, []string{
+				string(metav1.DeletePropagationForeground),
+				string(metav1.DeletePropagationBackground),
+				string(metav1.DeletePropagationOrphan),
+			}))
+		}
+	}
+	if p.MaxDeletionsPerRun != nil && *p.MaxDeletionsPerRun < 1 {
+		errs = append(errs, field.Invalid(path.Child("maxDeletionsPerRun"), *p.MaxDeletionsPerRun, "must be greater than zero"))
+	}
+	if p.DeletionRateLimit != nil && *p.DeletionRateLimit < 1 {
+		errs = append(errs, field.Invalid(path.Child("deletionRateLimit"), *p.DeletionRateLimit, "must be greater than zero"))
+	}
+	if p.PageSize != nil && *p.PageSize < 1 {
+		errs = append(errs, field.Invalid(path.Child("pageSize"), *p.PageSize, "must be greater than zero"))
+	}
 	return errs
 }
 
@@ -0,0 +1,44 @@
+package v2alpha1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_ComplianceFramework_Validate(t *testing.T) {
+	subject := ComplianceFramework{
+		Spec: ComplianceFrameworkSpec{
+			Framework: "PSS",
+			Controls: []ComplianceControl{
+				{ID: "host-path", Policies: []ComplianceControlPolicy{{Name: "disallow-host-path"}}},
+				{ID: "host-path", Policies: []ComplianceControlPolicy{{}}},
+				{Policies: nil},
+			},
+		},
+	}
+	errs := subject.Validate()
+	assert.Equal(t, len(errs), 4)
+	assert.Equal(t, errs[0].Field, "spec.controls[1].id")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeDuplicate)
+	assert.Equal(t, errs[1].Field, "spec.controls[1].policies[0].name")
+	assert.Equal(t, errs[2].Field, "spec.controls[2].id")
+	assert.Equal(t, errs[3].Field, "spec.controls[2].policies")
+}
+
+func Test_ComplianceControl_Matches(t *testing.T) {
+	control := ComplianceControl{
+		ID: "privileged",
+		Policies: []ComplianceControlPolicy{
+			{Name: "disallow-privileged", Rules: []string{"check"}},
+			{Name: "default/restrict-capabilities"},
+		},
+	}
+	assert.Assert(t, control.Matches("disallow-privileged", "check"))
+	assert.Assert(t, control.Matches("disallow-privileged", "autogen-check"))
+	assert.Assert(t, control.Matches("disallow-privileged", "autogen-cronjob-check"))
+	assert.Assert(t, !control.Matches("disallow-privileged", "other"))
+	assert.Assert(t, control.Matches("default/restrict-capabilities", "any"))
+	assert.Assert(t, !control.Matches("restrict-capabilities", "any"))
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=compfw,categories=kyverno
+// +kubebuilder:printcolumn:name="Framework",type=string,JSONPath=".spec.framework"
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ComplianceFramework maps the controls of a compliance framework (CIS, NSA, PSS...) to the policies and rules checking them.
+// The reports controller computes a ComplianceReport with the same name from the policy reports.
+type ComplianceFramework struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the framework controls.
+	Spec ComplianceFrameworkSpec `json:"spec"`
+}
+
+// Validate implements programmatic validation
+func (f *ComplianceFramework) Validate() (errs field.ErrorList) {
+	return f.Spec.Validate(field.NewPath("spec"))
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplianceFrameworkList is a list of ComplianceFramework instances.
+type ComplianceFrameworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ComplianceFramework `json:"items"`
+}
+
+// ComplianceFrameworkSpec declares the controls of a compliance framework.
+type ComplianceFrameworkSpec struct {
+	// Framework is the name of the compliance framework, for example CIS, NSA or PSS.
+	Framework string `json:"framework"`
+
+	// Version is the version of the compliance framework.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Controls maps the controls of the framework to policies and rules.
+	Controls []ComplianceControl `json:"controls"`
+}
+
+// Validate implements programmatic validation
+func (s *ComplianceFrameworkSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.Framework == "" {
+		errs = append(errs, field.Required(path.Child("framework"), "a framework name is required"))
+	}
+	if len(s.Controls) == 0 {
+		errs = append(errs, field.Required(path.Child("controls"), "at least one control is required"))
+	}
+	ids := sets.New[string]()
+	for i, control := range s.Controls {
+		controlPath := path.Child("controls").Index(i)
+		if control.ID == "" {
+			errs = append(errs, field.Required(controlPath.Child("id"), "a control id is required"))
+		} else if ids.Has(control.ID) {
+			errs = append(errs, field.Duplicate(controlPath.Child("id"), control.ID))
+		}
+		ids.Insert(control.ID)
+		errs = append(errs, control.Validate(controlPath)...)
+	}
+	return errs
+}
+
+// ComplianceControl associates a control of a compliance framework with the policies and rules checking it.
+type ComplianceControl struct {
+	// ID identifies the control in the framework, for example 5.2.1.
+	ID string `json:"id"`
+
+	// Title is a short description of the control.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Policies are the policies and rules checking the control.
+	Policies []ComplianceControlPolicy `json:"policies"`
+}
+
+// Validate implements programmatic validation
+func (c *ComplianceControl) Validate(path *field.Path) (errs field.ErrorList) {
+	if len(c.Policies) == 0 {
+		errs = append(errs, field.Required(path.Child("policies"), "at least one policy is required"))
+	}
+	for i, policy := range c.Policies {
+		if policy.Name == "" {
+			errs = append(errs, field.Required(path.Child("policies").Index(i).Child("name"), "a policy name is required"))
+		}
+	}
+	return errs
+}
+
+// Matches returns true if a policy report result for the given policy and rule checks the control
+func (c *ComplianceControl) Matches(policy, rule string) bool {
+	for _, p := range c.Policies {
+		if p.Matches(policy, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// ComplianceControlPolicy references a policy and optionally some of its rules.
+type ComplianceControlPolicy struct {
+	// Name is the name of a cluster policy, or namespace/name for a namespaced policy.
+	Name string `json:"name"`
+
+	// Rules are the names of the rules of the policy checking the control, all the rules of the policy are considered when empty.
+	// Rules auto-generated for pod controllers are matched too.
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+}
+
+// Matches returns true if a policy report result for the given policy and rule is covered by the reference
+func (p *ComplianceControlPolicy) Matches(policy, rule string) bool {
+	if p.Name != policy {
+		return false
+	}
+	if len(p.Rules) == 0 {
+		return true
+	}
+	for _, r := range p.Rules {
+		if r == rule || autogenRuleName("autogen", r) == rule || autogenRuleName("autogen-cronjob", r) == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// autogenRuleName returns the name of a rule auto-generated for pod controllers, truncated to 63 characters
+func autogenRuleName(prefix, name string) string {
+	name = prefix + "-" + name
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return name
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControlStatus is the compliance status of a control
+type ControlStatus string
+
+const (
+	// ControlPass means all the results of the policies checking the control passed
+	ControlPass ControlStatus = "Pass"
+	// ControlFail means at least one result of the policies checking the control failed or errored
+	ControlFail ControlStatus = "Fail"
+	// ControlNotEvaluated means no policy report result was found for the control, it is not scored
+	ControlNotEvaluated ControlStatus = "NotEvaluated"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=compr,categories=kyverno
+// +kubebuilder:printcolumn:name="Framework",type=string,JSONPath=".framework"
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".version"
+// +kubebuilder:printcolumn:name="Score",type=integer,JSONPath=".summary.score"
+// +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=".summary.pass"
+// +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=".summary.fail"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ComplianceReport holds the compliance scores of a framework computed from the policy reports,
+// it is owned by the ComplianceFramework with the same name.
+type ComplianceReport struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Framework is the name of the compliance framework.
+	Framework string `json:"framework"`
+
+	// Version is the version of the compliance framework.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Summary is the compliance score of the whole cluster, a control passes when it passes in all namespaces.
+	Summary ComplianceScore `json:"summary"`
+
+	// Controls is the compliance status of each control in the whole cluster.
+	// +optional
+	Controls []ControlCompliance `json:"controls,omitempty"`
+
+	// Namespaces holds the compliance scores of each namespace, cluster wide resources are reported with an empty namespace.
+	// +optional
+	Namespaces []NamespaceCompliance `json:"namespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplianceReportList is a list of ComplianceReport instances.
+type ComplianceReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ComplianceReport `json:"items"`
+}
+
+// ComplianceScore counts the controls per status.
+type ComplianceScore struct {
+	// Score is the percentage of passing controls among the evaluated controls, 0 when no control was evaluated.
+	Score int `json:"score"`
+
+	// Pass is the number of passing controls.
+	Pass int `json:"pass"`
+
+	// Fail is the number of failing controls.
+	Fail int `json:"fail"`
+
+	// NotEvaluated is the number of controls without policy report results.
+	NotEvaluated int `json:"notEvaluated"`
+}
+
+// ControlCompliance is the compliance status of a control.
+type ControlCompliance struct {
+	// ID identifies the control in the framework.
+	ID string `json:"id"`
+
+	// Title is a short description of the control.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Status is the status of the control, Pass, Fail or NotEvaluated.
+	Status ControlStatus `json:"status"`
+
+	// Pass is the number of passing policy report results for the control.
+	Pass int `json:"pass"`
+
+	// Fail is the number of failing or errored policy report results for the control.
+	Fail int `json:"fail"`
+}
+
+// NamespaceCompliance holds the compliance score of a namespace.
+type NamespaceCompliance struct {
+	// Namespace is the namespace name, empty for cluster wide resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Score is the compliance score of the namespace.
+	Score ComplianceScore `json:"score"`
+
+	// FailedControls are the ids of the controls failing in the namespace.
+	// +optional
+	FailedControls []string `json:"failedControls,omitempty"`
+}
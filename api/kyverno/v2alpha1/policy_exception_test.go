@@ -0,0 +1,70 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func Test_PolicyException_IsActive(t *testing.T) {
+	now := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	before := metav1.NewTime(now.Add(-time.Hour))
+	after := metav1.NewTime(now.Add(time.Hour))
+	assert.Assert(t, (&PolicyExceptionSpec{}).IsActive(now))
+	assert.Assert(t, (&PolicyExceptionSpec{NotBefore: &before, ExpiresAt: &after}).IsActive(now))
+	assert.Assert(t, !(&PolicyExceptionSpec{NotBefore: &after}).IsActive(now))
+	assert.Assert(t, !(&PolicyExceptionSpec{ExpiresAt: &before}).IsActive(now))
+	assert.Assert(t, !(&PolicyExceptionSpec{ExpiresAt: &metav1.Time{Time: now}}).IsActive(now))
+}
+
+func Test_PolicyException_TimeBounds(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC))
+	subject := PolicyExceptionSpec{
+		NotBefore: &now,
+		ExpiresAt: &now,
+	}
+	errs := subject.Validate(field.NewPath("spec"))
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.expiresAt")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+}
+
+func Test_PolicyException_Approval(t *testing.T) {
+	path := field.NewPath("spec", "approval")
+	subject := PolicyExceptionSpec{}
+	assert.Assert(t, len(subject.ValidateApproval(path, nil)) == 0)
+	errs := subject.ValidateApproval(path, []string{ApprovalOwner, ApprovalJustification})
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.approval.owner")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeRequired)
+	subject.Approval = &ExceptionApproval{
+		Owner:         "team-a",
+		Justification: "legacy workload",
+	}
+	assert.Assert(t, len(subject.ValidateApproval(path, []string{ApprovalOwner, ApprovalJustification})) == 0)
+	errs = subject.ValidateApproval(path, []string{ApprovalTicket})
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.approval.ticket")
+}
+
+func Test_PolicyException_PartialExemptions(t *testing.T) {
+	assert.Assert(t, !(&PolicyExceptionSpec{}).IsPartial())
+	assert.Assert(t, (&PolicyExceptionSpec{Containers: &ContainerSelector{Names: []string{"istio-proxy"}}}).IsPartial())
+	subject := PolicyExceptionSpec{
+		Containers:  &ContainerSelector{},
+		PodSecurity: []kyvernov1.PodSecurityStandard{{}},
+	}
+	assert.Assert(t, subject.IsPartial())
+	errs := subject.Validate(field.NewPath("spec"))
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.podSecurity[0].controlName")
+	assert.Equal(t, errs[1].Field, "spec.containers")
+	selector := ContainerSelector{Names: []string{"istio-*"}, Images: []string{"ghcr.io/example/*"}}
+	assert.Assert(t, selector.Matches("istio-proxy", "docker.io/istio/proxyv2:1.16.1"))
+	assert.Assert(t, selector.Matches("app", "ghcr.io/example/app:v1"))
+	assert.Assert(t, !selector.Matches("app", "nginx:1.23"))
+}
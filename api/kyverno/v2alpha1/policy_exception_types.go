@@ -19,9 +19,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"time"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	wildcard "github.com/kyverno/kyverno/pkg/utils/wildcard"
 	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -32,6 +36,12 @@ import (
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:resource:shortName=polex,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".spec.expiresAt"
+// +kubebuilder:printcolumn:name="Active",type=string,JSONPath=".status.conditions[?(@.type == 'Active')].status"
+// +kubebuilder:printcolumn:name="Matches",type=integer,JSONPath=".status.usage.matchCount"
+// +kubebuilder:printcolumn:name="Last Used",type="date",JSONPath=".status.usage.lastUsedTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // PolicyException declares resources to be excluded from specified policies.
 type PolicyException struct {
@@ -40,6 +50,10 @@ type PolicyException struct {
 
 	// Spec declares policy exception behaviors.
 	Spec PolicyExceptionSpec `json:"spec"`
+
+	// Status contains policy exception runtime data.
+	// +optional
+	Status PolicyExceptionStatus `json:"status,omitempty"`
 }
 
 // regexVariables represents regex for '{{}}'
@@ -54,7 +68,17 @@ func (p *PolicyException) Validate() (errs field.ErrorList) {
 	return errs
 }
 
+// IsActive returns true if the exception is within its validity window at the given time
+func (p *PolicyException) IsActive(now time.Time) bool {
+	return p.Spec.IsActive(now)
+}
+
+// ValidateVariables returns an error if the exception uses variables outside of its conditions
 func ValidateVariables(polex *PolicyException) error {
+	if polex.Spec.Conditions != nil {
+		polex = polex.DeepCopy()
+		polex.Spec.Conditions = nil
+	}
 	return objectHasVariables(polex)
 }
 
@@ -85,8 +109,161 @@ type PolicyExceptionSpec struct {
 	// Match defines match clause used to check if a resource applies to the exception
 	Match kyvernov2beta1.MatchResources `json:"match"`
 
+	// Conditions are used to determine if the exception applies to the resource being admitted, they are
+	// evaluated with the full engine context (e.g. request.operation, request.object.metadata.annotations).
+	// Variables are allowed in conditions only. Conditions relying on admission request data do not pass
+	// during background scans.
+	// +optional
+	Conditions *kyvernov2beta1.AnyAllConditions `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+
 	// Exceptions is a list policy/rules to be excluded
 	Exceptions []Exception `json:"exceptions"`
+
+	// NotBefore is the time before which the exception is not applied.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty" yaml:"notBefore,omitempty"`
+
+	// ExpiresAt is the time after which the exception is not applied anymore.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+
+	// Approval records who owns the exception and why it was granted. The fields required
+	// are defined in the Kyverno configuration.
+	// +optional
+	Approval *ExceptionApproval `json:"approval,omitempty" yaml:"approval,omitempty"`
+
+	// PodSecurity specifies the Pod Security Standard controls to be exempted, with the same semantics as
+	// the exclusions of a validate.podSecurity rule. When set, the exception exempts these controls only
+	// instead of the whole rule. Applicable only to validate.podSecurity rules.
+	// +optional
+	PodSecurity []kyvernov1.PodSecurityStandard `json:"podSecurity,omitempty" yaml:"podSecurity,omitempty"`
+
+	// Containers selects the containers exempted from the rules. When set, the exception exempts the selected
+	// containers and foreach elements only instead of the whole resource. When combined with podSecurity, the
+	// controls are exempted for the selected containers only. Applicable only to validate rules.
+	// +optional
+	Containers *ContainerSelector `json:"containers,omitempty" yaml:"containers,omitempty"`
+}
+
+// ContainerSelector selects containers by name or by image.
+type ContainerSelector struct {
+	// Names selects the containers by name. Wildcards ('*' and '?') are allowed.
+	// +optional
+	Names []string `json:"names,omitempty" yaml:"names,omitempty"`
+
+	// Images selects the containers by image. Each image is the image name consisting of the registry
+	// address, repository, image, and tag. Wildcards ('*' and '?') are allowed.
+	// +optional
+	Images []string `json:"images,omitempty" yaml:"images,omitempty"`
+}
+
+// Matches returns true if the container with the given name and image is selected
+func (s *ContainerSelector) Matches(name, image string) bool {
+	return wildcard.CheckPatterns(s.Names, name) || wildcard.CheckPatterns(s.Images, image)
+}
+
+// ExceptionApproval records who owns a policy exception and why it was granted.
+type ExceptionApproval struct {
+	// Owner is the person or team responsible for the exception.
+	// +optional
+	Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+
+	// Justification explains why the exception was granted.
+	// +optional
+	Justification string `json:"justification,omitempty" yaml:"justification,omitempty"`
+
+	// Ticket references the request tracking the exception approval.
+	// +optional
+	Ticket string `json:"ticket,omitempty" yaml:"ticket,omitempty"`
+}
+
+// Approval fields that can be required by the configuration
+const (
+	ApprovalOwner         = "owner"
+	ApprovalJustification = "justification"
+	ApprovalTicket        = "ticket"
+)
+
+// PolicyExceptionStatus stores the status of the policy exception.
+type PolicyExceptionStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// Usage aggregates the policy report results relying on the exception.
+	// +optional
+	Usage *ExceptionUsage `json:"usage,omitempty"`
+}
+
+// ExceptionUsage aggregates the policy report results relying on a policy exception.
+type ExceptionUsage struct {
+	// MatchCount is the number of policy report results relying on the exception.
+	MatchCount int `json:"matchCount"`
+
+	// Rules lists the number of policy report results relying on the exception per policy rule.
+	// +optional
+	Rules []ExceptionRuleUsage `json:"rules,omitempty"`
+
+	// LastUsedTime is the time of the most recent policy report result relying on the exception.
+	// +optional
+	LastUsedTime *metav1.Time `json:"lastUsedTime,omitempty"`
+}
+
+// ExceptionRuleUsage is the number of policy report results of a policy rule relying on a policy exception.
+type ExceptionRuleUsage struct {
+	// PolicyName is the name of the policy, it uses the format <namespace>/<name> for namespaced policies.
+	PolicyName string `json:"policyName"`
+
+	// RuleName is the name of the rule.
+	RuleName string `json:"ruleName"`
+
+	// MatchCount is the number of policy report results of the rule relying on the exception.
+	MatchCount int `json:"matchCount"`
+}
+
+const (
+	// PolicyExceptionConditionActive means that the exception is within its validity window
+	PolicyExceptionConditionActive = "Active"
+)
+
+const (
+	// PolicyExceptionReasonActive is the reason set when the exception is applied
+	PolicyExceptionReasonActive = "Active"
+	// PolicyExceptionReasonExpiring is the reason set when the exception is applied but expires soon
+	PolicyExceptionReasonExpiring = "Expiring"
+	// PolicyExceptionReasonNotYetValid is the reason set when the exception validity window didn't start yet
+	PolicyExceptionReasonNotYetValid = "NotYetValid"
+	// PolicyExceptionReasonExpired is the reason set when the exception expired
+	PolicyExceptionReasonExpired = "Expired"
+)
+
+// SetActive records the validity of the exception in the Active condition
+func (status *PolicyExceptionStatus) SetActive(generation int64, reason string, message string) {
+	condition := metav1.Condition{
+		Type:               PolicyExceptionConditionActive,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	}
+	if reason == PolicyExceptionReasonActive || reason == PolicyExceptionReasonExpiring {
+		condition.Status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsActive returns true if the exception is within its validity window at the given time
+func (p *PolicyExceptionSpec) IsActive(now time.Time) bool {
+	if p.NotBefore != nil && now.Before(p.NotBefore.Time) {
+		return false
+	}
+	if p.ExpiresAt != nil && !now.Before(p.ExpiresAt.Time) {
+		return false
+	}
+	return true
+}
+
+// IsPartial returns true if the exception exempts specific controls or containers instead of whole rules
+func (p *PolicyExceptionSpec) IsPartial() bool {
+	return len(p.PodSecurity) != 0 || p.Containers != nil
 }
 
 func (p *PolicyExceptionSpec) BackgroundProcessingEnabled() bool {
@@ -108,6 +285,42 @@ func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList)
 	for i, e := range p.Exceptions {
 		errs = append(errs, e.Validate(exceptionsPath.Index(i))...)
 	}
+	if p.NotBefore != nil && p.ExpiresAt != nil && !p.NotBefore.Before(p.ExpiresAt) {
+		errs = append(errs, field.Invalid(path.Child("expiresAt"), p.ExpiresAt.String(), "must be after notBefore"))
+	}
+	for i, control := range p.PodSecurity {
+		if control.ControlName == "" {
+			errs = append(errs, field.Required(path.Child("podSecurity").Index(i).Child("controlName"), "a control name is required"))
+		}
+	}
+	if p.Containers != nil && len(p.Containers.Names) == 0 && len(p.Containers.Images) == 0 {
+		errs = append(errs, field.Required(path.Child("containers"), "at least one name or image is required"))
+	}
+	return errs
+}
+
+// ValidateApproval checks the given approval fields are set
+func (p *PolicyExceptionSpec) ValidateApproval(path *field.Path, required []string) (errs field.ErrorList) {
+	var approval ExceptionApproval
+	if p.Approval != nil {
+		approval = *p.Approval
+	}
+	for _, name := range required {
+		var value string
+		switch name {
+		case ApprovalOwner:
+			value = approval.Owner
+		case ApprovalJustification:
+			value = approval.Justification
+		case ApprovalTicket:
+			value = approval.Ticket
+		default:
+			continue
+		}
+		if value == "" {
+			errs = append(errs, field.Required(path.Child(name), "required by the Kyverno configuration"))
+		}
+	}
 	return errs
 }
 
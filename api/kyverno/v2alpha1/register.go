@@ -53,6 +53,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CleanupPolicyList{},
 		&ClusterCleanupPolicy{},
 		&ClusterCleanupPolicyList{},
+		&ComplianceFramework{},
+		&ComplianceFrameworkList{},
+		&ComplianceReport{},
+		&ComplianceReportList{},
 		&PolicyException{},
 		&PolicyExceptionList{},
 	)
@@ -60,6 +60,10 @@ type Rule struct {
 	// VerifyImages is used to verify image signatures and mutate them to add a digest
 	// +optional
 	VerifyImages []ImageVerification `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+
+	// ReportMetadata is added to the policy report results of the rule.
+	// +optional
+	ReportMetadata *kyvernov1.ReportMetadata `json:"reportMetadata,omitempty" yaml:"reportMetadata,omitempty"`
 }
 
 // HasMutate checks for mutate rule
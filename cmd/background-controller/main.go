@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/cmd/internal"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/background"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
@@ -75,6 +76,8 @@ func createrLeaderControllers(
 	metricsConfig metrics.MetricsConfigManager,
 	eventGenerator event.Interface,
 	configMapResolver engineapi.ConfigmapResolver,
+	mutateReportsWriter admissionreports.Writer,
+	generateReportsWriter admissionreports.Writer,
 ) ([]internal.Controller, error) {
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
@@ -106,6 +109,8 @@ func createrLeaderControllers(
 		eventGenerator,
 		configuration,
 		configMapResolver,
+		mutateReportsWriter,
+		generateReportsWriter,
 	)
 	return []internal.Controller{
 		internal.NewController("policy-controller", policyCtrl, 2),
@@ -121,6 +126,10 @@ func main() {
 		imageSignatureRepository  string
 		allowInsecureRegistry     bool
 		leaderElectionRetryPeriod time.Duration
+		mutateReports             bool
+		generateReports           bool
+		admissionReportsStore     string
+		admissionReportsUrl       string
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
@@ -129,6 +138,10 @@ func main() {
 	flagset.BoolVar(&allowInsecureRegistry, "allowInsecureRegistry", false, "Whether to allow insecure connections to registries. Don't use this for anything but testing.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flagset.BoolVar(&mutateReports, "mutateReports", false, "Create admission reports with the results of the mutate existing rules applied by the background controller.")
+	flagset.BoolVar(&generateReports, "generateReports", false, "Create admission reports with the results of the generate rules applied by the background controller.")
+	flagset.StringVar(&admissionReportsStore, "admissionReportsStore", admissionreports.StoreEtcd, "Where admission reports are stored, etcd or memory (in the reports controller).")
+	flagset.StringVar(&admissionReportsUrl, "admissionReportsUrl", "", "HTTPS URL of the reports controller API service admission reports are sent to, in the https://<name>.<namespace>.svc:<port> form, required when admissionReportsStore is memory.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -170,6 +183,21 @@ func main() {
 		os.Exit(1)
 	}
 	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister().Secrets(config.KyvernoNamespace())
+	// setup admission reports writers
+	var mutateReportsWriter, generateReportsWriter admissionreports.Writer
+	if mutateReports || generateReports {
+		writer, err := admissionreports.NewWriter(kyvernoClient, admissionReportsStore, admissionReportsUrl, admissionreports.NewRootCAProvider(secretLister, admissionReportsUrl))
+		if err != nil {
+			logger.Error(err, "invalid admission reports store configuration")
+			os.Exit(1)
+		}
+		if mutateReports {
+			mutateReportsWriter = writer
+		}
+		if generateReports {
+			generateReportsWriter = writer
+		}
+	}
 	// setup registry client
 	rclient, err := setupRegistryClient(signalCtx, logger, secretLister, imagePullSecrets, allowInsecureRegistry)
 	if err != nil {
@@ -252,6 +280,8 @@ func main() {
 				metricsConfig,
 				eventGenerator,
 				configMapResolver,
+				mutateReportsWriter,
+				generateReportsWriter,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -2,13 +2,16 @@ package admission
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers/cleanup/ttl"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	validation "github.com/kyverno/kyverno/pkg/validation/cleanuppolicy"
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type handlers struct {
@@ -27,9 +30,22 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 		logger.Error(err, "failed to unmarshal policies from admission request")
 		return admissionutils.Response(request.UID, err)
 	}
-	if err := validation.Validate(ctx, logger, h.client, policy); err != nil {
+	if err := validation.Validate(ctx, logger, h.client, policy, request.UserInfo); err != nil {
 		logger.Error(err, "policy validation errors")
 		return admissionutils.Response(request.UID, err)
 	}
 	return nil
 }
+
+func (h *handlers) ValidateTTL(_ context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, _ time.Time) *admissionv1.AdmissionResponse {
+	var metadata metav1.PartialObjectMetadata
+	if err := json.Unmarshal(request.Object.Raw, &metadata); err != nil {
+		logger.Error(err, "failed to unmarshal resource from admission request")
+		return admissionutils.Response(request.UID, err)
+	}
+	if err := ttl.Validate(metadata.GetLabels()); err != nil {
+		logger.Info("invalid ttl label", "error", err.Error())
+		return admissionutils.Response(request.UID, err)
+	}
+	return nil
+}
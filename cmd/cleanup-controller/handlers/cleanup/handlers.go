@@ -2,52 +2,75 @@ package cleanup
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
-	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/auth"
+	"github.com/kyverno/kyverno/pkg/cleanup"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
-	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/event"
-	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
-	"github.com/kyverno/kyverno/pkg/utils/match"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"go.uber.org/multierr"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/sets"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
+// ImpersonateFunc returns a client impersonating the given user
+type ImpersonateFunc = func(authenticationv1.UserInfo) (dclient.Interface, error)
+
 type handlers struct {
-	client     dclient.Interface
-	cpolLister kyvernov2alpha1listers.ClusterCleanupPolicyLister
-	polLister  kyvernov2alpha1listers.CleanupPolicyLister
-	nsLister   corev1listers.NamespaceLister
-	recorder   record.EventRecorder
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+	cpolLister    kyvernov2alpha1listers.ClusterCleanupPolicyLister
+	polLister     kyvernov2alpha1listers.CleanupPolicyLister
+	nsLister      corev1listers.NamespaceLister
+	recorder      record.EventRecorder
+	impersonate   ImpersonateFunc
+	references    cleanup.ReferenceResolver
+
+	// impersonated clients, indexed by user name
+	lock    sync.Mutex
+	clients map[string]dclient.Interface
 }
 
 func New(
 	client dclient.Interface,
+	kyvernoClient versioned.Interface,
 	cpolLister kyvernov2alpha1listers.ClusterCleanupPolicyLister,
 	polLister kyvernov2alpha1listers.CleanupPolicyLister,
 	nsLister corev1listers.NamespaceLister,
+	impersonate ImpersonateFunc,
+	references cleanup.ReferenceResolver,
 ) *handlers {
 	return &handlers{
-		client:     client,
-		cpolLister: cpolLister,
-		polLister:  polLister,
-		nsLister:   nsLister,
-		recorder:   event.NewRecorder(event.CleanupController, client.GetEventsInterface()),
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		cpolLister:    cpolLister,
+		polLister:     polLister,
+		nsLister:      nsLister,
+		recorder:      event.NewRecorder(event.CleanupController, client.GetEventsInterface()),
+		impersonate:   impersonate,
+		references:    references,
+		clients:       map[string]dclient.Interface{},
 	}
 }
 
-func (h *handlers) Cleanup(ctx context.Context, logger logr.Logger, name string, _ time.Time, cfg config.Configuration) error {
+func (h *handlers) Cleanup(ctx context.Context, logger logr.Logger, name string, now time.Time, cfg config.Configuration) error {
 	logger.Info("cleaning up...")
 	defer logger.Info("done")
 	namespace, name, err := cache.SplitMetaNamespaceKey(name)
@@ -58,7 +81,7 @@ func (h *handlers) Cleanup(ctx context.Context, logger logr.Logger, name string,
 	if err != nil {
 		return err
 	}
-	return h.executePolicy(ctx, logger, policy, cfg)
+	return h.executePolicy(ctx, logger, policy, now, cfg)
 }
 
 func (h *handlers) lookupPolicy(namespace, name string) (kyvernov2alpha1.CleanupPolicyInterface, error) {
@@ -69,125 +92,265 @@ func (h *handlers) lookupPolicy(namespace, name string) (kyvernov2alpha1.Cleanup
 	}
 }
 
-func (h *handlers) executePolicy(ctx context.Context, logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface, cfg config.Configuration) error {
-	spec := policy.GetSpec()
-	kinds := sets.New(spec.MatchResources.GetKinds()...)
-	debug := logger.V(4)
+func (h *handlers) namespaceLabels(namespace string) (map[string]string, error) {
+	ns, err := h.nsLister.Get(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return ns.GetLabels(), nil
+}
+
+// policyClient returns the client a policy executes with, policies declaring a service account
+// execute with a client impersonating the service account, only cluster policies execute with the
+// cleanup controller permissions
+func (h *handlers) policyClient(policy kyvernov2alpha1.CleanupPolicyInterface) (dclient.Interface, error) {
+	userInfo, ok := cleanup.UserInfo(policy)
+	if !ok {
+		if policy.GetNamespace() != "" {
+			return nil, fmt.Errorf("cleanup policy %s/%s doesn't declare a service account", policy.GetNamespace(), policy.GetName())
+		}
+		return h.client, nil
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if client, ok := h.clients[userInfo.Username]; ok {
+		return client, nil
+	}
+	client, err := h.impersonate(userInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client impersonating %s: %w", userInfo.Username, err)
+	}
+	h.clients[userInfo.Username] = client
+	return client, nil
+}
+
+// deletionAllowed checks with a SubjectAccessReview that the service account of a policy can delete a resource,
+// results are cached per kind and namespace in the given map
+func (h *handlers) deletionAllowed(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured, allowed map[string]bool) (bool, error) {
+	userInfo, ok := cleanup.UserInfo(policy)
+	if !ok {
+		return true, nil
+	}
+	kind := resource.GetAPIVersion() + "/" + resource.GetKind()
+	key := kind + "/" + resource.GetNamespace()
+	if result, ok := allowed[key]; ok {
+		return result, nil
+	}
+	checker := auth.NewCanIForUser(h.client.Discovery(), h.client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), userInfo, kind, resource.GetNamespace(), "delete", "")
+	result, err := checker.RunAccessCheck(ctx)
+	if err != nil {
+		return false, err
+	}
+	allowed[key] = result
+	return result, nil
+}
+
+// deletableResources filters out the resources the service account of a policy is not allowed to delete,
+// dry runs only report the resources a real run would delete
+func (h *handlers) deletableResources(ctx context.Context, logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface, resources []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	var deletable []unstructured.Unstructured
 	var errs []error
-	for kind := range kinds {
-		debug := debug.WithValues("kind", kind)
-		debug.Info("processing...")
-		list, err := h.client.ListResource(ctx, "", kind, policy.GetNamespace(), nil)
+	allowed := map[string]bool{}
+	for _, resource := range resources {
+		if ok, err := h.deletionAllowed(ctx, policy, resource, allowed); err != nil || !ok {
+			if err == nil {
+				err = fmt.Errorf("service account %s is not allowed to delete %s in namespace %s", policy.GetSpec().ServiceAccountName, resource.GetKind(), resource.GetNamespace())
+			}
+			logger.V(4).Error(err, "deletion not authorized", "kind", resource.GetKind(), "name", resource.GetName(), "namespace", resource.GetNamespace())
+			errs = append(errs, err)
+			continue
+		}
+		deletable = append(deletable, resource)
+	}
+	return deletable, multierr.Combine(errs...)
+}
+
+// policyReferences returns the references resolver a policy executes with, for policies declaring a service account
+// lookups are allowed only if the service account can list the referencing kind in the target namespace
+func (h *handlers) policyReferences(policy kyvernov2alpha1.CleanupPolicyInterface) cleanup.ReferenceResolver {
+	userInfo, ok := cleanup.UserInfo(policy)
+	if !ok {
+		return h.references
+	}
+	allowed := map[string]bool{}
+	var lock sync.Mutex
+	return cleanup.NewAuthorizedReferenceResolver(h.references, func(ctx context.Context, kind, namespace string) (bool, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		key := kind + "/" + namespace
+		if result, ok := allowed[key]; ok {
+			return result, nil
+		}
+		checker := auth.NewCanIForUser(h.client.Discovery(), h.client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), userInfo, kind, namespace, "list", "")
+		result, err := checker.RunAccessCheck(ctx)
 		if err != nil {
-			debug.Error(err, "failed to list resources")
+			return false, err
+		}
+		allowed[key] = result
+		return result, nil
+	})
+}
+
+func (h *handlers) executePolicy(ctx context.Context, logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface, now time.Time, cfg config.Configuration) error {
+	client, err := h.policyClient(policy)
+	if err != nil {
+		return err
+	}
+	references := h.policyReferences(policy)
+	if policy.GetSpec().DryRun {
+		resources, err := cleanup.Evaluate(ctx, logger, client, h.namespaceLabels, references, policy, cfg)
+		resources, authErr := h.deletableResources(ctx, logger, policy, resources)
+		return multierr.Combine(err, authErr, h.recordDryRun(ctx, logger, policy, resources, now))
+	}
+	var errs []error
+	if policy.GetStatus().DryRun != nil {
+		errs = append(errs, h.clearDryRun(ctx, policy))
+	}
+	spec := policy.GetSpec()
+	var limiter flowcontrol.RateLimiter
+	if spec.DeletionRateLimit != nil {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(float32(*spec.DeletionRateLimit), *spec.DeletionRateLimit)
+	}
+	options := metav1.DeleteOptions{
+		PropagationPolicy: spec.DeletionPropagationPolicy,
+	}
+	debug := logger.V(4)
+	deleted, limitReached := 0, false
+	// last is the last processed resource, the next execution resumes after it when the deletion limit is reached
+	var last *kyvernov2alpha1.CleanupResumePosition
+	allowed := map[string]bool{}
+	resumeAfter := policy.GetStatus().GetResumePosition(policy.GetGeneration())
+	walkErr := cleanup.Walk(ctx, logger, client, h.namespaceLabels, references, policy, cfg, resumeAfter, func(kind string, resource unstructured.Unstructured) bool {
+		if spec.MaxDeletionsPerRun != nil && deleted >= *spec.MaxDeletionsPerRun {
+			limitReached = true
+			return false
+		}
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				errs = append(errs, err)
+				return false
+			}
+		}
+		last = &kyvernov2alpha1.CleanupResumePosition{Kind: kind, Key: cleanup.ResourceKey(resource)}
+		namespace := resource.GetNamespace()
+		name := resource.GetName()
+		debug := debug.WithValues("kind", resource.GetKind(), "name", name, "namespace", namespace)
+		if ok, err := h.deletionAllowed(ctx, policy, resource, allowed); err != nil || !ok {
+			if err == nil {
+				err = fmt.Errorf("service account %s is not allowed to delete %s in namespace %s", spec.ServiceAccountName, resource.GetKind(), namespace)
+			}
+			debug.Error(err, "deletion not authorized")
+			errs = append(errs, err)
+			h.createEvent(policy, resource, err)
+			return true
+		}
+		logger.WithValues("name", name, "namespace", namespace).Info("resource matched, it will be deleted...")
+		if err := client.DeleteResourceWithOptions(ctx, resource.GetAPIVersion(), resource.GetKind(), namespace, name, options); err != nil {
+			debug.Error(err, "failed to delete resource")
 			errs = append(errs, err)
+			h.createEvent(policy, resource, err)
 		} else {
-			for i := range list.Items {
-				resource := list.Items[i]
-				namespace := resource.GetNamespace()
-				name := resource.GetName()
-				debug := debug.WithValues("name", name, "namespace", namespace)
-				if !controllerutils.IsManagedByKyverno(&resource) {
-					var nsLabels map[string]string
-					if namespace != "" {
-						ns, err := h.nsLister.Get(namespace)
-						if err != nil {
-							debug.Error(err, "failed to get namespace labels")
-							errs = append(errs, err)
-						}
-						nsLabels = ns.GetLabels()
-					}
-					// match namespaces
-					if err := match.CheckNamespace(policy.GetNamespace(), resource); err != nil {
-						debug.Info("resource namespace didn't match policy namespace", "result", err)
-					}
-					// match resource with match/exclude clause
-					matched := match.CheckMatchesResources(
-						resource,
-						spec.MatchResources,
-						nsLabels,
-						nil,
-						"",
-						// TODO(eddycharly): we don't have user info here, we should check that
-						// we don't have user conditions in the policy rule
-						kyvernov1beta1.RequestInfo{},
-						nil,
-					)
-					if matched != nil {
-						debug.Info("resource/match didn't match", "result", matched)
-						continue
-					}
-					if spec.ExcludeResources != nil {
-						excluded := match.CheckMatchesResources(
-							resource,
-							*spec.ExcludeResources,
-							nsLabels,
-							nil,
-							"",
-							// TODO(eddycharly): we don't have user info here, we should check that
-							// we don't have user conditions in the policy rule
-							kyvernov1beta1.RequestInfo{},
-							nil,
-						)
-						if excluded == nil {
-							debug.Info("resource/exclude matched")
-							continue
-						} else {
-							debug.Info("resource/exclude didn't match", "result", excluded)
-						}
-					}
-					// check conditions
-					if spec.Conditions != nil {
-						enginectx := enginecontext.NewContext()
-						if err := enginectx.AddTargetResource(resource.Object); err != nil {
-							debug.Error(err, "failed to add resource in context")
-							errs = append(errs, err)
-							continue
-						}
-						if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
-							debug.Error(err, "failed to add namespace in context")
-							errs = append(errs, err)
-							continue
-						}
-						if err := enginectx.AddImageInfos(&resource, cfg); err != nil {
-							debug.Error(err, "failed to add image infos in context")
-							errs = append(errs, err)
-							continue
-						}
-						passed, err := checkAnyAllConditions(logger, enginectx, *spec.Conditions)
-						if err != nil {
-							debug.Error(err, "failed to check condition")
-							errs = append(errs, err)
-							continue
-						}
-						if !passed {
-							debug.Info("conditions did not pass")
-							continue
-						}
-					}
-					logger.WithValues("name", name, "namespace", namespace).Info("resource matched, it will be deleted...")
-					if err := h.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), namespace, name, false); err != nil {
-						debug.Error(err, "failed to delete resource")
-						errs = append(errs, err)
-						h.createEvent(policy, resource, err)
-					} else {
-						debug.Info("deleted")
-						h.createEvent(policy, resource, nil)
-					}
-				}
-			}
+			debug.Info("deleted")
+			deleted++
+			h.createEvent(policy, resource, nil)
+		}
+		return true
+	})
+	// the walk function appends to errs, walk errors must be added once the walk is done
+	errs = append(errs, walkErr)
+	err = multierr.Combine(errs...)
+	if statusErr := cleanup.UpdateStatus(ctx, h.kyvernoClient, policy, func(status *kyvernov2alpha1.CleanupPolicyStatus) {
+		status.ResumeAfter = nil
+		if limitReached {
+			status.ResumeAfter = last
 		}
+		switch {
+		case err != nil:
+			status.SetComplete(policy.GetGeneration(), kyvernov2alpha1.CleanupReasonFailed, fmt.Sprintf("%d resource(s) deleted, %d error(s) occurred", deleted, len(multierr.Errors(err))))
+		case limitReached:
+			status.SetComplete(policy.GetGeneration(), kyvernov2alpha1.CleanupReasonDeletionLimitReached, fmt.Sprintf("%d resource(s) deleted, the deletion limit was reached and the next execution will resume after %s %s", deleted, last.Kind, last.Key))
+		default:
+			status.SetComplete(policy.GetGeneration(), kyvernov2alpha1.CleanupReasonSucceeded, fmt.Sprintf("%d resource(s) deleted", deleted))
+		}
+	}); statusErr != nil {
+		logger.Error(statusErr, "failed to update policy status")
+		err = multierr.Append(err, statusErr)
+	}
+	return err
+}
+
+// recordDryRun stores the resources that would have been deleted in the policy status,
+// in the policy dry run report and as events
+func (h *handlers) recordDryRun(ctx context.Context, logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface, resources []unstructured.Unstructured, now time.Time) error {
+	for _, resource := range resources {
+		logger.WithValues("name", resource.GetName(), "namespace", resource.GetNamespace()).Info("resource matched, it would be deleted (dry run)")
+		h.createDryRunEvent(policy, resource)
+	}
+	var errs []error
+	if err := cleanup.UpdateStatus(ctx, h.kyvernoClient, policy, func(status *kyvernov2alpha1.CleanupPolicyStatus) {
+		status.DryRun = cleanup.NewDryRunStatus(resources, now)
+	}); err != nil {
+		logger.Error(err, "failed to update policy status")
+		errs = append(errs, err)
+	}
+	if err := h.reconcileDryRunReport(ctx, cleanup.NewDryRunReport(policy, resources, now)); err != nil {
+		logger.Error(err, "failed to reconcile dry run report")
+		errs = append(errs, err)
 	}
 	return multierr.Combine(errs...)
 }
 
-func (h *handlers) createEvent(policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured, err error) {
-	var cleanuppol runtime.Object
-	if policy.GetNamespace() == "" {
-		cleanuppol = policy.(*kyvernov2alpha1.ClusterCleanupPolicy)
-	} else if policy.GetNamespace() != "" {
-		cleanuppol = policy.(*kyvernov2alpha1.CleanupPolicy)
+// clearDryRun removes the dry run status and report once a policy is not in dry run mode anymore
+func (h *handlers) clearDryRun(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface) error {
+	if err := cleanup.UpdateStatus(ctx, h.kyvernoClient, policy, func(status *kyvernov2alpha1.CleanupPolicyStatus) {
+		status.DryRun = nil
+	}); err != nil {
+		return err
 	}
+	report := cleanup.NewDryRunReport(policy, nil, time.Time{})
+	if err := reportutils.DeleteReport(ctx, report, h.kyvernoClient); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (h *handlers) reconcileDryRunReport(ctx context.Context, report kyvernov1alpha2.ReportInterface) error {
+	var observed kyvernov1alpha2.ReportInterface
+	var err error
+	if report.GetNamespace() == "" {
+		observed, err = h.kyvernoClient.Wgpolicyk8sV1alpha2().ClusterPolicyReports().Get(ctx, report.GetName(), metav1.GetOptions{})
+	} else {
+		observed, err = h.kyvernoClient.Wgpolicyk8sV1alpha2().PolicyReports(report.GetNamespace()).Get(ctx, report.GetName(), metav1.GetOptions{})
+	}
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err := reportutils.CreateReport(ctx, report, h.kyvernoClient)
+		return err
+	}
+	updated := reportutils.DeepCopy(observed)
+	updated.SetLabels(report.GetLabels())
+	updated.SetOwnerReferences(report.GetOwnerReferences())
+	reportutils.SetResults(updated, report.GetResults()...)
+	_, err = reportutils.UpdateReport(ctx, updated, h.kyvernoClient)
+	return err
+}
+
+func (h *handlers) createDryRunEvent(policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured) {
+	h.recorder.Eventf(
+		policyObject(policy),
+		corev1.EventTypeNormal,
+		string(event.PolicyApplied),
+		"dry run, the target resource %v/%v/%v would have been cleaned up",
+		resource.GetKind(),
+		resource.GetNamespace(),
+		resource.GetName(),
+	)
+}
+
+func (h *handlers) createEvent(policy kyvernov2alpha1.CleanupPolicyInterface, resource unstructured.Unstructured, err error) {
+	cleanuppol := policyObject(policy)
 	if err == nil {
 		h.recorder.Eventf(
 			cleanuppol,
@@ -211,3 +374,10 @@ func (h *handlers) createEvent(policy kyvernov2alpha1.CleanupPolicyInterface, re
 		)
 	}
 }
+
+func policyObject(policy kyvernov2alpha1.CleanupPolicyInterface) runtime.Object {
+	if policy.GetNamespace() == "" {
+		return policy.(*kyvernov2alpha1.ClusterCleanupPolicy)
+	}
+	return policy.(*kyvernov2alpha1.CleanupPolicy)
+}
@@ -8,30 +8,40 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	admissionhandlers "github.com/kyverno/kyverno/cmd/cleanup-controller/handlers/admission"
 	cleanuphandlers "github.com/kyverno/kyverno/cmd/cleanup-controller/handlers/cleanup"
 	"github.com/kyverno/kyverno/cmd/internal"
+	pkgcleanup "github.com/kyverno/kyverno/pkg/cleanup"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	dynamicclient "github.com/kyverno/kyverno/pkg/clients/dynamic"
 	kubeclient "github.com/kyverno/kyverno/pkg/clients/kube"
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/kyverno"
+	metadataclient "github.com/kyverno/kyverno/pkg/clients/metadata"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
 	"github.com/kyverno/kyverno/pkg/controllers/cleanup"
+	"github.com/kyverno/kyverno/pkg/controllers/cleanup/ttl"
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
+	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/webhooks"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/rest"
 )
 
 const (
-	resyncPeriod          = 15 * time.Minute
-	webhookWorkers        = 2
-	webhookControllerName = "webhook-controller"
+	resyncPeriod             = 15 * time.Minute
+	webhookWorkers           = 2
+	webhookControllerName    = "webhook-controller"
+	ttlWebhookControllerName = "ttl-webhook-controller"
 )
 
 // TODO:
@@ -55,12 +65,14 @@ func main() {
 		dumpPayload               bool
 		serverIP                  string
 		servicePort               int
+		cronJobs                  bool
 	)
 	flagset := flag.NewFlagSet("cleanup-controller", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
 	flagset.StringVar(&serverIP, "serverIP", "", "IP address where Kyverno controller runs. Only required if out-of-cluster.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
+	flagset.BoolVar(&cronJobs, "cronJobs", false, "Run cleanup policies with CronJobs calling the cleanup service instead of the in-process scheduler.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -83,6 +95,36 @@ func main() {
 	kubeClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
 	leaderElectionClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
 	kyvernoClient := internal.CreateKyvernoClient(logger, kyvernoclient.WithMetrics(metricsConfig, metrics.KubeClient), kyvernoclient.WithTracing())
+	metadataClient := internal.CreateMetadataClient(logger, metadataclient.WithMetrics(metricsConfig, metrics.KubeClient), metadataclient.WithTracing())
+	dynamicClient := internal.CreateDynamicClient(logger, dynamicclient.WithMetrics(metricsConfig, metrics.KyvernoClient), dynamicclient.WithTracing())
+	dClient := internal.CreateDClient(logger, ctx, dynamicClient, kubeClient, 15*time.Minute)
+	// informer factories
+	kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
+	kubeKyvernoInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
+	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+	// listers
+	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister().Secrets(config.KyvernoNamespace())
+	cpolLister := kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies().Lister()
+	polLister := kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies().Lister()
+	nsLister := kubeInformer.Core().V1().Namespaces().Lister()
+	// create handlers
+	admissionHandlers := admissionhandlers.New(dClient)
+	cleanupHandlers := cleanuphandlers.New(dClient, kyvernoClient, cpolLister, polLister, nsLister, func(userInfo authenticationv1.UserInfo) (dclient.Interface, error) {
+		clientConfig := internal.CreateClientConfig(logger)
+		// only the service account user name is impersonated, the api server adds the service account groups
+		clientConfig.Impersonate = rest.ImpersonationConfig{
+			UserName: userInfo.Username,
+		}
+		kubeClient, err := kubeclient.NewForConfig(clientConfig, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
+		if err != nil {
+			return nil, err
+		}
+		dynamicClient, err := dynamicclient.NewForConfig(clientConfig, dynamicclient.WithMetrics(metricsConfig, metrics.KyvernoClient), dynamicclient.WithTracing())
+		if err != nil {
+			return nil, err
+		}
+		return dclient.NewClientWithDiscovery(dynamicClient, kubeClient, dClient.Discovery()), nil
+	}, pkgcleanup.NewInformerReferenceResolver(ctx, dClient, resyncPeriod))
 	// setup leader election
 	le, err := leaderelection.New(
 		logger.WithName("leader-election"),
@@ -141,22 +183,72 @@ func main() {
 							admissionregistrationv1.Update,
 						},
 					}},
+					nil,
 					genericwebhookcontroller.Fail,
 					genericwebhookcontroller.None,
 				),
 				webhookWorkers,
 			)
+			ttlWebhookController := internal.NewController(
+				ttlWebhookControllerName,
+				genericwebhookcontroller.NewController(
+					ttlWebhookControllerName,
+					kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
+					kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
+					kubeKyvernoInformer.Core().V1().Secrets(),
+					config.TTLValidatingWebhookConfigurationName,
+					config.TTLValidatingWebhookServicePath,
+					serverIP,
+					int32(servicePort),
+					[]admissionregistrationv1.RuleWithOperations{{
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{"*"},
+							APIVersions: []string{"*"},
+							Resources:   []string{"*"},
+						},
+						Operations: []admissionregistrationv1.OperationType{
+							admissionregistrationv1.Create,
+							admissionregistrationv1.Update,
+						},
+					}},
+					&metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      ttl.LabelTTL,
+							Operator: metav1.LabelSelectorOpExists,
+						}},
+					},
+					genericwebhookcontroller.Ignore,
+					genericwebhookcontroller.None,
+				),
+				webhookWorkers,
+			)
 			cleanupController := internal.NewController(
 				cleanup.ControllerName,
 				cleanup.NewController(
 					kubeClient,
+					kyvernoClient,
 					kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies(),
 					kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies(),
 					kubeInformer.Batch().V1().CronJobs(),
 					"https://"+config.KyvernoServiceName()+"."+config.KyvernoNamespace()+".svc",
+					cronJobs,
+					func(ctx context.Context, logger logr.Logger, policy string, now time.Time) error {
+						return cleanupHandlers.Cleanup(ctx, logger, policy, now, config.NewDefaultConfiguration())
+					},
 				),
 				cleanup.Workers,
 			)
+			ttlController := internal.NewController(
+				ttl.ControllerName,
+				ttl.NewManager(
+					metadataClient,
+					kubeClient.Discovery(),
+					kubeClient.AuthorizationV1().SelfSubjectAccessReviews(),
+					event.NewRecorder(event.CleanupController, kubeClient.CoreV1().Events(metav1.NamespaceAll)),
+					ttl.DiscoveryInterval,
+				),
+				ttl.Workers,
+			)
 			// start informers and wait for cache sync
 			if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
 				logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -166,7 +258,9 @@ func main() {
 			var wg sync.WaitGroup
 			certController.Run(ctx, logger, &wg)
 			webhookController.Run(ctx, logger, &wg)
+			ttlWebhookController.Run(ctx, logger, &wg)
 			cleanupController.Run(ctx, logger, &wg)
+			ttlController.Run(ctx, logger, &wg)
 			// wait all controllers shut down
 			wg.Wait()
 		},
@@ -176,24 +270,10 @@ func main() {
 		logger.Error(err, "failed to initialize leader election")
 		os.Exit(1)
 	}
-	dynamicClient := internal.CreateDynamicClient(logger, dynamicclient.WithMetrics(metricsConfig, metrics.KyvernoClient), dynamicclient.WithTracing())
-	dClient := internal.CreateDClient(logger, ctx, dynamicClient, kubeClient, 15*time.Minute)
-	// informer factories
-	kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod)
-	kubeKyvernoInformer := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, resyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
-	kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
-	// listers
-	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister().Secrets(config.KyvernoNamespace())
-	cpolLister := kyvernoInformer.Kyverno().V2alpha1().ClusterCleanupPolicies().Lister()
-	polLister := kyvernoInformer.Kyverno().V2alpha1().CleanupPolicies().Lister()
-	nsLister := kubeInformer.Core().V1().Namespaces().Lister()
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kubeKyvernoInformer, kubeInformer, kyvernoInformer) {
 		os.Exit(1)
 	}
-	// create handlers
-	admissionHandlers := admissionhandlers.New(dClient)
-	cleanupHandlers := cleanuphandlers.New(dClient, cpolLister, polLister, nsLister)
 	// create server
 	server := NewServer(
 		func() ([]byte, []byte, error) {
@@ -204,6 +284,7 @@ func main() {
 			return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
 		},
 		admissionHandlers.Validate,
+		admissionHandlers.ValidateTTL,
 		cleanupHandlers.Cleanup,
 		metricsConfig,
 		webhooks.DebugModeOptions{
@@ -44,6 +44,7 @@ type Probes interface {
 func NewServer(
 	tlsProvider TlsProvider,
 	validationHandler ValidationHandler,
+	ttlValidationHandler ValidationHandler,
 	cleanupHandler CleanupHandler,
 	metricsConfig metrics.MetricsConfigManager,
 	debugModeOpts webhooks.DebugModeOptions,
@@ -51,6 +52,7 @@ func NewServer(
 	cfg config.Configuration,
 ) Server {
 	policyLogger := logging.WithName("cleanup-policy")
+	ttlLogger := logging.WithName("ttl")
 	cleanupLogger := logging.WithName("cleanup")
 	cleanupHandlerFunc := func(w http.ResponseWriter, r *http.Request) {
 		policy := r.URL.Query().Get("policy")
@@ -77,6 +79,16 @@ func NewServer(
 			WithAdmission(policyLogger.WithName("validate")).
 			ToHandlerFunc(),
 	)
+	mux.HandlerFunc(
+		"POST",
+		config.TTLValidatingWebhookServicePath,
+		handlers.FromAdmissionFunc("VALIDATE", ttlValidationHandler).
+			WithDump(debugModeOpts.DumpPayload, nil, nil, nil).
+			WithSubResourceFilter().
+			WithMetrics(ttlLogger, metricsConfig.Config(), metrics.WebhookValidating).
+			WithAdmission(ttlLogger.WithName("validate")).
+			ToHandlerFunc(),
+	)
 	mux.HandlerFunc(
 		"GET",
 		cleanup.CleanupServicePath,
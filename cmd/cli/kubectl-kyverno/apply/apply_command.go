@@ -1,30 +1,26 @@
 package apply
 
 import (
-	"context"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/go-git/go-billy/v5/memfs"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
-	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	policy2 "github.com/kyverno/kyverno/pkg/policy"
 	gitutils "github.com/kyverno/kyverno/pkg/utils/git"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	yaml1 "sigs.k8s.io/yaml"
 )
@@ -57,6 +53,7 @@ type ApplyCommandConfig struct {
 	ValuesFile      string
 	UserInfoPath    string
 	Cluster         bool
+	Snapshot        string
 	PolicyReport    bool
 	Stdin           bool
 	RegistryAccess  bool
@@ -79,6 +76,9 @@ To apply on a folder of resources:
 To apply on a cluster:
         kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --cluster
 
+To apply on a cluster snapshot captured with "kyverno snapshot":
+        kyverno apply /path/to/policy.yaml /path/to/folderOfPolicies --snapshot=/path/to/cluster.snapshot
+
 To apply policies from a gitSourceURL on a cluster:
 	Example: Taking github.com as a gitSourceURL here. Some other standards  gitSourceURL are: gitlab.com , bitbucket.org , etc.
 		kyverno apply https://github.com/kyverno/policies/openshift/ --git-branch main --cluster
@@ -180,6 +180,7 @@ func Command() *cobra.Command {
 	}
 	cmd.Flags().StringArrayVarP(&applyCommandConfig.ResourcePaths, "resource", "r", []string{}, "Path to resource files")
 	cmd.Flags().BoolVarP(&applyCommandConfig.Cluster, "cluster", "c", false, "Checks if policies should be applied to cluster in the current context")
+	cmd.Flags().StringVarP(&applyCommandConfig.Snapshot, "snapshot", "", "", "Path to a cluster snapshot used instead of a live cluster to list resources and resolve API calls")
 	cmd.Flags().StringVarP(&applyCommandConfig.MutateLogPath, "output", "o", "", "Prints the mutated resources in provided file/directory")
 	// currently `set` flag supports variable for single policy applied on single resource
 	cmd.Flags().StringVarP(&applyCommandConfig.UserInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
@@ -200,6 +201,9 @@ func Command() *cobra.Command {
 func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, resources []*unstructured.Unstructured, skipInvalidPolicies SkippedInvalidPolicies, pvInfos []common.Info, err error) {
 	store.SetMock(true)
 	store.SetRegistryAccess(c.RegistryAccess)
+	if c.Snapshot != "" {
+		c.Cluster = true
+	}
 	if c.Cluster {
 		store.AllowApiCall(true)
 	}
@@ -223,20 +227,16 @@ func (c *ApplyCommandConfig) applyCommandHelper() (rc *common.ResultCounts, reso
 	}
 
 	var dClient dclient.Interface
-	if c.Cluster {
-		restConfig, err := config.CreateClientConfigWithContext(c.KubeConfig, c.Context)
-		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
-		}
-		kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if c.Snapshot != "" {
+		clusterSnapshot, err := snapshot.ReadFile(c.Snapshot)
 		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
-		}
-		dynamicClient, err := dynamic.NewForConfig(restConfig)
-		if err != nil {
-			return rc, resources, skipInvalidPolicies, pvInfos, err
+			return rc, resources, skipInvalidPolicies, pvInfos, sanitizederror.NewWithError("failed to load snapshot", err)
 		}
-		dClient, err = dclient.NewClient(context.Background(), dynamicClient, kubeClient, 15*time.Minute)
+		dClient = snapshot.NewClient(clusterSnapshot)
+		store.SetConfigMapResolver(snapshot.NewConfigMapResolver(clusterSnapshot))
+		namespaceSelectorMap = clusterSnapshot.MergeNamespaceLabels(namespaceSelectorMap)
+	} else if c.Cluster {
+		dClient, err = common.NewClusterClient(c.KubeConfig, c.Context)
 		if err != nil {
 			return rc, resources, skipInvalidPolicies, pvInfos, err
 		}
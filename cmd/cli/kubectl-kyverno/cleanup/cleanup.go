@@ -0,0 +1,142 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cleanup"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// Preview holds the resources a cleanup policy would delete
+type Preview struct {
+	Policy    kyvernov2alpha1.CleanupPolicyInterface
+	Resources []unstructured.Unstructured
+	// Errors contains the errors that occurred while evaluating some resources
+	Errors error
+}
+
+// Report returns the policy report the cleanup controller would record for this preview in dry run mode
+func (p Preview) Report(now time.Time) kyvernov1alpha2.ReportInterface {
+	return cleanup.NewDryRunReport(p.Policy, p.Resources, now)
+}
+
+// Evaluate computes the resources each policy would delete, without deleting anything
+func Evaluate(ctx context.Context, logger logr.Logger, client dclient.Interface, policies ...kyvernov2alpha1.CleanupPolicyInterface) []Preview {
+	nsLabels := func(namespace string) (map[string]string, error) {
+		ns, err := client.GetResource(ctx, "v1", "Namespace", "", namespace)
+		if err != nil {
+			return nil, err
+		}
+		return ns.GetLabels(), nil
+	}
+	cfg := config.NewDefaultConfiguration()
+	references := cleanup.NewClientReferenceResolver(client)
+	previews := make([]Preview, 0, len(policies))
+	for _, policy := range policies {
+		resources, err := cleanup.Evaluate(ctx, logger, client, nsLabels, references, policy, cfg)
+		previews = append(previews, Preview{Policy: policy, Resources: resources, Errors: err})
+	}
+	return previews
+}
+
+// LoadPolicies loads the ClusterCleanupPolicy and CleanupPolicy documents found in the given paths,
+// directories are walked recursively. CleanupPolicies without a namespace are set in the given namespace.
+func LoadPolicies(paths []string, namespace string) ([]kyvernov2alpha1.CleanupPolicyInterface, error) {
+	files, err := listFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	var policies []kyvernov2alpha1.CleanupPolicyInterface
+	for _, file := range files {
+		// We accept the risk of including a user provided file here.
+		data, err := os.ReadFile(filepath.Clean(file)) // #nosec G304
+		if err != nil {
+			return nil, err
+		}
+		filePolicies, err := parsePolicies(data, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policies from %s: %w", file, err)
+		}
+		policies = append(policies, filePolicies...)
+	}
+	return policies, nil
+}
+
+func parsePolicies(data []byte, namespace string) ([]kyvernov2alpha1.CleanupPolicyInterface, error) {
+	documents, err := yamlutils.SplitDocuments(data)
+	if err != nil {
+		return nil, err
+	}
+	var policies []kyvernov2alpha1.CleanupPolicyInterface
+	for _, document := range documents {
+		var us unstructured.Unstructured
+		if err := yaml.Unmarshal(document, &us.Object); err != nil {
+			return nil, err
+		}
+		if us.Object == nil {
+			continue
+		}
+		var policy kyvernov2alpha1.CleanupPolicyInterface
+		switch us.GetKind() {
+		case "ClusterCleanupPolicy":
+			policy = &kyvernov2alpha1.ClusterCleanupPolicy{}
+		case "CleanupPolicy":
+			policy = &kyvernov2alpha1.CleanupPolicy{}
+		default:
+			continue
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(us.Object, policy); err != nil {
+			return nil, err
+		}
+		if us.GetKind() == "CleanupPolicy" && policy.GetNamespace() == "" {
+			policy.SetNamespace(namespace)
+		}
+		if errs := policy.Validate(sets.New[string]()); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid policy %s: %w", policy.GetName(), errs.ToAggregate())
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// listFiles returns the yaml files found in the given paths, directories are walked recursively
+func listFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(file string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := filepath.Ext(file)
+			if !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,208 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/snapshot"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func object(apiVersion, kind, namespace, name string, labels map[string]interface{}) unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": name}
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	if labels != nil {
+		metadata["labels"] = labels
+	}
+	return unstructured.Unstructured{Object: map[string]interface{}{"apiVersion": apiVersion, "kind": kind, "metadata": metadata}}
+}
+
+func newTestSnapshot() *snapshot.Snapshot {
+	s := snapshot.New("")
+	s.Add(snapshot.ResourceList{
+		Version:  "v1",
+		Resource: "namespaces",
+		Kind:     "Namespace",
+		Items: []unstructured.Unstructured{
+			object("v1", "Namespace", "", "dev", map[string]interface{}{"env": "dev"}),
+			object("v1", "Namespace", "", "prod", map[string]interface{}{"env": "prod"}),
+		},
+	})
+	s.Add(snapshot.ResourceList{
+		Version:    "v1",
+		Resource:   "pods",
+		Kind:       "Pod",
+		Namespaced: true,
+		Items: []unstructured.Unstructured{
+			object("v1", "Pod", "dev", "old", map[string]interface{}{"ttl": "expired"}),
+			object("v1", "Pod", "dev", "keep", map[string]interface{}{"ttl": "expired"}),
+			object("v1", "Pod", "dev", "fresh", map[string]interface{}{"ttl": "valid"}),
+			object("v1", "Pod", "prod", "prod", map[string]interface{}{"ttl": "expired"}),
+			object("v1", "Pod", "dev", "managed", map[string]interface{}{"ttl": "expired", "app.kubernetes.io/managed-by": "kyverno"}),
+		},
+	})
+	return s
+}
+
+const policies = `
+apiVersion: kyverno.io/v2alpha1
+kind: ClusterCleanupPolicy
+metadata:
+  name: expired-pods
+spec:
+  schedule: "*/5 * * * *"
+  dryRun: true
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+        namespaceSelector:
+          matchLabels:
+            env: dev
+  exclude:
+    any:
+    - resources:
+        names: [keep]
+  conditions:
+    all:
+    - key: "{{ target.metadata.labels.ttl }}"
+      operator: Equals
+      value: expired
+---
+apiVersion: kyverno.io/v2alpha1
+kind: CleanupPolicy
+metadata:
+  name: all-pods
+spec:
+  schedule: "0 0 * * *"
+  serviceAccountName: cleanup
+  match:
+    any:
+    - resources:
+        kinds: [Pod]
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+func Test_Evaluate(t *testing.T) {
+	loaded, err := parsePolicies([]byte(policies), "prod")
+	assert.NilError(t, err)
+	assert.Equal(t, len(loaded), 2)
+	assert.Equal(t, loaded[0].GetName(), "expired-pods")
+	assert.Assert(t, loaded[0].GetSpec().DryRun)
+	assert.Equal(t, loaded[1].GetNamespace(), "prod")
+
+	previews := Evaluate(context.TODO(), logging.GlobalLogger(), snapshot.NewClient(newTestSnapshot()), loaded...)
+	assert.Equal(t, len(previews), 2)
+	assert.NilError(t, previews[0].Errors)
+	assert.Equal(t, len(previews[0].Resources), 1)
+	assert.Equal(t, previews[0].Resources[0].GetName(), "old")
+	assert.NilError(t, previews[1].Errors)
+	assert.Equal(t, len(previews[1].Resources), 1)
+	assert.Equal(t, previews[1].Resources[0].GetName(), "prod")
+
+	report := previews[0].Report(time.Now())
+	clusterReport, ok := report.(*policyreportv1alpha2.ClusterPolicyReport)
+	assert.Assert(t, ok)
+	assert.Equal(t, report.GetName(), "cleanup-expired-pods")
+	assert.Equal(t, len(report.GetResults()), 1)
+	assert.Equal(t, report.GetResults()[0].Resources[0].Name, "old")
+	assert.Equal(t, clusterReport.Summary.Warn, 1)
+}
+
+func Test_EvaluatePaginated(t *testing.T) {
+	loaded, err := parsePolicies([]byte(policies), "dev")
+	assert.NilError(t, err)
+	pageSize := int64(1)
+	loaded[1].GetSpec().PageSize = &pageSize
+	previews := Evaluate(context.TODO(), logging.GlobalLogger(), snapshot.NewClient(newTestSnapshot()), loaded[1])
+	assert.NilError(t, previews[0].Errors)
+	assert.Equal(t, len(previews[0].Resources), 3)
+	assert.Equal(t, previews[0].Resources[0].GetName(), "old")
+	assert.Equal(t, previews[0].Resources[2].GetName(), "fresh")
+}
+
+func Test_parsePoliciesInvalid(t *testing.T) {
+	_, err := parsePolicies([]byte(`
+apiVersion: kyverno.io/v2alpha1
+kind: ClusterCleanupPolicy
+metadata:
+  name: invalid
+spec:
+  schedule: "every minute"
+  match:
+    any:
+    - resources:
+        kinds: [Pod]`), "default")
+	assert.ErrorContains(t, err, "invalid policy invalid")
+}
+
+func Test_EvaluateOrphans(t *testing.T) {
+	s := snapshot.New("")
+	pod := object("v1", "Pod", "dev", "app", nil)
+	pod.Object["spec"] = map[string]interface{}{
+		"containers": []interface{}{map[string]interface{}{"name": "app", "image": "nginx"}},
+		"volumes": []interface{}{map[string]interface{}{
+			"name":      "config",
+			"configMap": map[string]interface{}{"name": "used"},
+		}},
+	}
+	s.Add(snapshot.ResourceList{
+		Version:  "v1",
+		Resource: "namespaces",
+		Kind:     "Namespace",
+		Items:    []unstructured.Unstructured{object("v1", "Namespace", "", "dev", nil)},
+	})
+	s.Add(snapshot.ResourceList{
+		Version:    "v1",
+		Resource:   "pods",
+		Kind:       "Pod",
+		Namespaced: true,
+		Items:      []unstructured.Unstructured{pod},
+	})
+	s.Add(snapshot.ResourceList{
+		Version:    "v1",
+		Resource:   "configmaps",
+		Kind:       "ConfigMap",
+		Namespaced: true,
+		Items: []unstructured.Unstructured{
+			object("v1", "ConfigMap", "dev", "used", nil),
+			object("v1", "ConfigMap", "dev", "orphan", nil),
+		},
+	})
+	loaded, err := parsePolicies([]byte(`
+apiVersion: kyverno.io/v2alpha1
+kind: CleanupPolicy
+metadata:
+  name: orphan-configmaps
+spec:
+  schedule: "0 0 * * *"
+  serviceAccountName: cleanup
+  match:
+    any:
+    - resources:
+        kinds: [ConfigMap]
+  context:
+  - name: pods
+    references:
+      kind: Pod
+  conditions:
+    all:
+    - key: "{{ length(pods) }}"
+      operator: Equals
+      value: 0`), "dev")
+	assert.NilError(t, err)
+	previews := Evaluate(context.TODO(), logging.GlobalLogger(), snapshot.NewClient(s), loaded...)
+	assert.NilError(t, previews[0].Errors)
+	assert.Equal(t, len(previews[0].Resources), 1)
+	assert.Equal(t, previews[0].Resources[0].GetName(), "orphan")
+}
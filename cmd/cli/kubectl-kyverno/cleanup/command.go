@@ -0,0 +1,168 @@
+package cleanup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/snapshot"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/lensesio/tableprinter"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var exampleHelp = `
+To preview the resources cleanup policies would delete in the current cluster:
+        kyverno cleanup /path/to/cleanup-policy.yaml
+
+To preview against a cluster snapshot captured with "kyverno snapshot":
+        kyverno cleanup /path/to/cleanup-policies/ --snapshot cluster.snapshot
+
+To print the policy reports the cleanup controller records for policies in dry run mode:
+        kyverno cleanup /path/to/cleanup-policy.yaml --output yaml
+
+Nothing is deleted, the command evaluates the match, exclude and conditions of the policies
+the same way the cleanup controller does when a policy is in dry run mode.
+CleanupPolicies without a namespace are evaluated in the namespace given with --namespace.
+`
+
+type options struct {
+	kubeConfig string
+	context    string
+	namespace  string
+	snapshot   string
+	output     string
+}
+
+type row struct {
+	ID        int    `header:"#"`
+	Policy    string `header:"policy"`
+	Kind      string `header:"kind"`
+	Namespace string `header:"namespace"`
+	Name      string `header:"name"`
+}
+
+// Command returns the cleanup command
+func Command() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:     "cleanup [cleanup policies...]",
+		Short:   "Previews the resources deleted by cleanup policies.",
+		Long:    "Evaluates ClusterCleanupPolicy and CleanupPolicy resources against a cluster or a snapshot and lists the resources they would delete, without deleting anything.",
+		Example: exampleHelp,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, policyPaths []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+			previews, err := o.execute(cmd.Context(), policyPaths)
+			if err != nil {
+				return err
+			}
+			for _, preview := range previews {
+				if preview.Errors != nil {
+					fmt.Fprintf(os.Stderr, "warning: policy %s: %s\n", preview.Policy.GetName(), preview.Errors)
+				}
+			}
+			return o.print(previews)
+		},
+	}
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "default", "Namespace of the CleanupPolicies that don't have one")
+	cmd.Flags().StringVarP(&o.snapshot, "snapshot", "", "", "Path to a cluster snapshot used instead of a live cluster")
+	cmd.Flags().StringVarP(&o.output, "output", "o", "table", "Output format, one of table, yaml or json")
+	cmd.Flags().StringVarP(&o.kubeConfig, "kubeconfig", "", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVarP(&o.context, "context", "", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+func (o options) execute(ctx context.Context, paths []string) ([]Preview, error) {
+	switch o.output {
+	case "table", "yaml", "json":
+	default:
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("unsupported output format %s", o.output), nil)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	policies, err := LoadPolicies(paths, o.namespace)
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to load cleanup policies", err)
+	}
+	if len(policies) == 0 {
+		return nil, sanitizederror.NewWithError("no cleanup policy found", nil)
+	}
+	var client dclient.Interface
+	if o.snapshot != "" {
+		clusterSnapshot, err := snapshot.ReadFile(o.snapshot)
+		if err != nil {
+			return nil, sanitizederror.NewWithError("failed to load snapshot", err)
+		}
+		client = snapshot.NewClient(clusterSnapshot)
+	} else {
+		client, err = common.NewClusterClient(o.kubeConfig, o.context)
+		if err != nil {
+			return nil, sanitizederror.NewWithError("failed to create cluster client", err)
+		}
+	}
+	return Evaluate(ctx, log.Log, client, policies...), nil
+}
+
+func (o options) print(previews []Preview) error {
+	now := time.Now()
+	switch o.output {
+	case "yaml":
+		for _, preview := range previews {
+			data, err := yaml.Marshal(preview.Report(now))
+			if err != nil {
+				return sanitizederror.NewWithError("failed to marshal report", err)
+			}
+			fmt.Printf("---\n%s", string(data))
+		}
+	case "json":
+		var reports []interface{}
+		for _, preview := range previews {
+			reports = append(reports, preview.Report(now))
+		}
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal reports", err)
+		}
+		fmt.Println(string(data))
+	default:
+		var rows []row
+		for _, preview := range previews {
+			policy := preview.Policy.GetName()
+			if preview.Policy.GetNamespace() != "" {
+				policy = preview.Policy.GetNamespace() + "/" + policy
+			}
+			for _, resource := range preview.Resources {
+				rows = append(rows, row{
+					ID:        len(rows) + 1,
+					Policy:    policy,
+					Kind:      resource.GetKind(),
+					Namespace: resource.GetNamespace(),
+					Name:      resource.GetName(),
+				})
+			}
+		}
+		if len(rows) == 0 {
+			fmt.Println("No resource would be deleted.")
+			return nil
+		}
+		printer := tableprinter.New(os.Stdout)
+		printer.Print(rows)
+		fmt.Printf("\n%d resource(s) would be deleted\n", len(rows))
+	}
+	return nil
+}
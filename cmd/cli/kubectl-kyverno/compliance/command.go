@@ -0,0 +1,167 @@
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/lensesio/tableprinter"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var exampleHelp = `
+To show the compliance score of all the frameworks:
+        kyverno compliance
+
+To show the score of each control of a framework:
+        kyverno compliance cis-benchmark --controls
+
+To show the compliance score of the frameworks in a namespace, cluster wide resources
+are reported with an empty namespace:
+        kyverno compliance --namespace prod
+
+Compliance reports are computed by the reports controller from the policy reports when
+it runs with the --complianceReports flag.
+`
+
+type options struct {
+	kubeConfig string
+	context    string
+	namespace  string
+	output     string
+	controls   bool
+}
+
+type reportRow struct {
+	Name         string `header:"name"`
+	Framework    string `header:"framework"`
+	Version      string `header:"version"`
+	Score        string `header:"score"`
+	Pass         int    `header:"pass"`
+	Fail         int    `header:"fail"`
+	NotEvaluated int    `header:"not evaluated"`
+}
+
+type controlRow struct {
+	Framework string `header:"framework"`
+	ID        string `header:"control"`
+	Title     string `header:"title"`
+	Status    string `header:"status"`
+	Pass      int    `header:"pass"`
+	Fail      int    `header:"fail"`
+}
+
+// Command returns the compliance command
+func Command() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:     "compliance [report]...",
+		Short:   "Shows the compliance scores of the compliance frameworks.",
+		Long:    "Shows the compliance reports computed by the reports controller for the compliance frameworks, in the whole cluster or in a namespace.",
+		Example: exampleHelp,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+			return o.execute(cmd, args...)
+		},
+	}
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "", "Show the compliance scores of the given namespace instead of the whole cluster")
+	cmd.Flags().BoolVarP(&o.controls, "controls", "", false, "Show the status of each control instead of the report scores")
+	cmd.Flags().StringVarP(&o.output, "output", "o", "table", "Output format, one of table, yaml or json")
+	cmd.Flags().StringVarP(&o.kubeConfig, "kubeconfig", "", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVarP(&o.context, "context", "", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+func (o options) execute(cmd *cobra.Command, names ...string) error {
+	switch o.output {
+	case "table", "yaml", "json":
+	default:
+		return sanitizederror.NewWithError(fmt.Sprintf("unsupported output format %s", o.output), nil)
+	}
+	client, err := common.NewKyvernoClient(o.kubeConfig, o.context)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to create cluster client", err)
+	}
+	reports, err := FetchReports(cmd.Context(), client, names...)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to get compliance reports", err)
+	}
+	if cmd.Flags().Changed("namespace") {
+		reports = ForNamespace(o.namespace, reports...)
+	}
+	return o.print(reports)
+}
+
+func (o options) print(reports []kyvernov2alpha1.ComplianceReport) error {
+	switch o.output {
+	case "yaml":
+		data, err := yaml.Marshal(reports)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal compliance reports", err)
+		}
+		fmt.Print(string(data))
+	case "json":
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal compliance reports", err)
+		}
+		fmt.Println(string(data))
+	default:
+		if len(reports) == 0 {
+			fmt.Println("No compliance report found.")
+			return nil
+		}
+		printer := tableprinter.New(os.Stdout)
+		if o.controls {
+			printer.Print(controlRows(reports...))
+		} else {
+			printer.Print(reportRows(reports...))
+		}
+	}
+	return nil
+}
+
+func reportRows(reports ...kyvernov2alpha1.ComplianceReport) []reportRow {
+	var rows []reportRow
+	for _, report := range reports {
+		rows = append(rows, reportRow{
+			Name:         report.Name,
+			Framework:    report.Framework,
+			Version:      report.Version,
+			Score:        fmt.Sprintf("%d%%", report.Summary.Score),
+			Pass:         report.Summary.Pass,
+			Fail:         report.Summary.Fail,
+			NotEvaluated: report.Summary.NotEvaluated,
+		})
+	}
+	return rows
+}
+
+func controlRows(reports ...kyvernov2alpha1.ComplianceReport) []controlRow {
+	var rows []controlRow
+	for _, report := range reports {
+		for _, control := range report.Controls {
+			rows = append(rows, controlRow{
+				Framework: report.Framework,
+				ID:        control.ID,
+				Title:     control.Title,
+				Status:    string(control.Status),
+				Pass:      control.Pass,
+				Fail:      control.Fail,
+			})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,65 @@
+package compliance
+
+import (
+	"context"
+	"sort"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// FetchReports lists the compliance reports with the given names, or all the compliance reports when no name is given
+func FetchReports(ctx context.Context, client versioned.Interface, names ...string) ([]kyvernov2alpha1.ComplianceReport, error) {
+	if len(names) == 0 {
+		list, err := client.KyvernoV2alpha1().ComplianceReports().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		reports := list.Items
+		sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+		return reports, nil
+	}
+	var reports []kyvernov2alpha1.ComplianceReport
+	for _, name := range names {
+		report, err := client.KyvernoV2alpha1().ComplianceReports().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, *report)
+	}
+	return reports, nil
+}
+
+// ForNamespace returns a copy of the reports restricted to the given namespace, the summary of each report is
+// replaced with the namespace score and only the controls failing in the namespace are kept, reports without
+// results in the namespace are dropped
+func ForNamespace(namespace string, reports ...kyvernov2alpha1.ComplianceReport) []kyvernov2alpha1.ComplianceReport {
+	var filtered []kyvernov2alpha1.ComplianceReport
+	for _, report := range reports {
+		for _, ns := range report.Namespaces {
+			if ns.Namespace != namespace {
+				continue
+			}
+			report := *report.DeepCopy()
+			report.Summary = ns.Score
+			report.Namespaces = []kyvernov2alpha1.NamespaceCompliance{ns}
+			failed := sets.New(ns.FailedControls...)
+			var controls []kyvernov2alpha1.ControlCompliance
+			for _, control := range report.Controls {
+				// result counts are only recorded for the whole cluster
+				if failed.Has(control.ID) {
+					controls = append(controls, kyvernov2alpha1.ControlCompliance{
+						ID:     control.ID,
+						Title:  control.Title,
+						Status: kyvernov2alpha1.ControlFail,
+					})
+				}
+			}
+			report.Controls = controls
+			filtered = append(filtered, report)
+		}
+	}
+	return filtered
+}
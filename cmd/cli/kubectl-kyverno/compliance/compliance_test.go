@@ -0,0 +1,61 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFetchReports(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&kyvernov2alpha1.ComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "pss"}, Framework: "PSS"},
+		&kyvernov2alpha1.ComplianceReport{ObjectMeta: metav1.ObjectMeta{Name: "cis"}, Framework: "CIS"},
+	)
+	reports, err := FetchReports(context.TODO(), client)
+	assert.NilError(t, err)
+	assert.Equal(t, len(reports), 2)
+	assert.Equal(t, reports[0].Name, "cis")
+	assert.Equal(t, reports[1].Name, "pss")
+	reports, err = FetchReports(context.TODO(), client, "pss")
+	assert.NilError(t, err)
+	assert.Equal(t, len(reports), 1)
+	assert.Equal(t, reports[0].Framework, "PSS")
+	_, err = FetchReports(context.TODO(), client, "nsa")
+	assert.Assert(t, err != nil)
+}
+
+func TestForNamespace(t *testing.T) {
+	report := kyvernov2alpha1.ComplianceReport{
+		Framework: "PSS",
+		Summary:   kyvernov2alpha1.ComplianceScore{Score: 50, Pass: 1, Fail: 1},
+		Controls: []kyvernov2alpha1.ControlCompliance{
+			{ID: "host-path", Status: kyvernov2alpha1.ControlPass, Pass: 2},
+			{ID: "privileged", Title: "Privileged containers", Status: kyvernov2alpha1.ControlFail, Pass: 1, Fail: 1},
+		},
+		Namespaces: []kyvernov2alpha1.NamespaceCompliance{{
+			Namespace:      "default",
+			Score:          kyvernov2alpha1.ComplianceScore{Score: 50, Pass: 1, Fail: 1},
+			FailedControls: []string{"privileged"},
+		}, {
+			Namespace: "kube-system",
+			Score:     kyvernov2alpha1.ComplianceScore{Score: 100, Pass: 2},
+		}},
+	}
+	reports := ForNamespace("default", report)
+	assert.Equal(t, len(reports), 1)
+	assert.DeepEqual(t, reports[0].Summary, kyvernov2alpha1.ComplianceScore{Score: 50, Pass: 1, Fail: 1})
+	assert.DeepEqual(t, reports[0].Controls, []kyvernov2alpha1.ControlCompliance{
+		{ID: "privileged", Title: "Privileged containers", Status: kyvernov2alpha1.ControlFail},
+	})
+	reports = ForNamespace("kube-system", report)
+	assert.Equal(t, len(reports), 1)
+	assert.Equal(t, reports[0].Summary.Score, 100)
+	assert.Equal(t, len(reports[0].Controls), 0)
+	assert.Equal(t, len(ForNamespace("prod", report)), 0)
+	// the original report is left untouched
+	assert.Equal(t, len(report.Controls), 2)
+}
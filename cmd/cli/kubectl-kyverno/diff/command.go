@@ -0,0 +1,287 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/snapshot"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	policyvalidation "github.com/kyverno/kyverno/pkg/policy"
+	"github.com/lensesio/tableprinter"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var exampleHelp = `
+To compare two versions of a policy against local resources:
+        kyverno diff old.yaml new.yaml --resource /path/to/resources.yaml
+
+To compare two versions of a policy against the resources of the current cluster:
+        kyverno diff old.yaml new.yaml --cluster --namespace prod
+
+To compare two versions of a policy against a cluster snapshot captured with "kyverno snapshot":
+        kyverno diff old.yaml new.yaml --snapshot cluster.snapshot
+
+Only the rules whose outcome changed are reported, the "change" property of each result
+is one of: result, mutated, no-longer-mutated, patch, generated, no-longer-generated, generated-resource.
+The "previousResult" property holds the result produced by the old policy.
+`
+
+var osExit = os.Exit
+
+type options struct {
+	kubeConfig    string
+	context       string
+	namespace     string
+	cluster       bool
+	snapshot      string
+	resourcePaths []string
+	valuesFile    string
+	userInfoPath  string
+	output        string
+	exitCode      bool
+}
+
+type row struct {
+	ID       int    `header:"#"`
+	Resource string `header:"resource"`
+	Policy   string `header:"policy"`
+	Rule     string `header:"rule"`
+	Change   string `header:"change"`
+	Previous string `header:"old result"`
+	Result   string `header:"new result"`
+}
+
+// Command returns the diff command
+func Command() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:     "diff <old policy> <new policy>",
+		Short:   "Shows the impact of a policy change on resources.",
+		Long:    "Evaluates two versions of a policy against the same set of resources and reports the resources whose result changed (pass to fail, newly mutated, different patch, newly generated...).",
+		Example: exampleHelp,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+			results, err := o.execute(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if err := o.print(results); err != nil {
+				return err
+			}
+			if o.exitCode && len(results) > 0 {
+				osExit(1)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVarP(&o.resourcePaths, "resource", "r", []string{}, "Path to resource files")
+	cmd.Flags().BoolVarP(&o.cluster, "cluster", "c", false, "Evaluates the policies against the resources of the cluster in the current context")
+	cmd.Flags().StringVarP(&o.snapshot, "snapshot", "", "", "Path to a cluster snapshot used instead of a live cluster to list resources and resolve API calls")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "", "Optional namespace used with cluster or snapshot flags")
+	cmd.Flags().StringVarP(&o.valuesFile, "values-file", "f", "", "File containing values for policy variables")
+	cmd.Flags().StringVarP(&o.userInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
+	cmd.Flags().StringVarP(&o.output, "output", "o", "table", "Output format, one of table, yaml or json")
+	cmd.Flags().BoolVarP(&o.exitCode, "exit-code", "", false, "Exit with 1 if at least one result changed")
+	cmd.Flags().StringVarP(&o.kubeConfig, "kubeconfig", "", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVarP(&o.context, "context", "", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+func (o options) execute(oldPath, newPath string) ([]policyreportv1alpha2.PolicyReportResult, error) {
+	switch o.output {
+	case "table", "yaml", "json":
+	default:
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("unsupported output format %s", o.output), nil)
+	}
+	store.SetMock(true)
+	if o.snapshot != "" {
+		o.cluster = true
+	}
+	if o.cluster {
+		store.AllowApiCall(true)
+	}
+	if len(o.resourcePaths) == 0 && !o.cluster {
+		return nil, sanitizederror.NewWithError("resource file(s), cluster or snapshot required", nil)
+	}
+	fs := memfs.New()
+	variables, globalValMap, valuesMap, namespaceSelectorMap, subresources, err := common.GetVariable("", o.valuesFile, fs, false, "")
+	if err != nil {
+		if !sanitizederror.IsErrorSanitized(err) {
+			return nil, sanitizederror.NewWithError("failed to decode yaml", err)
+		}
+		return nil, err
+	}
+	var dClient dclient.Interface
+	if o.snapshot != "" {
+		clusterSnapshot, err := snapshot.ReadFile(o.snapshot)
+		if err != nil {
+			return nil, sanitizederror.NewWithError("failed to load snapshot", err)
+		}
+		dClient = snapshot.NewClient(clusterSnapshot)
+		store.SetConfigMapResolver(snapshot.NewConfigMapResolver(clusterSnapshot))
+		namespaceSelectorMap = clusterSnapshot.MergeNamespaceLabels(namespaceSelectorMap)
+	} else if o.cluster {
+		dClient, err = common.NewClusterClient(o.kubeConfig, o.context)
+		if err != nil {
+			return nil, sanitizederror.NewWithError("failed to create cluster client", err)
+		}
+	}
+	openApiManager, err := openapi.NewManager(log.Log)
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to initialize openAPIController", err)
+	}
+	oldPolicies, err := loadPolicies(fs, oldPath, openApiManager)
+	if err != nil {
+		return nil, err
+	}
+	newPolicies, err := loadPolicies(fs, newPath, openApiManager)
+	if err != nil {
+		return nil, err
+	}
+	allPolicies := append(append([]kyvernov1.PolicyInterface{}, oldPolicies...), newPolicies...)
+	resources, err := common.GetResourceAccordingToResourcePath(fs, o.resourcePaths, o.cluster, allPolicies, dClient, o.namespace, true, false, "")
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to load resources", err)
+	}
+	var userInfo kyvernov1beta1.RequestInfo
+	if o.userInfoPath != "" {
+		userInfo, err = common.GetUserInfoFromPath(fs, o.userInfoPath, false, "")
+		if err != nil {
+			return nil, sanitizederror.NewWithError("failed to load request info", err)
+		}
+	}
+	e := evaluator{
+		variables:            variables,
+		globalValMap:         globalValMap,
+		valuesMap:            valuesMap,
+		namespaceSelectorMap: namespaceSelectorMap,
+		subresources:         subresources,
+		userInfo:             userInfo,
+		client:               dClient,
+	}
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, resource := range resources {
+		oldResponses, err := e.evaluate(oldPolicies, resource)
+		if err != nil {
+			return nil, err
+		}
+		newResponses, err := e.evaluate(newPolicies, resource)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, compare(resource, oldResponses, newResponses)...)
+	}
+	sortResults(results)
+	return results, nil
+}
+
+func loadPolicies(fs billy.Filesystem, path string, openApiManager openapi.Manager) ([]kyvernov1.PolicyInterface, error) {
+	policies, err := common.GetPoliciesFromPaths(fs, []string{path}, false, "")
+	if err != nil {
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to load policies from %s", path), err)
+	}
+	for _, policy := range policies {
+		if _, err := policyvalidation.Validate(policy, nil, true, openApiManager); err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("invalid policy %s in %s", policy.GetName(), path), err)
+		}
+	}
+	return policies, nil
+}
+
+type evaluator struct {
+	variables            map[string]string
+	globalValMap         map[string]string
+	valuesMap            map[string]map[string]common.Resource
+	namespaceSelectorMap map[string]map[string]string
+	subresources         []common.Subresource
+	userInfo             kyvernov1beta1.RequestInfo
+	client               dclient.Interface
+}
+
+func (e evaluator) evaluate(policies []kyvernov1.PolicyInterface, resource *unstructured.Unstructured) ([]*engineapi.EngineResponse, error) {
+	var responses []*engineapi.EngineResponse
+	for _, policy := range policies {
+		variable := common.RemoveDuplicateAndObjectVariables(common.HasVariables(policy))
+		kinds := common.GetKindsFromPolicy(policy, e.subresources, e.client)
+		values, err := common.CheckVariableForPolicy(e.valuesMap, e.globalValMap, policy.GetName(), resource.GetName(), resource.GetKind(), e.variables, kinds, variable)
+		if err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("policy `%s` have variables. pass the values for the variables for resource `%s` using the values_file flag", policy.GetName(), resource.GetName()), err)
+		}
+		policyResponses, _, err := common.ApplyPolicyOnResource(common.ApplyPolicyConfig{
+			Policy:               policy,
+			Resource:             resource,
+			Variables:            values,
+			UserInfo:             e.userInfo,
+			PolicyReport:         true,
+			NamespaceSelectorMap: e.namespaceSelectorMap,
+			Rc:                   &common.ResultCounts{},
+			Client:               e.client,
+			Subresources:         e.subresources,
+		})
+		if err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to apply policy %s on resource %s", policy.GetName(), resource.GetName()), err)
+		}
+		responses = append(responses, policyResponses...)
+	}
+	return responses, nil
+}
+
+func (o options) print(results []policyreportv1alpha2.PolicyReportResult) error {
+	switch o.output {
+	case "yaml":
+		data, err := yaml.Marshal(results)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal results", err)
+		}
+		fmt.Print(string(data))
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal results", err)
+		}
+		fmt.Println(string(data))
+	default:
+		if len(results) == 0 {
+			fmt.Println("No changes.")
+			return nil
+		}
+		rows := make([]row, 0, len(results))
+		for i, result := range results {
+			rows = append(rows, row{
+				ID:       i + 1,
+				Resource: resourceName(result),
+				Policy:   result.Policy,
+				Rule:     result.Rule,
+				Change:   result.Properties[PropertyChange],
+				Previous: result.Properties[PropertyPreviousResult],
+				Result:   string(result.Result),
+			})
+		}
+		printer := tableprinter.New(os.Stdout)
+		printer.Print(rows)
+		fmt.Printf("\n%d result(s) changed\n", len(results))
+	}
+	return nil
+}
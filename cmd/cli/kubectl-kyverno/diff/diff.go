@@ -0,0 +1,202 @@
+package diff
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ChangeType describes how the outcome of a rule changed between two policy versions
+type ChangeType string
+
+const (
+	// ResultChanged is reported when the rule result changed (pass -> fail for example)
+	ResultChanged ChangeType = "result"
+	// NewlyMutated is reported when the resource is mutated by the new policy only
+	NewlyMutated ChangeType = "mutated"
+	// NoLongerMutated is reported when the resource is mutated by the old policy only
+	NoLongerMutated ChangeType = "no-longer-mutated"
+	// PatchChanged is reported when both policies mutate the resource with different patches
+	PatchChanged ChangeType = "patch"
+	// NewlyGenerated is reported when the new policy only generates a resource
+	NewlyGenerated ChangeType = "generated"
+	// NoLongerGenerated is reported when the old policy only generates a resource
+	NoLongerGenerated ChangeType = "no-longer-generated"
+	// GeneratedResourceChanged is reported when both policies generate different resources
+	GeneratedResourceChanged ChangeType = "generated-resource"
+)
+
+const (
+	// PropertyChange is the result property holding the change type
+	PropertyChange = "change"
+	// PropertyPreviousResult is the result property holding the result of the old policy
+	PropertyPreviousResult = "previousResult"
+)
+
+type ruleKey struct {
+	policy   string
+	rule     string
+	ruleType engineapi.RuleType
+}
+
+type outcome struct {
+	result    policyreportv1alpha2.PolicyReportResult
+	status    engineapi.RuleStatus
+	patches   [][]byte
+	generated unstructured.Unstructured
+}
+
+func (o outcome) mutated() bool {
+	return o.status == engineapi.RuleStatusPass && len(o.patches) > 0
+}
+
+func (o outcome) hasGenerated() bool {
+	return o.status == engineapi.RuleStatusPass && len(o.generated.Object) > 0
+}
+
+// outcomes indexes the rule results of the engine responses computed for a single resource
+func outcomes(responses []*engineapi.EngineResponse) map[ruleKey]outcome {
+	out := map[ruleKey]outcome{}
+	for _, response := range responses {
+		if response == nil {
+			continue
+		}
+		results := reportutils.EngineResponseToReportResults(response)
+		for i, rule := range response.PolicyResponse.Rules {
+			out[ruleKey{policy: results[i].Policy, rule: rule.Name, ruleType: rule.Type}] = outcome{
+				result:    results[i],
+				status:    rule.Status,
+				patches:   rule.Patches,
+				generated: rule.GeneratedResource,
+			}
+		}
+	}
+	return out
+}
+
+// compare returns a report result for every rule whose outcome on the given resource differs
+// between the old and new engine responses. Rules missing from a response are considered skipped.
+func compare(resource *unstructured.Unstructured, oldResponses, newResponses []*engineapi.EngineResponse) []policyreportv1alpha2.PolicyReportResult {
+	oldOutcomes := outcomes(oldResponses)
+	newOutcomes := outcomes(newResponses)
+	keys := make([]ruleKey, 0, len(oldOutcomes)+len(newOutcomes))
+	for key := range oldOutcomes {
+		keys = append(keys, key)
+	}
+	for key := range newOutcomes {
+		if _, ok := oldOutcomes[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, key := range keys {
+		oldOutcome, inOld := oldOutcomes[key]
+		newOutcome, inNew := newOutcomes[key]
+		if !inOld {
+			oldOutcome = skipped(newOutcome)
+		}
+		if !inNew {
+			newOutcome = skipped(oldOutcome)
+		}
+		change := changeOf(key.ruleType, oldOutcome, newOutcome)
+		if change == "" {
+			continue
+		}
+		result := *newOutcome.result.DeepCopy()
+		result.Resources = []corev1.ObjectReference{resourceReference(resource)}
+		result.Properties = map[string]string{
+			PropertyChange:         string(change),
+			PropertyPreviousResult: string(oldOutcome.result.Result),
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func changeOf(ruleType engineapi.RuleType, oldOutcome, newOutcome outcome) ChangeType {
+	switch ruleType {
+	case engineapi.Mutation:
+		switch {
+		case !oldOutcome.mutated() && newOutcome.mutated():
+			return NewlyMutated
+		case oldOutcome.mutated() && !newOutcome.mutated():
+			return NoLongerMutated
+		case oldOutcome.mutated() && !equalPatches(oldOutcome.patches, newOutcome.patches):
+			return PatchChanged
+		}
+	case engineapi.Generation:
+		switch {
+		case !oldOutcome.hasGenerated() && newOutcome.hasGenerated():
+			return NewlyGenerated
+		case oldOutcome.hasGenerated() && !newOutcome.hasGenerated():
+			return NoLongerGenerated
+		case oldOutcome.hasGenerated() && !reflect.DeepEqual(oldOutcome.generated.Object, newOutcome.generated.Object):
+			return GeneratedResourceChanged
+		}
+	}
+	if oldOutcome.result.Result != newOutcome.result.Result {
+		return ResultChanged
+	}
+	return ""
+}
+
+// skipped returns the outcome of a rule that did not apply to the resource
+func skipped(o outcome) outcome {
+	result := *o.result.DeepCopy()
+	result.Result = policyreportv1alpha2.StatusSkip
+	result.Message = ""
+	return outcome{result: result, status: engineapi.RuleStatusSkip}
+}
+
+func equalPatches(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func resourceReference(resource *unstructured.Unstructured) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: resource.GetAPIVersion(),
+		Kind:       resource.GetKind(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+		UID:        resource.GetUID(),
+	}
+}
+
+// sortResults orders results by resource, policy and rule
+func sortResults(results []policyreportv1alpha2.PolicyReportResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := resourceName(results[i]), resourceName(results[j])
+		if a != b {
+			return a < b
+		}
+		if results[i].Policy != results[j].Policy {
+			return results[i].Policy < results[j].Policy
+		}
+		return results[i].Rule < results[j].Rule
+	})
+}
+
+func resourceName(result policyreportv1alpha2.PolicyReportResult) string {
+	if len(result.Resources) == 0 {
+		return ""
+	}
+	r := result.Resources[0]
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Namespace + "/" + r.Kind + "/" + r.Name
+}
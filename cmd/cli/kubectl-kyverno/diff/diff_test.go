@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newResponse(rules ...engineapi.RuleResponse) *engineapi.EngineResponse {
+	return &engineapi.EngineResponse{
+		Policy:         &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}},
+		PolicyResponse: engineapi.PolicyResponse{Rules: rules},
+	}
+}
+
+func generated(name string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name},
+	}}
+}
+
+func Test_compare(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "pod", "namespace": "default"},
+	}}
+	patchA := [][]byte{[]byte(`{"op":"add","path":"/metadata/labels/env","value":"dev"}`)}
+	patchB := [][]byte{[]byte(`{"op":"add","path":"/metadata/labels/env","value":"prod"}`)}
+	tests := []struct {
+		name     string
+		old      engineapi.RuleResponse
+		new      engineapi.RuleResponse
+		change   ChangeType
+		previous policyreportv1alpha2.PolicyResult
+	}{
+		{
+			name:     "pass to fail",
+			old:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Validation, Status: engineapi.RuleStatusPass},
+			new:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Validation, Status: engineapi.RuleStatusFail},
+			change:   ResultChanged,
+			previous: policyreportv1alpha2.StatusPass,
+		},
+		{
+			name: "same result",
+			old:  engineapi.RuleResponse{Name: "rule", Type: engineapi.Validation, Status: engineapi.RuleStatusFail},
+			new:  engineapi.RuleResponse{Name: "rule", Type: engineapi.Validation, Status: engineapi.RuleStatusFail},
+		},
+		{
+			name:     "newly mutated",
+			old:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Mutation, Status: engineapi.RuleStatusSkip},
+			new:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Mutation, Status: engineapi.RuleStatusPass, Patches: patchA},
+			change:   NewlyMutated,
+			previous: policyreportv1alpha2.StatusSkip,
+		},
+		{
+			name:     "no longer mutated",
+			old:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Mutation, Status: engineapi.RuleStatusPass, Patches: patchA},
+			new:      engineapi.RuleResponse{Name: "other", Type: engineapi.Mutation, Status: engineapi.RuleStatusSkip},
+			change:   NoLongerMutated,
+			previous: policyreportv1alpha2.StatusPass,
+		},
+		{
+			name:     "different patch",
+			old:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Mutation, Status: engineapi.RuleStatusPass, Patches: patchA},
+			new:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Mutation, Status: engineapi.RuleStatusPass, Patches: patchB},
+			change:   PatchChanged,
+			previous: policyreportv1alpha2.StatusPass,
+		},
+		{
+			name: "same patch",
+			old:  engineapi.RuleResponse{Name: "rule", Type: engineapi.Mutation, Status: engineapi.RuleStatusPass, Patches: patchA},
+			new:  engineapi.RuleResponse{Name: "rule", Type: engineapi.Mutation, Status: engineapi.RuleStatusPass, Patches: patchA},
+		},
+		{
+			name:     "newly generated",
+			old:      engineapi.RuleResponse{Name: "other", Type: engineapi.Validation, Status: engineapi.RuleStatusPass},
+			new:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Generation, Status: engineapi.RuleStatusPass, GeneratedResource: generated("a")},
+			change:   NewlyGenerated,
+			previous: policyreportv1alpha2.StatusSkip,
+		},
+		{
+			name:     "different generated resource",
+			old:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Generation, Status: engineapi.RuleStatusPass, GeneratedResource: generated("a")},
+			new:      engineapi.RuleResponse{Name: "rule", Type: engineapi.Generation, Status: engineapi.RuleStatusPass, GeneratedResource: generated("b")},
+			change:   GeneratedResourceChanged,
+			previous: policyreportv1alpha2.StatusPass,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := compare(resource, []*engineapi.EngineResponse{newResponse(test.old)}, []*engineapi.EngineResponse{newResponse(test.new)})
+			var found *policyreportv1alpha2.PolicyReportResult
+			for i := range results {
+				if results[i].Rule == "rule" {
+					found = &results[i]
+				}
+			}
+			if test.change == "" {
+				assert.Assert(t, found == nil)
+				return
+			}
+			assert.Assert(t, found != nil)
+			assert.Equal(t, found.Properties[PropertyChange], string(test.change))
+			assert.Equal(t, found.Properties[PropertyPreviousResult], string(test.previous))
+			assert.Equal(t, len(found.Resources), 1)
+			assert.Equal(t, found.Resources[0].Name, "pod")
+		})
+	}
+}
@@ -0,0 +1,457 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+)
+
+// Level is the severity of a finding, values match the SARIF result levels
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelNote    Level = "note"
+)
+
+func (l Level) rank() int {
+	switch l {
+	case LevelError:
+		return 3
+	case LevelWarning:
+		return 2
+	case LevelNote:
+		return 1
+	}
+	return 0
+}
+
+// AnnotationIgnore lists the comma separated IDs of the checks disabled for a policy
+const AnnotationIgnore = "lint.kyverno.io/ignore"
+
+// Check describes a lint check, IDs are stable and can be used to suppress findings
+type Check struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Level       Level  `json:"level"`
+	Description string `json:"description"`
+	run         func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string
+}
+
+// Checks returns all the available checks ordered by ID
+func Checks() []Check {
+	return []Check{
+		{
+			ID:          "KYV000",
+			Name:        "invalid-policy",
+			Level:       LevelError,
+			Description: "The policy does not pass the validation performed when it is created in a cluster.",
+		},
+		{
+			ID:          "KYV001",
+			Name:        "never-matching-rule",
+			Level:       LevelWarning,
+			Description: "The exclude block of the rule covers every resource selected by its match block, the rule can never apply.",
+			run:         checkNeverMatches,
+		},
+		{
+			ID:          "KYV002",
+			Name:        "unused-context-entry",
+			Level:       LevelWarning,
+			Description: "A context entry is declared but never referenced by a variable, it is evaluated for nothing.",
+			run:         checkUnusedContext,
+		},
+		{
+			ID:          "KYV003",
+			Name:        "variable-without-default",
+			Level:       LevelNote,
+			Description: "A variable references a field that is not guaranteed to be present (labels, annotations, old object) without a default value.",
+			run:         checkVariablesWithoutDefault,
+		},
+		{
+			ID:          "KYV004",
+			Name:        "background-request-variable",
+			Level:       LevelWarning,
+			Description: "A rule processed in background mode references admission request data that is not available during background scans.",
+			run:         checkBackgroundRequestVariables,
+		},
+		{
+			ID:          "KYV005",
+			Name:        "wildcard-kind",
+			Level:       LevelWarning,
+			Description: "A rule matches all kinds, it is evaluated for every admission request and every resource during background scans.",
+			run:         checkWildcardKinds,
+		},
+		{
+			ID:          "KYV006",
+			Name:        "missing-validation-message",
+			Level:       LevelNote,
+			Description: "A validate rule has no message, users get a generic error when their resource is blocked.",
+			run:         checkMissingMessage,
+		},
+		{
+			ID:          "KYV007",
+			Name:        "audit-never-reports",
+			Level:       LevelWarning,
+			Description: "An audit rule can only apply to requests that never produce report results (deletions or events).",
+			run:         checkAuditNeverReports,
+		},
+	}
+}
+
+// Finding is a lint issue found in a policy rule
+type Finding struct {
+	CheckID   string `json:"checkId"`
+	CheckName string `json:"checkName"`
+	Level     Level  `json:"level"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Policy    string `json:"policy"`
+	Rule      string `json:"rule"`
+	Message   string `json:"message"`
+}
+
+// Lint runs the enabled checks on the rules of a policy, checks listed in the policy ignore annotation are skipped
+func Lint(policy kyvernov1.PolicyInterface, disabled map[string]bool) []Finding {
+	ignored := map[string]bool{}
+	for _, id := range strings.Split(policy.GetAnnotations()[AnnotationIgnore], ",") {
+		ignored[strings.TrimSpace(id)] = true
+	}
+	var findings []Finding
+	for _, rule := range policy.GetSpec().Rules {
+		for _, check := range Checks() {
+			if check.run == nil || disabled[check.ID] || ignored[check.ID] {
+				continue
+			}
+			for _, message := range check.run(policy, rule) {
+				findings = append(findings, Finding{
+					CheckID:   check.ID,
+					CheckName: check.Name,
+					Level:     check.Level,
+					Policy:    policyKey(policy),
+					Rule:      rule.Name,
+					Message:   message,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func policyKey(policy kyvernov1.PolicyInterface) string {
+	if policy.GetNamespace() != "" {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+// ruleVariables returns the variables used in a rule, context entries excluded
+func ruleVariables(rule kyvernov1.Rule) []string {
+	rule = *rule.DeepCopy()
+	rule.Context = nil
+	for i := range rule.Validation.ForEachValidation {
+		rule.Validation.ForEachValidation[i].Context = nil
+	}
+	for i := range rule.Mutation.ForEachMutation {
+		rule.Mutation.ForEachMutation[i].Context = nil
+	}
+	raw, _ := json.Marshal(rule)
+	var vars []string
+	for _, match := range variables.RegexVariables.FindAllStringSubmatch(string(raw), -1) {
+		vars = append(vars, match[2])
+	}
+	return vars
+}
+
+// coversDescription returns true if the exclude description unconditionally excludes every resource of the match description
+func coversDescription(exclude, match kyvernov1.ResourceDescription) bool {
+	if exclude.Name != "" || len(exclude.Names) > 0 || len(exclude.Annotations) > 0 || exclude.Selector != nil || exclude.NamespaceSelector != nil {
+		return false
+	}
+	if len(exclude.Kinds) == 0 && len(exclude.Namespaces) == 0 {
+		return false
+	}
+	if len(exclude.Kinds) > 0 && !coversAll(exclude.Kinds, match.Kinds) {
+		return false
+	}
+	if len(exclude.Namespaces) > 0 && !coversAll(exclude.Namespaces, match.Namespaces) {
+		return false
+	}
+	return true
+}
+
+func coversAll(patterns, values []string) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, value := range values {
+		covered := false
+		for _, pattern := range patterns {
+			if pattern == "*" || wildcard.Match(pattern, value) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func checkNeverMatches(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	var excludes []kyvernov1.ResourceDescription
+	if rule.ExcludeResources.UserInfo.IsEmpty() && !rule.ExcludeResources.ResourceDescription.IsEmpty() {
+		excludes = append(excludes, rule.ExcludeResources.ResourceDescription)
+	}
+	for _, filter := range rule.ExcludeResources.Any {
+		if filter.UserInfo.IsEmpty() {
+			excludes = append(excludes, filter.ResourceDescription)
+		}
+	}
+	if len(rule.ExcludeResources.All) == 1 && rule.ExcludeResources.All[0].UserInfo.IsEmpty() {
+		excludes = append(excludes, rule.ExcludeResources.All[0].ResourceDescription)
+	}
+	if len(excludes) == 0 {
+		return nil
+	}
+	covered := func(match kyvernov1.ResourceDescription) bool {
+		for _, exclude := range excludes {
+			if coversDescription(exclude, match) {
+				return true
+			}
+		}
+		return false
+	}
+	// each alternative of the match block must be covered by an exclude filter
+	var alternatives int
+	if !rule.MatchResources.ResourceDescription.IsEmpty() {
+		alternatives++
+		if !covered(rule.MatchResources.ResourceDescription) {
+			return nil
+		}
+	}
+	for _, filter := range rule.MatchResources.Any {
+		alternatives++
+		if !covered(filter.ResourceDescription) {
+			return nil
+		}
+	}
+	if len(rule.MatchResources.All) > 0 {
+		alternatives++
+		allCovered := false
+		for _, filter := range rule.MatchResources.All {
+			if covered(filter.ResourceDescription) {
+				allCovered = true
+				break
+			}
+		}
+		if !allCovered {
+			return nil
+		}
+	}
+	if alternatives == 0 {
+		return nil
+	}
+	return []string{"every resource selected by the match block is excluded, the rule never applies"}
+}
+
+func checkUnusedContext(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	entries := append([]kyvernov1.ContextEntry{}, rule.Context...)
+	for _, fe := range rule.Validation.ForEachValidation {
+		entries = append(entries, fe.Context...)
+	}
+	for _, fe := range rule.Mutation.ForEachMutation {
+		entries = append(entries, fe.Context...)
+	}
+	usages := ruleVariables(rule)
+	var messages []string
+	for i, entry := range entries {
+		if entry.Name == "" {
+			continue
+		}
+		re := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(entry.Name) + `\b`)
+		used := false
+		// context entries can be referenced by the rule or by the other context entries
+		for _, usage := range append(usages, contextUsages(entries, i)...) {
+			if re.MatchString(usage) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			messages = append(messages, fmt.Sprintf("context entry %s is never used", entry.Name))
+		}
+	}
+	return messages
+}
+
+// contextUsages returns the variables and JMESPath expressions of the context entries, except the one at the given index
+func contextUsages(entries []kyvernov1.ContextEntry, skip int) []string {
+	var usages []string
+	for i, entry := range entries {
+		if i == skip {
+			continue
+		}
+		raw, _ := json.Marshal(entry)
+		for _, match := range variables.RegexVariables.FindAllStringSubmatch(string(raw), -1) {
+			usages = append(usages, match[2])
+		}
+		switch {
+		case entry.APICall != nil:
+			usages = append(usages, entry.APICall.JMESPath)
+		case entry.Variable != nil:
+			usages = append(usages, entry.Variable.JMESPath)
+		case entry.ImageRegistry != nil:
+			usages = append(usages, entry.ImageRegistry.JMESPath)
+		}
+	}
+	return usages
+}
+
+var optionalFields = regexp.MustCompile(`request\.(oldObject\b|object\.metadata\.(labels|annotations)\.)`)
+
+func checkVariablesWithoutDefault(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	seen := map[string]bool{}
+	var messages []string
+	for _, v := range ruleVariables(rule) {
+		if seen[v] || !optionalFields.MatchString(v) || strings.Contains(v, "||") {
+			continue
+		}
+		seen[v] = true
+		messages = append(messages, fmt.Sprintf("variable %s may not be present, add a default value with ||", v))
+	}
+	return messages
+}
+
+var requestFields = regexp.MustCompile(`request\.(\w+)`)
+
+func checkBackgroundRequestVariables(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	if !policy.GetSpec().BackgroundProcessingEnabled() || rule.IsMutateExisting() || rule.HasGenerate() {
+		return nil
+	}
+	fields := map[string]bool{}
+	for _, v := range ruleVariables(rule) {
+		for _, match := range requestFields.FindAllStringSubmatch(v, -1) {
+			if match[1] != "object" {
+				fields["request."+match[1]] = true
+			}
+		}
+	}
+	var messages []string
+	for _, field := range sortedKeys(fields) {
+		messages = append(messages, fmt.Sprintf("%s is not available in background scans, set spec.background to false or avoid the variable", field))
+	}
+	return messages
+}
+
+func ruleKinds(rule kyvernov1.Rule) []string {
+	kinds := append([]string{}, rule.MatchResources.Kinds...)
+	for _, filter := range rule.MatchResources.Any {
+		kinds = append(kinds, filter.Kinds...)
+	}
+	for _, filter := range rule.MatchResources.All {
+		kinds = append(kinds, filter.Kinds...)
+	}
+	return kinds
+}
+
+func checkWildcardKinds(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	for _, kind := range ruleKinds(rule) {
+		if kind == "*" || strings.HasSuffix(kind, "/*") {
+			return []string{fmt.Sprintf("kind %s matches every resource, restrict the rule to the kinds it needs", kind)}
+		}
+	}
+	return nil
+}
+
+func checkMissingMessage(_ kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	if !rule.HasValidate() || rule.Validation.PodSecurity != nil || rule.Validation.Manifests != nil {
+		return nil
+	}
+	if strings.TrimSpace(rule.Validation.Message) == "" {
+		return []string{"validate rule has no message"}
+	}
+	return nil
+}
+
+func checkAuditNeverReports(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) []string {
+	if !policy.GetSpec().ValidationFailureAction.Audit() || !rule.HasValidate() {
+		return nil
+	}
+	kinds := ruleKinds(rule)
+	if len(kinds) > 0 {
+		onlyEvents := true
+		for _, kind := range kinds {
+			if kind != "Event" && !strings.HasSuffix(kind, "/Event") {
+				onlyEvents = false
+				break
+			}
+		}
+		if onlyEvents {
+			return []string{"the rule only matches events, results are never reported for events"}
+		}
+	}
+	if onlyDeleteOperation(rule) {
+		return []string{"the rule only applies to DELETE requests, results are never reported for deletions"}
+	}
+	return nil
+}
+
+// onlyDeleteOperation returns true when the rule preconditions require the request operation to be DELETE
+func onlyDeleteOperation(rule kyvernov1.Rule) bool {
+	raw, err := json.Marshal(rule.GetAnyAllConditions())
+	if err != nil {
+		return false
+	}
+	var conditions kyvernov1.AnyAllConditions
+	if err := json.Unmarshal(raw, &conditions); err != nil {
+		// preconditions can also be a list of conditions that must all pass
+		if err := json.Unmarshal(raw, &conditions.AllConditions); err != nil {
+			return false
+		}
+	}
+	for _, condition := range conditions.AllConditions {
+		if isDeleteCondition(condition) {
+			return true
+		}
+	}
+	if len(conditions.AnyConditions) == 0 {
+		return false
+	}
+	for _, condition := range conditions.AnyConditions {
+		if !isDeleteCondition(condition) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDeleteCondition(condition kyvernov1.Condition) bool {
+	key, _ := json.Marshal(condition.GetKey())
+	if !strings.Contains(string(key), "request.operation") {
+		return false
+	}
+	switch condition.Operator {
+	case kyvernov1.ConditionOperators["Equal"], kyvernov1.ConditionOperators["Equals"], kyvernov1.ConditionOperators["In"], kyvernov1.ConditionOperators["AnyIn"], kyvernov1.ConditionOperators["AllIn"]:
+		value, _ := json.Marshal(condition.GetValue())
+		return strings.Trim(string(value), `[]"`) == "DELETE"
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,286 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+func lintPolicy(t *testing.T, policy string, disabled map[string]bool) []string {
+	policies, err := yamlutils.GetPolicy([]byte(policy))
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies), 1)
+	var ids []string
+	for _, finding := range Lint(policies[0], disabled) {
+		ids = append(ids, finding.CheckID)
+	}
+	return ids
+}
+
+func Test_Lint(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		want   []string
+	}{
+		{
+			name: "clean",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: clean
+spec:
+  validationFailureAction: Audit
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    exclude:
+      any:
+      - resources:
+          namespaces: [kube-system]
+    context:
+    - name: team
+      variable:
+        jmesPath: request.object.metadata.labels.team || ''
+    validate:
+      message: "team {{ team }} is not allowed"
+      pattern:
+        metadata:
+          labels:
+            team: "?*"`,
+		},
+		{
+			name: "never matching rule",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: never
+spec:
+  rules:
+  - name: check
+    match:
+      resources:
+        kinds: [Pod, Deployment]
+        namespaces: [prod]
+    exclude:
+      resources:
+        namespaces: ["pro*"]
+    validate:
+      message: "no"
+      deny: {}`,
+			want: []string{"KYV001"},
+		},
+		{
+			name: "unused context entries",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: context
+spec:
+  background: false
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    context:
+    - name: unused
+      variable:
+        value: foo
+    - name: base
+      apiCall:
+        urlPath: /api/v1/namespaces
+    - name: names
+      variable:
+        jmesPath: base.items[].metadata.name
+    validate:
+      message: "{{ names }}"
+      deny: {}`,
+			want: []string{"KYV002"},
+		},
+		{
+			name: "variables without default and background request variables",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: variables
+spec:
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    validate:
+      message: "{{ request.object.metadata.labels.team }} {{ request.object.metadata.annotations.owner || '' }} {{ request.operation }}"
+      deny: {}`,
+			want: []string{"KYV003", "KYV004"},
+		},
+		{
+			name: "wildcard kinds and missing message",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: wildcard
+spec:
+  background: false
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds: ["*"]
+    validate:
+      pattern:
+        metadata:
+          name: "?*"`,
+			want: []string{"KYV005", "KYV006"},
+		},
+		{
+			name: "audit rule on deletions",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: deletions
+spec:
+  validationFailureAction: Audit
+  background: false
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    preconditions:
+      any:
+      - key: "{{ request.operation }}"
+        operator: In
+        value: [DELETE]
+    validate:
+      message: "no"
+      deny: {}`,
+			want: []string{"KYV007"},
+		},
+		{
+			name: "audit rule on events",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: events
+spec:
+  validationFailureAction: Audit
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds: [Event, events.k8s.io/v1/Event]
+    validate:
+      message: "no"
+      deny: {}`,
+			want: []string{"KYV007"},
+		},
+		{
+			name: "checks ignored by annotation",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: ignored
+  annotations:
+    lint.kyverno.io/ignore: KYV005, KYV006
+spec:
+  background: false
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds: ["*"]
+    validate:
+      pattern:
+        metadata:
+          name: "?*"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.DeepEqual(t, lintPolicy(t, test.policy, nil), test.want)
+		})
+	}
+}
+
+func Test_LintDisabled(t *testing.T) {
+	policy := `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: wildcard
+spec:
+  background: false
+  rules:
+  - name: check
+    match:
+      any:
+      - resources:
+          kinds: ["*"]
+    validate:
+      pattern:
+        metadata:
+          name: "?*"`
+	assert.DeepEqual(t, lintPolicy(t, policy, map[string]bool{"KYV005": true}), []string{"KYV006"})
+}
+
+func Test_printSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NilError(t, printSARIF(&buf, []Finding{{
+		CheckID:   "KYV005",
+		CheckName: "wildcard-kind",
+		Level:     LevelWarning,
+		File:      "policy.yaml",
+		Line:      12,
+		Policy:    "wildcard",
+		Rule:      "check",
+		Message:   "kind * matches every resource",
+	}}))
+	var log sarifLog
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, log.Version, "2.1.0")
+	assert.Equal(t, len(log.Runs), 1)
+	assert.Equal(t, len(log.Runs[0].Tool.Driver.Rules), len(Checks()))
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, result.RuleID, "KYV005")
+	assert.Equal(t, log.Runs[0].Tool.Driver.Rules[result.RuleIndex].ID, "KYV005")
+	assert.Equal(t, result.Locations[0].PhysicalLocation.Region.StartLine, 12)
+}
+
+func Test_locate(t *testing.T) {
+	data := `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: policy
+spec:
+  rules:
+  - name: first
+  - name: second`
+	policies, err := yamlutils.GetPolicy([]byte(data))
+	assert.NilError(t, err)
+	lines := strings.Split(data, "\n")
+	assert.Equal(t, locate(lines, policies[0], "second"), 8)
+	assert.Equal(t, locate(lines, policies[0], ""), 4)
+}
@@ -0,0 +1,216 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/pkg/openapi"
+	policyvalidation "github.com/kyverno/kyverno/pkg/policy"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var exampleHelp = `
+To lint policies:
+        kyverno lint /path/to/policy.yaml /path/to/folderOfPolicies
+
+To produce a SARIF report, for code scanning tools:
+        kyverno lint /path/to/folderOfPolicies --output sarif > kyverno.sarif
+
+To disable some checks:
+        kyverno lint /path/to/folderOfPolicies --disable KYV003 --disable KYV006
+
+Checks can also be disabled for a single policy with the lint.kyverno.io/ignore annotation:
+        metadata:
+          annotations:
+            lint.kyverno.io/ignore: KYV003,KYV006
+
+To list the available checks:
+        kyverno lint --list-checks
+`
+
+var osExit = os.Exit
+
+type options struct {
+	output     string
+	disable    []string
+	failOn     string
+	listChecks bool
+}
+
+// Command returns the lint command
+func Command() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:     "lint [policies...]",
+		Short:   "Checks policies for quality issues.",
+		Long:    "Validates policies and reports quality issues like rules that never match, unused context entries, variables that may be missing or rules matching all kinds.",
+		Example: exampleHelp,
+		RunE: func(cmd *cobra.Command, policyPaths []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+			if o.listChecks {
+				for _, check := range Checks() {
+					fmt.Printf("%s %-28s %-8s %s\n", check.ID, check.Name, check.Level, check.Description)
+				}
+				return nil
+			}
+			findings, err := o.execute(policyPaths)
+			if err != nil {
+				return err
+			}
+			if err := o.print(findings); err != nil {
+				return sanitizederror.NewWithError("failed to print findings", err)
+			}
+			threshold := Level(o.failOn).rank()
+			for _, finding := range findings {
+				if threshold > 0 && finding.Level.rank() >= threshold {
+					osExit(1)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&o.output, "output", "o", "text", "Output format, one of text, json or sarif")
+	cmd.Flags().StringArrayVarP(&o.disable, "disable", "d", []string{}, "ID of a check to disable, can be repeated")
+	cmd.Flags().StringVarP(&o.failOn, "fail-on", "", string(LevelWarning), "Exit with 1 if a finding has at least the given level, one of error, warning, note or none")
+	cmd.Flags().BoolVarP(&o.listChecks, "list-checks", "", false, "List the available checks")
+	return cmd
+}
+
+func (o options) execute(paths []string) ([]Finding, error) {
+	switch o.output {
+	case "text", "json", "sarif":
+	default:
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("unsupported output format %s", o.output), nil)
+	}
+	switch Level(o.failOn) {
+	case LevelError, LevelWarning, LevelNote, "none":
+	default:
+		return nil, sanitizederror.NewWithError(fmt.Sprintf("unsupported level %s", o.failOn), nil)
+	}
+	if len(paths) == 0 {
+		return nil, sanitizederror.NewWithError("require policy", nil)
+	}
+	files, err := listFiles(paths)
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to list policy files", err)
+	}
+	openApiManager, err := openapi.NewManager(log.Log)
+	if err != nil {
+		return nil, sanitizederror.NewWithError("failed to initialize openAPIController", err)
+	}
+	disabled := map[string]bool{}
+	for _, id := range o.disable {
+		disabled[id] = true
+	}
+	var findings []Finding
+	for _, file := range files {
+		// We accept the risk of including a user provided file here.
+		data, err := os.ReadFile(filepath.Clean(file)) // #nosec G304
+		if err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to read %s", file), err)
+		}
+		policies, err := yamlutils.GetPolicy(data)
+		if err != nil {
+			return nil, sanitizederror.NewWithError(fmt.Sprintf("failed to load policies from %s", file), err)
+		}
+		lines := strings.Split(string(data), "\n")
+		for _, policy := range policies {
+			var policyFindings []Finding
+			if _, err := policyvalidation.Validate(policy, nil, true, openApiManager); err != nil && !disabled["KYV000"] {
+				policyFindings = append(policyFindings, Finding{
+					CheckID:   "KYV000",
+					CheckName: "invalid-policy",
+					Level:     LevelError,
+					Policy:    policyKey(policy),
+					Message:   strings.TrimSpace(err.Error()),
+				})
+			}
+			policyFindings = append(policyFindings, Lint(policy, disabled)...)
+			for i := range policyFindings {
+				policyFindings[i].File = file
+				policyFindings[i].Line = locate(lines, policy, policyFindings[i].Rule)
+			}
+			findings = append(findings, policyFindings...)
+		}
+	}
+	return findings, nil
+}
+
+func (o options) print(findings []Finding) error {
+	switch o.output {
+	case "json":
+		return printJSON(os.Stdout, findings)
+	case "sarif":
+		return printSARIF(os.Stdout, findings)
+	default:
+		printText(os.Stdout, findings)
+	}
+	return nil
+}
+
+// listFiles returns the yaml files found in the given paths, directories are walked recursively
+func listFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(file string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := filepath.Ext(file)
+			if !d.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// locate returns the line of the rule, or of the policy if the rule is not found, in the policy file
+func locate(lines []string, policy kyvernov1.PolicyInterface, rule string) int {
+	policyLine := findLine(lines, 0, `^\s*name:\s*["']?`+regexp.QuoteMeta(policy.GetName())+`["']?\s*$`)
+	if policyLine < 0 {
+		return 0
+	}
+	if rule != "" {
+		if ruleLine := findLine(lines, policyLine+1, `^\s*(-\s+)?name:\s*["']?`+regexp.QuoteMeta(rule)+`["']?\s*$`); ruleLine >= 0 {
+			return ruleLine + 1
+		}
+	}
+	return policyLine + 1
+}
+
+func findLine(lines []string, from int, pattern string) int {
+	re := regexp.MustCompile(pattern)
+	for i := from; i < len(lines); i++ {
+		if re.MatchString(lines[i]) {
+			return i
+		}
+	}
+	return -1
+}
@@ -6,10 +6,16 @@ import (
 	"strconv"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apply"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/cleanup"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/compliance"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/diff"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/lint"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/oci"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/version"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/violations"
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/klogr"
@@ -33,6 +39,12 @@ func main() {
 		apply.Command(),
 		test.Command(),
 		jp.Command(),
+		lint.Command(),
+		snapshot.Command(),
+		diff.Command(),
+		cleanup.Command(),
+		violations.Command(),
+		compliance.Command(),
 	}
 
 	if enableExperimental() {
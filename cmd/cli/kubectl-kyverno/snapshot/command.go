@@ -0,0 +1,106 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/snapshot"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var exampleHelp = `
+To capture the resources matched by policies:
+        kyverno snapshot /path/to/policy.yaml /path/to/folderOfPolicies -o cluster.snapshot
+
+To capture specific kinds in a namespace:
+        kyverno snapshot --kind Deployment --kind apps/v1/StatefulSet --namespace prod -o cluster.snapshot
+
+Namespaces, ConfigMaps, ServiceAccounts and RBAC resources are always captured.
+The snapshot can then be used offline with:
+        kyverno apply /path/to/policy.yaml --snapshot cluster.snapshot
+        kyverno test /path/to/tests --snapshot cluster.snapshot
+`
+
+type options struct {
+	kubeConfig string
+	context    string
+	namespace  string
+	output     string
+	kinds      []string
+}
+
+// Command returns the snapshot command
+func Command() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:     "snapshot [policies...]",
+		Short:   "Captures cluster resources into a local snapshot.",
+		Long:    "Captures the resources needed to evaluate policies offline (matched resources, namespaces, configmaps and RBAC) into a local snapshot usable with the --snapshot flag of apply and test commands.",
+		Example: exampleHelp,
+		RunE: func(cmd *cobra.Command, policyPaths []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+			return o.execute(cmd, policyPaths)
+		},
+	}
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", "Path of the snapshot file to write")
+	cmd.Flags().StringArrayVarP(&o.kinds, "kind", "k", []string{}, "Additional kinds to capture, in the Kind or Group/Version/Kind format")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "", "Restrict namespaced resources to the given namespace")
+	cmd.Flags().StringVarP(&o.kubeConfig, "kubeconfig", "", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVarP(&o.context, "context", "", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+func (o options) execute(cmd *cobra.Command, policyPaths []string) error {
+	if o.output == "" {
+		return sanitizederror.NewWithError("an output file is required", nil)
+	}
+	dClient, err := common.NewClusterClient(o.kubeConfig, o.context)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to create cluster client", err)
+	}
+	kinds := append([]string{}, snapshot.DefaultKinds...)
+	kinds = append(kinds, o.kinds...)
+	if len(policyPaths) > 0 {
+		policies, err := common.GetPoliciesFromPaths(memfs.New(), policyPaths, false, "")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to load policies", err)
+		}
+		var policyKinds []string
+		for _, policy := range policies {
+			for _, rule := range autogen.ComputeRules(policy) {
+				gvks, _ := common.GetKindsFromRule(rule, dClient)
+				for gvk := range gvks {
+					policyKinds = append(policyKinds, gvk.GroupVersion().String()+"/"+gvk.Kind)
+				}
+			}
+		}
+		sort.Strings(policyKinds)
+		kinds = append(kinds, policyKinds...)
+	}
+	clusterSnapshot, errs := snapshot.Capture(cmd.Context(), dClient, o.namespace, kinds...)
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+	}
+	if err := clusterSnapshot.WriteFile(o.output); err != nil {
+		return sanitizederror.NewWithError("failed to write snapshot", err)
+	}
+	var count int
+	for _, list := range clusterSnapshot.Resources {
+		count += len(list.Items)
+	}
+	fmt.Printf("Captured %d resources of %d kinds in %s\n", count, len(clusterSnapshot.Resources), o.output)
+	return nil
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -9,9 +10,11 @@ type Test struct {
 	Name      string        `json:"name"`
 	Policies  []string      `json:"policies"`
 	Resources []string      `json:"resources"`
-	Variables string        `json:"variables"`
-	UserInfo  string        `json:"userinfo"`
+	Variables string        `json:"variables,omitempty"`
+	UserInfo  string        `json:"userinfo,omitempty"`
 	Results   []TestResults `json:"results"`
+	// Mocks declares mocked responses for apiCall, imageRegistry and configMap context entries.
+	Mocks *store.Mocks `json:"mocks,omitempty"`
 }
 
 type TestResults struct {
@@ -24,27 +27,27 @@ type TestResults struct {
 	Result policyreportv1alpha2.PolicyResult `json:"result"`
 	// Status mentions the status that the user is expecting.
 	// Possible values are pass, fail and skip.
-	Status policyreportv1alpha2.PolicyResult `json:"status"`
+	Status policyreportv1alpha2.PolicyResult `json:"status,omitempty"`
 	// Resource mentions the name of the resource on which the policy is to be applied.
-	Resource string `json:"resource"`
+	Resource string `json:"resource,omitempty"`
 	// Resources gives us the list of resources on which the policy is going to be applied.
 	Resources []string `json:"resources"`
 	// Kind mentions the kind of the resource on which the policy is to be applied.
-	Kind string `json:"kind"`
+	Kind string `json:"kind,omitempty"`
 	// Namespace mentions the namespace of the policy which has namespace scope.
-	Namespace string `json:"namespace"`
+	Namespace string `json:"namespace,omitempty"`
 	// PatchedResource takes a resource configuration file in yaml format from
 	// the user to compare it against the Kyverno mutated resource configuration.
-	PatchedResource string `json:"patchedResource"`
+	PatchedResource string `json:"patchedResource,omitempty"`
 	// AutoGeneratedRule is internally set by the CLI command. It takes values either
 	// autogen or autogen-cronjob.
-	AutoGeneratedRule string `json:"auto_generated_rule"`
+	AutoGeneratedRule string `json:"auto_generated_rule,omitempty"`
 	// GeneratedResource takes a resource configuration file in yaml format from
 	// the user to compare it against the Kyverno generated resource configuration.
-	GeneratedResource string `json:"generatedResource"`
+	GeneratedResource string `json:"generatedResource,omitempty"`
 	// CloneSourceResource takes the resource configuration file in yaml format
 	// from the user which is meant to be cloned by the generate rule.
-	CloneSourceResource string `json:"cloneSourceResource"`
+	CloneSourceResource string `json:"cloneSourceResource,omitempty"`
 }
 
 type ReportResult struct {
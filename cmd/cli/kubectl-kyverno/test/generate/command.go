@@ -0,0 +1,143 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/snapshot"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	PolicyFile    = "policy.yaml"
+	ResourcesFile = "resources.yaml"
+	TestFile      = "kyverno-test.yaml"
+)
+
+var exampleHelp = `
+To generate a test for a cluster policy from the reports of the current cluster:
+        kyverno test generate --policy require-labels -o tests/require-labels
+
+To generate a test for a namespaced policy:
+        kyverno test generate --policy prod/require-labels -o tests/require-labels
+
+To generate a test from a snapshot, the snapshot must contain the policy, the reports and the reported resources:
+        kyverno snapshot --kind kyverno.io/v1/ClusterPolicy --kind kyverno.io/v1alpha2/BackgroundScanReport --kind Pod -o cluster.snapshot
+        kyverno test generate --policy require-labels --snapshot cluster.snapshot -o tests/require-labels
+
+The generated directory contains the policy, the reported resources and a kyverno-test.yaml file
+with the results found in AdmissionReport and BackgroundScanReport objects.
+`
+
+type options struct {
+	kubeConfig string
+	context    string
+	namespace  string
+	snapshot   string
+	policy     string
+	output     string
+}
+
+// Command returns the test generate command
+func Command() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:     "generate",
+		Short:   "Generates a test directory from admission and background scan reports.",
+		Long:    "Reads the AdmissionReport and BackgroundScanReport objects of a policy, fetches the policy and the reported resources and writes a test directory with the expected results.",
+		Example: exampleHelp,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+			return o.execute(cmd)
+		},
+	}
+	cmd.Flags().StringVarP(&o.policy, "policy", "p", "", "Name of the policy, in the namespace/name format for namespaced policies")
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", "Path of the test directory to write")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "", "Restrict namespaced reports to the given namespace")
+	cmd.Flags().StringVarP(&o.snapshot, "snapshot", "", "", "Path to a cluster snapshot used instead of a live cluster")
+	cmd.Flags().StringVarP(&o.kubeConfig, "kubeconfig", "", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVarP(&o.context, "context", "", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+func (o options) execute(cmd *cobra.Command) error {
+	if o.policy == "" {
+		return sanitizederror.NewWithError("a policy is required", nil)
+	}
+	if o.output == "" {
+		return sanitizederror.NewWithError("an output directory is required", nil)
+	}
+	var dClient dclient.Interface
+	if o.snapshot != "" {
+		clusterSnapshot, err := snapshot.ReadFile(o.snapshot)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to load snapshot", err)
+		}
+		dClient = snapshot.NewClient(clusterSnapshot)
+	} else {
+		var err error
+		dClient, err = common.NewClusterClient(o.kubeConfig, o.context)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to create cluster client", err)
+		}
+	}
+	tc, warnings, err := Generate(cmd.Context(), dClient, o.policy, o.namespace)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	if err != nil {
+		return sanitizederror.NewWithError("failed to generate test", err)
+	}
+	if err := tc.Write(o.output); err != nil {
+		return sanitizederror.NewWithError("failed to write test", err)
+	}
+	fmt.Printf("Generated test with %d resources and %d results in %s\n", len(tc.Resources), len(tc.Test.Results), o.output)
+	return nil
+}
+
+// Write stores the test case files in the given directory
+func (tc *TestCase) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	policy, err := yaml.Marshal(tc.Policy.Object)
+	if err != nil {
+		return err
+	}
+	var resources bytes.Buffer
+	for i, resource := range tc.Resources {
+		data, err := yaml.Marshal(resource.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			resources.WriteString("---\n")
+		}
+		resources.Write(data)
+	}
+	test, err := yaml.Marshal(tc.Test)
+	if err != nil {
+		return err
+	}
+	for name, data := range map[string][]byte{PolicyFile: policy, ResourcesFile: resources.Bytes(), TestFile: test} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
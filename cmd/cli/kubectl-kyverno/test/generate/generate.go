@@ -0,0 +1,276 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// reportKinds are the intermediary report kinds read to build the test results,
+// background scan reports are listed last so that they take precedence over admission reports
+var reportKinds = []string{"ClusterAdmissionReport", "AdmissionReport", "ClusterBackgroundScanReport", "BackgroundScanReport"}
+
+// TestCase holds the content of a generated test directory
+type TestCase struct {
+	Policy    *unstructured.Unstructured
+	Resources []*unstructured.Unstructured
+	Test      api.Test
+}
+
+type resultKey struct {
+	rule      string
+	kind      string
+	namespace string
+	name      string
+}
+
+type groupKey struct {
+	rule      string
+	kind      string
+	namespace string
+	result    policyreportv1alpha2.PolicyResult
+}
+
+type generator struct {
+	client    dclient.Interface
+	policy    string
+	namespace string
+	warnings  []string
+}
+
+// Generate builds a test case for the given policy from the admission and background scan reports
+// available through the client. The policy is identified by its name or namespace/name for namespaced policies.
+func Generate(ctx context.Context, client dclient.Interface, policy, namespace string) (*TestCase, []string, error) {
+	g := &generator{client: client, policy: policy, namespace: namespace}
+	tc, err := g.generate(ctx)
+	return tc, g.warnings, err
+}
+
+func (g *generator) warn(format string, args ...interface{}) {
+	g.warnings = append(g.warnings, fmt.Sprintf(format, args...))
+}
+
+func (g *generator) generate(ctx context.Context) (*TestCase, error) {
+	policy, err := g.getPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := g.listReports(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := map[resultKey]policyreportv1alpha2.PolicyResult{}
+	for _, report := range reports {
+		for _, result := range report.GetResults() {
+			if result.Policy != g.policy || result.Result == policyreportv1alpha2.StatusError {
+				continue
+			}
+			refs := result.Resources
+			if len(refs) == 0 {
+				owner, ok := reportOwner(report)
+				if !ok {
+					continue
+				}
+				refs = []corev1.ObjectReference{owner}
+			}
+			for _, ref := range refs {
+				key := resultKey{rule: result.Rule, kind: ref.APIVersion + "/" + ref.Kind, namespace: ref.Namespace, name: ref.Name}
+				results[key] = result.Result
+			}
+		}
+	}
+	tc := &TestCase{
+		Policy: policy,
+		Test: api.Test{
+			Name:      strings.ReplaceAll(g.policy, "/", "-"),
+			Policies:  []string{PolicyFile},
+			Resources: []string{ResourcesFile},
+		},
+	}
+	resources := map[resultKey]*unstructured.Unstructured{}
+	grouped := map[groupKey][]string{}
+	for key, result := range results {
+		resourceKey := resultKey{kind: key.kind, namespace: key.namespace, name: key.name}
+		resource, found := resources[resourceKey]
+		if !found {
+			apiVersion, kind := splitKind(key.kind)
+			resource, err = g.client.GetResource(ctx, apiVersion, kind, key.namespace, key.name)
+			if err != nil {
+				g.warn("skipping results for %s %s/%s: %s", kind, key.namespace, key.name, err)
+			} else {
+				cleanResource(resource)
+			}
+			resources[resourceKey] = resource
+		}
+		if resource == nil {
+			continue
+		}
+		group := groupKey{rule: key.rule, kind: resource.GetKind(), namespace: key.namespace, result: result}
+		grouped[group] = append(grouped[group], key.name)
+	}
+	for _, resource := range resources {
+		if resource != nil {
+			tc.Resources = append(tc.Resources, resource)
+		}
+	}
+	sort.Slice(tc.Resources, func(i, j int) bool {
+		return resourceID(tc.Resources[i]) < resourceID(tc.Resources[j])
+	})
+	for group, names := range grouped {
+		sort.Strings(names)
+		tc.Test.Results = append(tc.Test.Results, api.TestResults{
+			Policy:    g.policy,
+			Rule:      group.rule,
+			Result:    group.result,
+			Kind:      group.kind,
+			Namespace: group.namespace,
+			Resources: names,
+		})
+	}
+	sort.Slice(tc.Test.Results, func(i, j int) bool {
+		a, b := tc.Test.Results[i], tc.Test.Results[j]
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Result < b.Result
+	})
+	if len(tc.Test.Results) == 0 {
+		return nil, fmt.Errorf("no report results found for policy %s", g.policy)
+	}
+	return tc, nil
+}
+
+func (g *generator) getPolicy(ctx context.Context) (*unstructured.Unstructured, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(g.policy)
+	if err != nil {
+		return nil, err
+	}
+	kind := "ClusterPolicy"
+	if namespace != "" {
+		kind = "Policy"
+	}
+	policy, err := g.client.GetResource(ctx, "kyverno.io/v1", kind, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy %s: %w", g.policy, err)
+	}
+	cleanResource(policy)
+	unstructured.RemoveNestedField(policy.Object, "status")
+	return policy, nil
+}
+
+func (g *generator) listReports(ctx context.Context) ([]kyvernov1alpha2.ReportInterface, error) {
+	var reports []kyvernov1alpha2.ReportInterface
+	for _, kind := range reportKinds {
+		namespace := g.namespace
+		if strings.HasPrefix(kind, "Cluster") {
+			namespace = ""
+		}
+		list, err := g.client.ListResource(ctx, kyvernov1alpha2.SchemeGroupVersion.String(), kind, namespace, nil)
+		if err != nil {
+			g.warn("failed to list %s: %s", kind, err)
+			continue
+		}
+		var kindReports []kyvernov1alpha2.ReportInterface
+		for _, item := range list.Items {
+			report, err := toReport(kind, item)
+			if err != nil {
+				g.warn("failed to decode %s %s: %s", kind, item.GetName(), err)
+				continue
+			}
+			kindReports = append(kindReports, report)
+		}
+		// the most recent report wins
+		sort.SliceStable(kindReports, func(i, j int) bool {
+			a, b := kindReports[i].GetCreationTimestamp(), kindReports[j].GetCreationTimestamp()
+			return a.Before(&b)
+		})
+		reports = append(reports, kindReports...)
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no admission or background scan reports found")
+	}
+	return reports, nil
+}
+
+func toReport(kind string, item unstructured.Unstructured) (kyvernov1alpha2.ReportInterface, error) {
+	var report kyvernov1alpha2.ReportInterface
+	switch kind {
+	case "AdmissionReport":
+		report = &kyvernov1alpha2.AdmissionReport{}
+	case "ClusterAdmissionReport":
+		report = &kyvernov1alpha2.ClusterAdmissionReport{}
+	case "BackgroundScanReport":
+		report = &kyvernov1alpha2.BackgroundScanReport{}
+	case "ClusterBackgroundScanReport":
+		report = &kyvernov1alpha2.ClusterBackgroundScanReport{}
+	default:
+		return nil, fmt.Errorf("unsupported report kind %s", kind)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// reportOwner returns a reference to the resource a report was created for
+func reportOwner(report kyvernov1alpha2.ReportInterface) (corev1.ObjectReference, bool) {
+	var owner metav1.OwnerReference
+	switch r := report.(type) {
+	case *kyvernov1alpha2.AdmissionReport:
+		owner = r.Spec.Owner
+	case *kyvernov1alpha2.ClusterAdmissionReport:
+		owner = r.Spec.Owner
+	}
+	if owner.Name == "" {
+		if len(report.GetOwnerReferences()) != 1 {
+			return corev1.ObjectReference{}, false
+		}
+		owner = report.GetOwnerReferences()[0]
+	}
+	return corev1.ObjectReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Namespace:  report.GetNamespace(),
+		Name:       owner.Name,
+		UID:        reportutils.GetResourceUid(report),
+	}, true
+}
+
+// cleanResource removes the server populated fields from a resource
+func cleanResource(resource *unstructured.Unstructured) {
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(resource.Object, "metadata", field)
+	}
+	annotations := resource.GetAnnotations()
+	if _, ok := annotations["kubectl.kubernetes.io/last-applied-configuration"]; ok {
+		delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		resource.SetAnnotations(annotations)
+	}
+}
+
+func splitKind(kind string) (string, string) {
+	i := strings.LastIndex(kind, "/")
+	return kind[:i], kind[i+1:]
+}
+
+func resourceID(resource *unstructured.Unstructured) string {
+	return strings.Join([]string{resource.GetKind(), resource.GetNamespace(), resource.GetName()}, "/")
+}
@@ -0,0 +1,130 @@
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/snapshot"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func pod(name string, labels map[string]interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            name,
+			"namespace":       "default",
+			"uid":             name + "-uid",
+			"resourceVersion": "1",
+			"labels":          labels,
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "nginx", "image": "nginx"}},
+		},
+	}}
+}
+
+func result(rule, status string, resources ...interface{}) interface{} {
+	r := map[string]interface{}{"policy": "require-labels", "rule": rule, "result": status, "source": "kyverno"}
+	if len(resources) > 0 {
+		r["resources"] = resources
+	}
+	return r
+}
+
+func newTestSnapshot() *snapshot.Snapshot {
+	s := snapshot.New("")
+	s.Add(snapshot.ResourceList{
+		Group:    "kyverno.io",
+		Version:  "v1",
+		Resource: "clusterpolicies",
+		Kind:     "ClusterPolicy",
+		Items: []unstructured.Unstructured{{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "require-labels", "resourceVersion": "10"},
+			"spec":     map[string]interface{}{"rules": []interface{}{}},
+			"status":   map[string]interface{}{"ready": true},
+		}}},
+	})
+	s.Add(snapshot.ResourceList{
+		Version:    "v1",
+		Resource:   "pods",
+		Kind:       "Pod",
+		Namespaced: true,
+		Items: []unstructured.Unstructured{
+			pod("good", map[string]interface{}{"team": "a"}),
+			pod("bad", nil),
+		},
+	})
+	s.Add(snapshot.ResourceList{
+		Group:      "kyverno.io",
+		Version:    "v1alpha2",
+		Resource:   "backgroundscanreports",
+		Kind:       "BackgroundScanReport",
+		Namespaced: true,
+		Items: []unstructured.Unstructured{
+			{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":            "good-uid",
+					"namespace":       "default",
+					"ownerReferences": []interface{}{map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "name": "good", "uid": "good-uid"}},
+				},
+				"spec": map[string]interface{}{"results": []interface{}{
+					result("check-team", "pass"),
+					map[string]interface{}{"policy": "other", "rule": "check", "result": "fail"},
+				}},
+			}},
+		},
+	})
+	s.Add(snapshot.ResourceList{
+		Group:      "kyverno.io",
+		Version:    "v1alpha2",
+		Resource:   "admissionreports",
+		Kind:       "AdmissionReport",
+		Namespaced: true,
+		Items: []unstructured.Unstructured{
+			{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "aggregate", "namespace": "default"},
+				"spec": map[string]interface{}{
+					"owner": map[string]interface{}{"apiVersion": "", "kind": "", "name": "", "uid": ""},
+					"results": []interface{}{
+						result("check-team", "fail", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "bad"}),
+						result("check-team", "fail", map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "deleted"}),
+					},
+				},
+			}},
+		},
+	})
+	return s
+}
+
+func Test_Generate(t *testing.T) {
+	tc, warnings, err := Generate(context.TODO(), snapshot.NewClient(newTestSnapshot()), "require-labels", "")
+	assert.NilError(t, err)
+	assert.Equal(t, len(warnings), 3)
+	assert.Equal(t, tc.Policy.GetName(), "require-labels")
+	assert.Equal(t, tc.Policy.GetResourceVersion(), "")
+	_, found, _ := unstructured.NestedMap(tc.Policy.Object, "status")
+	assert.Assert(t, !found)
+	assert.Equal(t, len(tc.Resources), 2)
+	assert.Equal(t, tc.Resources[0].GetName(), "bad")
+	assert.Equal(t, string(tc.Resources[0].GetUID()), "")
+	assert.Equal(t, len(tc.Test.Results), 2)
+	assert.Equal(t, string(tc.Test.Results[0].Result), "fail")
+	assert.DeepEqual(t, tc.Test.Results[0].Resources, []string{"bad"})
+	assert.Equal(t, string(tc.Test.Results[1].Result), "pass")
+	assert.DeepEqual(t, tc.Test.Results[1].Resources, []string{"good"})
+	assert.Equal(t, tc.Test.Results[1].Kind, "Pod")
+	assert.Equal(t, tc.Test.Results[1].Namespace, "default")
+
+	dir := t.TempDir()
+	assert.NilError(t, tc.Write(dir))
+	for _, file := range []string{PolicyFile, ResourcesFile, TestFile} {
+		_, err := os.Stat(filepath.Join(dir, file))
+		assert.NilError(t, err)
+	}
+
+	_, _, err = Generate(context.TODO(), snapshot.NewClient(newTestSnapshot()), "missing", "")
+	assert.ErrorContains(t, err, "failed to get policy")
+}
@@ -19,9 +19,11 @@ import (
 	"github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/api"
+	testgenerate "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/generate"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/test/manifest"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
 	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/snapshot"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/store"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/background/generate"
@@ -98,10 +100,11 @@ Test Summary: 1 tests passed and 0 tests failed
 
 **TEST FILE STRUCTURE**:
 
-The kyverno-test.yaml has four parts:
+The kyverno-test.yaml has five parts:
 	"policies"   --> List of policies which are applied.
 	"resources"  --> List of resources on which the policies are applied.
 	"variables"  --> Variable file path containing variables referenced in the policy (OPTIONAL).
+	"mocks"      --> Mocked responses for apiCall, imageRegistry and configMap context entries (OPTIONAL).
 	"results"    --> List of results expected after applying the policies to the resources.
 
 ** TEST FILE FORMAT**:
@@ -114,6 +117,20 @@ resources:
 - <path/to/resource1.yaml>
 - <path/to/resource2.yaml>
 variables: <variable_file> (OPTIONAL)
+mocks: (OPTIONAL)
+  apiCalls:
+  - urlPath: <kubernetes api path> (For Kubernetes API calls)
+    url: <service url> (For service calls)
+    method: <GET|POST> (OPTIONAL, defaults to GET)
+    body: <expected POST body> (OPTIONAL, matches any body if not set)
+    response: <response returned by the call>
+  imageRegistries:
+  - reference: <image reference>
+    data: <image data, e.g. manifest, configData, resolvedImage>
+  configMaps:
+  - name: <name>
+    namespace: <namespace> (OPTIONAL, defaults to default)
+    data: <configmap data>
 results:
 - policy: <name> (For Namespaced [Policy] files, format is <policy_namespace>/<policy_name>)
   rule: <name>
@@ -167,6 +184,7 @@ func Command() *cobra.Command {
 	var cmd *cobra.Command
 	var testCase string
 	var fileName, gitBranch string
+	var snapshotPath string
 	var registryAccess, failOnly, removeColor, manifestValidate, manifestMutate bool
 	cmd = &cobra.Command{
 		Use: "test <path_to_folder_Containing_test.yamls> [flags]\n  kyverno test <path_to_gitRepository_with_dir> --git-branch <branchName>\n  kyverno test --manifest-mutate > kyverno-test.yaml\n  kyverno test --manifest-validate > kyverno-test.yaml",
@@ -189,7 +207,16 @@ func Command() *cobra.Command {
 				manifest.PrintValidate()
 			} else {
 				store.SetRegistryAccess(registryAccess)
-				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor)
+				var clusterSnapshot *snapshot.Snapshot
+				if snapshotPath != "" {
+					clusterSnapshot, err = snapshot.ReadFile(snapshotPath)
+					if err != nil {
+						return sanitizederror.NewWithError("failed to load snapshot", err)
+					}
+					store.AllowApiCall(true)
+					store.SetConfigMapResolver(snapshot.NewConfigMapResolver(clusterSnapshot))
+				}
+				_, err = testCommandExecute(dirPath, fileName, gitBranch, testCase, failOnly, removeColor, clusterSnapshot)
 				if err != nil {
 					log.Log.V(3).Info("a directory is required")
 					return err
@@ -206,6 +233,8 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&registryAccess, "registry", "", false, "If set to true, access the image registry using local docker credentials to populate external data")
 	cmd.Flags().BoolVarP(&failOnly, "fail-only", "", false, "If set to true, display all the failing test only as output for the test command")
 	cmd.Flags().BoolVarP(&removeColor, "remove-color", "", false, "Remove any color from output")
+	cmd.Flags().StringVarP(&snapshotPath, "snapshot", "", "", "Path to a cluster snapshot used to resolve API calls, configmaps, namespace labels and resources not found in the test resources")
+	cmd.AddCommand(testgenerate.Command())
 	return cmd
 }
 
@@ -232,7 +261,7 @@ type testFilter struct {
 
 var ftable = []Table{}
 
-func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool) (rc *resultCounts, err error) {
+func testCommandExecute(dirPath []string, fileName string, gitBranch string, testCase string, failOnly bool, removeColor bool, clusterSnapshot *snapshot.Snapshot) (rc *resultCounts, err error) {
 	var errors []error
 	fs := memfs.New()
 	rc = &resultCounts{}
@@ -352,7 +381,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 					errors = append(errors, sanitizederror.NewWithError("failed to convert to JSON", err))
 					continue
 				}
-				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+				if err := applyPoliciesFromPath(fs, policyBytes, true, policyresoucePath, rc, openApiManager, tf, failOnly, removeColor, clusterSnapshot); err != nil {
 					return rc, sanitizederror.NewWithError("failed to apply test command", err)
 				}
 			}
@@ -364,7 +393,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 	} else {
 		var testFiles int
 		path := filepath.Clean(dirPath[0])
-		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor)
+		errors = getLocalDirTestFiles(fs, path, fileName, rc, &testFiles, openApiManager, tf, failOnly, removeColor, clusterSnapshot)
 
 		if testFiles == 0 {
 			fmt.Printf("\n No test files found. Please provide test YAML files named kyverno-test.yaml \n")
@@ -393,7 +422,7 @@ func testCommandExecute(dirPath []string, fileName string, gitBranch string, tes
 	return rc, nil
 }
 
-func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) []error {
+func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *resultCounts, testFiles *int, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool, clusterSnapshot *snapshot.Snapshot) []error {
 	var errors []error
 
 	files, err := os.ReadDir(path)
@@ -402,7 +431,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 	}
 	for _, file := range files {
 		if file.IsDir() {
-			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor)
+			getLocalDirTestFiles(fs, filepath.Join(path, file.Name()), fileName, rc, testFiles, openApiManager, tf, failOnly, removeColor, clusterSnapshot)
 			continue
 		}
 		if file.Name() == fileName {
@@ -418,7 +447,7 @@ func getLocalDirTestFiles(fs billy.Filesystem, path, fileName string, rc *result
 				errors = append(errors, sanitizederror.NewWithError("failed to convert json", err))
 				continue
 			}
-			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor); err != nil {
+			if err := applyPoliciesFromPath(fs, valuesBytes, false, path, rc, openApiManager, tf, failOnly, removeColor, clusterSnapshot); err != nil {
 				errors = append(errors, sanitizederror.NewWithError(fmt.Sprintf("failed to apply test command from file %s", file.Name()), err))
 				continue
 			}
@@ -730,7 +759,7 @@ func getFullPath(paths []string, policyResourcePath string, isGit bool) []string
 	return paths
 }
 
-func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool) (err error) {
+func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool, policyResourcePath string, rc *resultCounts, openApiManager openapi.Manager, tf *testFilter, failOnly, removeColor bool, clusterSnapshot *snapshot.Snapshot) (err error) {
 	engineResponses := make([]*engineapi.EngineResponse, 0)
 	var dClient dclient.Interface
 	values := &api.Test{}
@@ -742,6 +771,7 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 	if err := json.Unmarshal(policyBytes, values); err != nil {
 		return sanitizederror.NewWithError("failed to decode yaml", err)
 	}
+	store.SetMocks(values.Mocks)
 
 	if tf.enabled {
 		var filteredResults []api.TestResults
@@ -768,6 +798,11 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		return err
 	}
 
+	if clusterSnapshot != nil {
+		dClient = snapshot.NewClient(clusterSnapshot)
+		namespaceSelectorMap = clusterSnapshot.MergeNamespaceLabels(namespaceSelectorMap)
+	}
+
 	// get the user info as request info from a different file
 	var userInfo v1beta1.RequestInfo
 
@@ -856,6 +891,15 @@ func applyPoliciesFromPath(fs billy.Filesystem, policyBytes []byte, isGit bool,
 		os.Exit(1)
 	}
 
+	if clusterSnapshot != nil {
+		snapshotResources, err := common.GetResources(policies, nil, dClient, true, "", false)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to load resources from snapshot", err)
+		}
+		// resources listed in the test take precedence over the snapshot ones
+		resources = clusterSnapshot.MergeResources(resources, snapshotResources...)
+	}
+
 	filteredResources := []*unstructured.Unstructured{}
 	for _, r := range resources {
 		for _, res := range values.Results {
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewClusterClient creates a dclient.Interface for the cluster referenced by the given kubeconfig and context
+func NewClusterClient(kubeConfig, kubeContext string) (dclient.Interface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(kubeConfig, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return dclient.NewClient(context.Background(), dynamicClient, kubeClient, 15*time.Minute)
+}
+
+// NewKyvernoClient creates a kyverno clientset for the cluster referenced by the given kubeconfig and context
+func NewKyvernoClient(kubeConfig, kubeContext string) (versioned.Interface, error) {
+	restConfig, err := config.CreateClientConfigWithContext(kubeConfig, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+	return versioned.NewForConfig(restConfig)
+}
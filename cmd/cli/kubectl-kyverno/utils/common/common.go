@@ -478,7 +478,7 @@ OuterLoop:
 		cfg,
 		c.Client,
 		registryclient.NewOrDie(),
-		store.ContextLoaderFactory(nil),
+		store.ContextLoaderFactory(store.GetConfigMapResolver()),
 		nil,
 	)
 	policyContext := engine.NewPolicyContextWithJsonContext(ctx).
@@ -1077,7 +1077,7 @@ func initializeMockController(objects []runtime.Object) (*generate.GenerateContr
 		config.NewDefaultConfiguration(),
 		client,
 		nil,
-		store.ContextLoaderFactory(nil),
+		store.ContextLoaderFactory(store.GetConfigMapResolver()),
 		nil,
 	))
 	return c, nil
@@ -0,0 +1,240 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+var errReadOnly = errors.New("snapshot client is read-only")
+
+// client is a read-only dclient.Interface serving resources and raw API calls from a snapshot
+type client struct {
+	snapshot *Snapshot
+	disco    dclient.IDiscovery
+	kube     kubernetes.Interface
+}
+
+// NewClient returns a dclient.Interface backed by the given snapshot
+func NewClient(snapshot *Snapshot) dclient.Interface {
+	return &client{
+		snapshot: snapshot,
+		disco:    newDiscovery(snapshot),
+		kube:     kubefake.NewSimpleClientset(),
+	}
+}
+
+// GetKubeClient returns an empty fake clientset, typed access is not served from the snapshot
+func (c *client) GetKubeClient() kubernetes.Interface {
+	return c.kube
+}
+
+func (c *client) GetEventsInterface() corev1.EventInterface {
+	return c.kube.CoreV1().Events(metav1.NamespaceAll)
+}
+
+func (c *client) GetDynamicInterface() dynamic.Interface {
+	return nil
+}
+
+func (c *client) Discovery() dclient.IDiscovery {
+	return c.disco
+}
+
+func (c *client) SetDiscovery(discoveryClient dclient.IDiscovery) {
+	c.disco = discoveryClient
+}
+
+// RawAbsPath serves get and list requests for the captured resources, e.g.
+// /api/v1/namespaces/default/configmaps or /apis/apps/v1/deployments
+func (c *client) RawAbsPath(_ context.Context, path string) ([]byte, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	var gv schema.GroupVersion
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		gv = schema.GroupVersion{Version: segments[1]}
+		segments = segments[2:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		gv = schema.GroupVersion{Group: segments[1], Version: segments[2]}
+		segments = segments[3:]
+	default:
+		return nil, fmt.Errorf("unsupported path %s", path)
+	}
+	var namespace string
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		namespace = segments[1]
+		segments = segments[2:]
+	}
+	if len(segments) > 2 {
+		return nil, fmt.Errorf("subresources are not supported in snapshot: %s", path)
+	}
+	list := c.findList(gv.WithResource(segments[0]))
+	if list == nil {
+		return nil, apierrors.NewNotFound(gv.WithResource(segments[0]).GroupResource(), "")
+	}
+	if len(segments) == 2 {
+		obj, err := c.get(list, namespace, segments[1])
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(obj.Object)
+	}
+	var selector *metav1.LabelSelector
+	if s := u.Query().Get("labelSelector"); s != "" {
+		if selector, err = metav1.ParseToLabelSelector(s); err != nil {
+			return nil, err
+		}
+	}
+	items, err := c.list(list, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(items.UnstructuredContent())
+}
+
+func (c *client) GetResource(_ context.Context, apiVersion string, kind string, namespace string, name string, subresources ...string) (*unstructured.Unstructured, error) {
+	list, err := c.findListForKind(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	if len(subresources) > 0 {
+		return nil, fmt.Errorf("subresources are not supported in snapshot: %s/%s", kind, strings.Join(subresources, "/"))
+	}
+	return c.get(list, namespace, name)
+}
+
+func (c *client) ListResource(_ context.Context, apiVersion string, kind string, namespace string, lselector *metav1.LabelSelector) (*unstructured.UnstructuredList, error) {
+	list, err := c.findListForKind(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	return c.list(list, namespace, lselector)
+}
+
+// ListResourceWithOptions supports label selectors and paginated listing, the continue token is the
+// offset of the next item in the snapshot
+func (c *client) ListResourceWithOptions(_ context.Context, apiVersion string, kind string, namespace string, options metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	list, err := c.findListForKind(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	var selector *metav1.LabelSelector
+	if options.LabelSelector != "" {
+		if selector, err = metav1.ParseToLabelSelector(options.LabelSelector); err != nil {
+			return nil, err
+		}
+	}
+	result, err := c.list(list, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	offset := 0
+	if options.Continue != "" {
+		if offset, err = strconv.Atoi(options.Continue); err != nil || offset < 0 || offset > len(result.Items) {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid continue token %s", options.Continue))
+		}
+	}
+	result.Items = result.Items[offset:]
+	if options.Limit > 0 && int64(len(result.Items)) > options.Limit {
+		result.Items = result.Items[:options.Limit]
+		result.SetContinue(strconv.Itoa(offset + int(options.Limit)))
+	}
+	return result, nil
+}
+
+func (c *client) PatchResource(context.Context, string, string, string, string, []byte) (*unstructured.Unstructured, error) {
+	return nil, errReadOnly
+}
+
+func (c *client) DeleteResource(context.Context, string, string, string, string, bool) error {
+	return errReadOnly
+}
+
+func (c *client) DeleteResourceWithOptions(context.Context, string, string, string, string, metav1.DeleteOptions) error {
+	return errReadOnly
+}
+
+func (c *client) CreateResource(context.Context, string, string, string, interface{}, bool) (*unstructured.Unstructured, error) {
+	return nil, errReadOnly
+}
+
+func (c *client) UpdateResource(context.Context, string, string, string, interface{}, bool, ...string) (*unstructured.Unstructured, error) {
+	return nil, errReadOnly
+}
+
+func (c *client) UpdateStatusResource(context.Context, string, string, string, interface{}, bool) (*unstructured.Unstructured, error) {
+	return nil, errReadOnly
+}
+
+func (c *client) findList(gvr schema.GroupVersionResource) *ResourceList {
+	for i := range c.snapshot.Resources {
+		list := &c.snapshot.Resources[i]
+		if list.Group == gvr.Group && list.Version == gvr.Version && list.Resource == gvr.Resource {
+			return list
+		}
+	}
+	return nil
+}
+
+func (c *client) findListForKind(apiVersion string, kind string) (*ResourceList, error) {
+	_, _, gvr, err := c.disco.FindResource(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	if list := c.findList(gvr); list != nil {
+		return list, nil
+	}
+	return nil, fmt.Errorf("kind '%s' not found in snapshot", kind)
+}
+
+func (c *client) get(list *ResourceList, namespace, name string) (*unstructured.Unstructured, error) {
+	for _, item := range list.Items {
+		if item.GetName() == name && (!list.Namespaced || item.GetNamespace() == namespace) {
+			return item.DeepCopy(), nil
+		}
+	}
+	return nil, apierrors.NewNotFound(list.GroupVersion().WithResource(list.Resource).GroupResource(), name)
+}
+
+func (c *client) list(list *ResourceList, namespace string, lselector *metav1.LabelSelector) (*unstructured.UnstructuredList, error) {
+	selector := labels.Everything()
+	if lselector != nil {
+		s, err := metav1.LabelSelectorAsSelector(lselector)
+		if err != nil {
+			return nil, err
+		}
+		selector = s
+	}
+	result := &unstructured.UnstructuredList{}
+	result.SetGroupVersionKind(list.GroupVersion().WithKind(list.Kind + "List"))
+	result.Items = []unstructured.Unstructured{}
+	for _, item := range list.Items {
+		if list.Namespaced && namespace != "" && item.GetNamespace() != namespace {
+			continue
+		}
+		if !selector.Matches(labels.Set(item.GetLabels())) {
+			continue
+		}
+		result.Items = append(result.Items, *item.DeepCopy())
+	}
+	return result, nil
+}
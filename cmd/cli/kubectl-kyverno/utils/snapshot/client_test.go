@@ -0,0 +1,179 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newTestSnapshot() *Snapshot {
+	s := New("")
+	s.Add(ResourceList{
+		Version:  "v1",
+		Resource: "namespaces",
+		Kind:     "Namespace",
+		Items: []unstructured.Unstructured{
+			{Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "prod", "labels": map[string]interface{}{"env": "prod"}}}},
+		},
+	})
+	s.Add(ResourceList{
+		Version:    "v1",
+		Resource:   "configmaps",
+		Kind:       "ConfigMap",
+		Namespaced: true,
+		Items: []unstructured.Unstructured{
+			{Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "config", "namespace": "prod", "labels": map[string]interface{}{"app": "a"}}, "data": map[string]interface{}{"key": "value"}}},
+			{Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "other", "namespace": "dev"}}},
+		},
+	})
+	s.Add(ResourceList{
+		Group:      "apps",
+		Version:    "v1",
+		Resource:   "deployments",
+		Kind:       "Deployment",
+		Namespaced: true,
+		Items: []unstructured.Unstructured{
+			{Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "nginx", "namespace": "prod"}}},
+		},
+	})
+	return s
+}
+
+func Test_ReadWrite(t *testing.T) {
+	s := newTestSnapshot()
+	var buf bytes.Buffer
+	assert.NilError(t, s.Write(&buf))
+	read, err := Read(&buf)
+	assert.NilError(t, err)
+	assert.Equal(t, len(read.Resources), 3)
+	assert.Equal(t, read.Resources[2].Items[0].GetKind(), "Deployment")
+	assert.Equal(t, read.Resources[2].Items[0].GetAPIVersion(), "apps/v1")
+	_, err = Read(bytes.NewBufferString(`{"apiVersion":"v1","kind":"List"}`))
+	assert.ErrorContains(t, err, "unsupported snapshot")
+}
+
+func Test_ListResource(t *testing.T) {
+	c := NewClient(newTestSnapshot())
+	list, err := c.ListResource(context.TODO(), "", "ConfigMap", "", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 2)
+	list, err = c.ListResource(context.TODO(), "v1", "ConfigMap", "prod", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	list, err = c.ListResource(context.TODO(), "", "ConfigMap", "", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	assert.Equal(t, list.Items[0].GetName(), "config")
+	list, err = c.ListResource(context.TODO(), "apps/v1", "Deployment", "", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	_, err = c.ListResource(context.TODO(), "", "Pod", "", nil)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func Test_ListResourceWithOptions(t *testing.T) {
+	c := NewClient(newTestSnapshot())
+	list, err := c.ListResourceWithOptions(context.TODO(), "", "ConfigMap", "", metav1.ListOptions{Limit: 1})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	assert.Equal(t, list.Items[0].GetName(), "config")
+	assert.Equal(t, list.GetContinue(), "1")
+	list, err = c.ListResourceWithOptions(context.TODO(), "", "ConfigMap", "", metav1.ListOptions{Limit: 1, Continue: list.GetContinue()})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	assert.Equal(t, list.Items[0].GetName(), "other")
+	assert.Equal(t, list.GetContinue(), "")
+	list, err = c.ListResourceWithOptions(context.TODO(), "", "ConfigMap", "", metav1.ListOptions{LabelSelector: "app=a"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(list.Items), 1)
+	_, err = c.ListResourceWithOptions(context.TODO(), "", "ConfigMap", "", metav1.ListOptions{Continue: "invalid"})
+	assert.ErrorContains(t, err, "invalid continue token")
+}
+
+func Test_GetResource(t *testing.T) {
+	c := NewClient(newTestSnapshot())
+	obj, err := c.GetResource(context.TODO(), "v1", "Namespace", "", "prod")
+	assert.NilError(t, err)
+	assert.Equal(t, obj.GetLabels()["env"], "prod")
+	_, err = c.GetResource(context.TODO(), "v1", "ConfigMap", "prod", "other")
+	assert.ErrorContains(t, err, "not found")
+	assert.ErrorContains(t, c.DeleteResource(context.TODO(), "v1", "ConfigMap", "prod", "config", false), "read-only")
+}
+
+func Test_RawAbsPath(t *testing.T) {
+	c := NewClient(newTestSnapshot())
+	tests := []struct {
+		path  string
+		items int
+		name  string
+		err   string
+	}{
+		{path: "/api/v1/namespaces", items: 1},
+		{path: "/api/v1/namespaces/prod", name: "prod"},
+		{path: "/api/v1/configmaps", items: 2},
+		{path: "/api/v1/namespaces/prod/configmaps", items: 1},
+		{path: "/api/v1/namespaces/prod/configmaps/config", name: "config"},
+		{path: "/api/v1/configmaps?labelSelector=app%3Da", items: 1},
+		{path: "/apis/apps/v1/namespaces/prod/deployments", items: 1},
+		{path: "/apis/apps/v1/namespaces/dev/deployments/nginx", err: "not found"},
+		{path: "/api/v1/pods", err: "not found"},
+		{path: "/healthz", err: "unsupported path"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			data, err := c.RawAbsPath(context.TODO(), test.path)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NilError(t, err)
+			var obj unstructured.Unstructured
+			assert.NilError(t, json.Unmarshal(data, &obj.Object))
+			if test.name != "" {
+				assert.Equal(t, obj.GetName(), test.name)
+			} else {
+				items, _, _ := unstructured.NestedSlice(obj.Object, "items")
+				assert.Equal(t, len(items), test.items)
+			}
+		})
+	}
+}
+
+func Test_NamespaceLabels(t *testing.T) {
+	s := newTestSnapshot()
+	merged := s.MergeNamespaceLabels(map[string]map[string]string{"dev": {"env": "dev"}})
+	assert.DeepEqual(t, merged, map[string]map[string]string{
+		"dev":  {"env": "dev"},
+		"prod": {"env": "prod"},
+	})
+}
+
+func Test_MergeResources(t *testing.T) {
+	s := newTestSnapshot()
+	resource := func(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+		var r unstructured.Unstructured
+		r.SetAPIVersion(apiVersion)
+		r.SetKind(kind)
+		r.SetNamespace(namespace)
+		r.SetName(name)
+		return &r
+	}
+	listed := resource("v1", "ConfigMap", "", "config")
+	listed.SetLabels(map[string]string{"listed": "true"})
+	merged := s.MergeResources(
+		[]*unstructured.Unstructured{listed, resource("apps/v1", "Deployment", "prod", "nginx")},
+		resource("v1", "ConfigMap", "default", "config"),
+		resource("v1", "ConfigMap", "prod", "config"),
+		resource("apps/v1", "Deployment", "prod", "nginx"),
+		resource("v1", "Namespace", "", "prod"),
+	)
+	assert.Equal(t, len(merged), 4)
+	assert.Equal(t, merged[0].GetLabels()["listed"], "true")
+	assert.Equal(t, merged[2].GetNamespace(), "prod")
+	assert.Equal(t, merged[3].GetKind(), "Namespace")
+}
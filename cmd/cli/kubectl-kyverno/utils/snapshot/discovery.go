@@ -0,0 +1,82 @@
+package snapshot
+
+import (
+	"fmt"
+
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+)
+
+// discoveryClient resolves kinds against the API resources recorded in a snapshot
+type discoveryClient struct {
+	resources []metav1.APIResource
+}
+
+func newDiscovery(snapshot *Snapshot) *discoveryClient {
+	var resources []metav1.APIResource
+	for _, list := range snapshot.Resources {
+		resources = append(resources, list.APIResource())
+	}
+	return &discoveryClient{resources: resources}
+}
+
+func (c *discoveryClient) FindResource(groupVersion string, kind string) (apiResource, parentAPIResource *metav1.APIResource, gvr schema.GroupVersionResource, err error) {
+	if _, subresource := kubeutils.SplitSubresource(kind); subresource != "" {
+		return nil, nil, schema.GroupVersionResource{}, fmt.Errorf("subresource %s not supported in snapshot", kind)
+	}
+	for _, resource := range c.resources {
+		resource := resource
+		gv := schema.GroupVersion{Group: resource.Group, Version: resource.Version}
+		if resource.Kind != kind {
+			continue
+		}
+		if groupVersion == "" || kubeutils.GroupVersionMatches(groupVersion, gv.String()) {
+			return &resource, nil, gv.WithResource(resource.Name), nil
+		}
+	}
+	return nil, nil, schema.GroupVersionResource{}, fmt.Errorf("kind '%s' not found in groupVersion '%s'", kind, groupVersion)
+}
+
+func (c *discoveryClient) GetGVRFromKind(kind string) (schema.GroupVersionResource, error) {
+	if kind == "" {
+		return schema.GroupVersionResource{}, nil
+	}
+	gv, k := kubeutils.GetKindFromGVK(kind)
+	_, _, gvr, err := c.FindResource(gv, k)
+	return gvr, err
+}
+
+func (c *discoveryClient) GetGVRFromAPIVersionKind(apiVersion string, kind string) schema.GroupVersionResource {
+	_, _, gvr, _ := c.FindResource(apiVersion, kind)
+	return gvr
+}
+
+func (c *discoveryClient) GetGVKFromGVR(groupVersion, resourceName string) (schema.GroupVersionKind, error) {
+	for _, resource := range c.resources {
+		gv := schema.GroupVersion{Group: resource.Group, Version: resource.Version}
+		if gv.String() == groupVersion && resource.Name == resourceName {
+			return gv.WithKind(resource.Kind), nil
+		}
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("resource %s not found in group %s", resourceName, groupVersion)
+}
+
+func (c *discoveryClient) GetServerVersion() (*version.Info, error) {
+	return &version.Info{}, nil
+}
+
+func (c *discoveryClient) OpenAPISchema() (*openapiv2.Document, error) {
+	return nil, nil
+}
+
+func (c *discoveryClient) DiscoveryCache() discovery.CachedDiscoveryInterface {
+	return nil
+}
+
+func (c *discoveryClient) DiscoveryInterface() discovery.DiscoveryInterface {
+	return nil
+}
@@ -0,0 +1,32 @@
+package snapshot
+
+import (
+	"context"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type configMapResolver struct {
+	client *client
+}
+
+// NewConfigMapResolver returns a configmap resolver serving configmaps from the given snapshot
+func NewConfigMapResolver(snapshot *Snapshot) engineapi.ConfigmapResolver {
+	return &configMapResolver{
+		client: NewClient(snapshot).(*client),
+	}
+}
+
+func (r *configMapResolver) Get(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	obj, err := r.client.GetResource(ctx, "v1", "ConfigMap", namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var cm corev1.ConfigMap
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &cm); err != nil {
+		return nil, err
+	}
+	return &cm, nil
+}
@@ -0,0 +1,254 @@
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	APIVersion = "cli.kyverno.io/v1alpha1"
+	Kind       = "ClusterSnapshot"
+)
+
+// DefaultKinds are always captured as they are needed to resolve namespace selectors,
+// configmap context entries and RBAC based match/exclude blocks.
+var DefaultKinds = []string{
+	"Namespace",
+	"ConfigMap",
+	"ServiceAccount",
+	"rbac.authorization.k8s.io/v1/Role",
+	"rbac.authorization.k8s.io/v1/RoleBinding",
+	"rbac.authorization.k8s.io/v1/ClusterRole",
+	"rbac.authorization.k8s.io/v1/ClusterRoleBinding",
+}
+
+// Snapshot is an offline copy of a subset of the cluster state
+type Snapshot struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// CreationTimestamp is the time at which the snapshot was captured
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	// Namespace is set when the snapshot was restricted to a single namespace
+	Namespace string `json:"namespace,omitempty"`
+	// Resources contains the captured resources grouped by API resource
+	Resources []ResourceList `json:"resources"`
+}
+
+// ResourceList holds the captured items of a single API resource
+type ResourceList struct {
+	Group      string                      `json:"group,omitempty"`
+	Version    string                      `json:"version"`
+	Resource   string                      `json:"resource"`
+	Kind       string                      `json:"kind"`
+	Namespaced bool                        `json:"namespaced"`
+	Items      []unstructured.Unstructured `json:"items"`
+}
+
+func (r ResourceList) GroupVersion() schema.GroupVersion {
+	return schema.GroupVersion{Group: r.Group, Version: r.Version}
+}
+
+func (r ResourceList) APIResource() metav1.APIResource {
+	return metav1.APIResource{
+		Name:       r.Resource,
+		Group:      r.Group,
+		Version:    r.Version,
+		Kind:       r.Kind,
+		Namespaced: r.Namespaced,
+		Verbs:      metav1.Verbs{"get", "list"},
+	}
+}
+
+// New creates an empty snapshot
+func New(namespace string) *Snapshot {
+	return &Snapshot{
+		APIVersion:        APIVersion,
+		Kind:              Kind,
+		CreationTimestamp: metav1.NewTime(time.Now().UTC()),
+		Namespace:         namespace,
+	}
+}
+
+// Capture lists the given kinds using the provided client and stores them in a new snapshot.
+// Kinds that cannot be resolved or listed are skipped and reported in the returned errors.
+func Capture(ctx context.Context, client dclient.Interface, namespace string, kinds ...string) (*Snapshot, []error) {
+	snapshot := New(namespace)
+	var errs []error
+	seen := map[schema.GroupVersionResource]bool{}
+	for _, kind := range kinds {
+		gv, k := kubeutils.GetKindFromGVK(kind)
+		apiResource, parentAPIResource, gvr, err := client.Discovery().FindResource(gv, k)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to find resource for kind %s: %w", kind, err))
+			continue
+		}
+		if parentAPIResource != nil {
+			errs = append(errs, fmt.Errorf("subresource %s can not be captured", kind))
+			continue
+		}
+		if seen[gvr] {
+			continue
+		}
+		seen[gvr] = true
+		ns := namespace
+		if !apiResource.Namespaced {
+			ns = ""
+		}
+		list, err := client.ListResource(ctx, gvr.GroupVersion().String(), apiResource.Kind, ns, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list %s: %w", kind, err))
+			continue
+		}
+		snapshot.Add(ResourceList{
+			Group:      gvr.Group,
+			Version:    gvr.Version,
+			Resource:   gvr.Resource,
+			Kind:       apiResource.Kind,
+			Namespaced: apiResource.Namespaced,
+			Items:      list.Items,
+		})
+	}
+	return snapshot, errs
+}
+
+// Add adds a resource list to the snapshot, merging items if the API resource is already present
+func (s *Snapshot) Add(list ResourceList) {
+	for i := range list.Items {
+		list.Items[i].SetGroupVersionKind(list.GroupVersion().WithKind(list.Kind))
+		unstructured.RemoveNestedField(list.Items[i].Object, "metadata", "managedFields")
+	}
+	for i := range s.Resources {
+		if s.Resources[i].Group == list.Group && s.Resources[i].Version == list.Version && s.Resources[i].Resource == list.Resource {
+			s.Resources[i].Items = append(s.Resources[i].Items, list.Items...)
+			return
+		}
+	}
+	s.Resources = append(s.Resources, list)
+}
+
+// Write stores the snapshot as a gzip compressed json document
+func (s *Snapshot) Write(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(s); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// WriteFile stores the snapshot in the given file
+func (s *Snapshot) WriteFile(path string) error {
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(path), buf.Bytes(), 0o600)
+}
+
+// Read loads a snapshot, the content can be either gzip compressed or plain json
+func Read(r io.Reader) (*Snapshot, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return nil, err
+		}
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.APIVersion != APIVersion || snapshot.Kind != Kind {
+		return nil, fmt.Errorf("unsupported snapshot %s/%s", snapshot.APIVersion, snapshot.Kind)
+	}
+	return &snapshot, nil
+}
+
+// ReadFile loads a snapshot from the given file
+func ReadFile(path string) (*Snapshot, error) {
+	// We accept the risk of including a user provided file here.
+	file, err := os.Open(filepath.Clean(path)) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// NamespaceLabels returns the labels of the namespaces contained in the snapshot
+func (s *Snapshot) NamespaceLabels() map[string]map[string]string {
+	labels := map[string]map[string]string{}
+	for _, list := range s.Resources {
+		if list.Group == "" && list.Kind == "Namespace" {
+			for _, item := range list.Items {
+				labels[item.GetName()] = item.GetLabels()
+			}
+		}
+	}
+	return labels
+}
+
+// MergeNamespaceLabels adds the namespace labels from the snapshot to the given namespace selector map,
+// labels already present in the map take precedence
+func (s *Snapshot) MergeNamespaceLabels(namespaceSelectorMap map[string]map[string]string) map[string]map[string]string {
+	if namespaceSelectorMap == nil {
+		namespaceSelectorMap = map[string]map[string]string{}
+	}
+	for namespace, labels := range s.NamespaceLabels() {
+		if _, ok := namespaceSelectorMap[namespace]; !ok {
+			namespaceSelectorMap[namespace] = labels
+		}
+	}
+	return namespaceSelectorMap
+}
+
+// MergeResources adds the resources loaded from the snapshot to the given resources, resources are identified
+// by group version kind, namespace and name and the given resources take precedence over the snapshot ones
+func (s *Snapshot) MergeResources(resources []*unstructured.Unstructured, snapshotResources ...*unstructured.Unstructured) []*unstructured.Unstructured {
+	seen := map[string]bool{}
+	for _, resource := range resources {
+		seen[s.resourceKey(resource)] = true
+	}
+	for _, resource := range snapshotResources {
+		key := s.resourceKey(resource)
+		if !seen[key] {
+			seen[key] = true
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// resourceKey identifies a resource, namespaced resources declared without a namespace belong to the default namespace
+func (s *Snapshot) resourceKey(resource *unstructured.Unstructured) string {
+	gvk := resource.GroupVersionKind()
+	namespace := resource.GetNamespace()
+	if namespace == "" {
+		for _, list := range s.Resources {
+			if list.Namespaced && list.Group == gvk.Group && list.Version == gvk.Version && list.Kind == gvk.Kind {
+				namespace = "default"
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%s/%s/%s", gvk.String(), namespace, resource.GetName())
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -22,6 +23,7 @@ func ContextLoaderFactory(
 				logger:     logging.WithName("MockContextLoaderFactory"),
 				policyName: policy.GetName(),
 				ruleName:   rule.Name,
+				cmResolver: cmResolver,
 			}
 		} else {
 			return inner(policy, rule)
@@ -33,6 +35,7 @@ type mockContextLoader struct {
 	logger     logr.Logger
 	policyName string
 	ruleName   string
+	cmResolver engineapi.ConfigmapResolver
 }
 
 func (l *mockContextLoader) Load(
@@ -51,20 +54,24 @@ func (l *mockContextLoader) Load(
 			}
 		}
 	}
-	hasRegistryAccess := GetRegistryAccess()
 	// Context Variable should be loaded after the values loaded from values file
 	for _, entry := range contextEntries {
-		if entry.ImageRegistry != nil && hasRegistryAccess {
-			rclient := GetRegistryClient()
-			if err := engineapi.LoadImageData(ctx, rclient, l.logger, entry, jsonContext); err != nil {
+		if entry.ImageRegistry != nil {
+			if err := l.loadImageData(ctx, entry, jsonContext); err != nil {
 				return err
 			}
 		} else if entry.Variable != nil {
 			if err := engineapi.LoadVariable(l.logger, entry, jsonContext); err != nil {
 				return err
 			}
-		} else if entry.APICall != nil && IsApiCallAllowed() {
-			if err := engineapi.LoadAPIData(ctx, l.logger, entry, jsonContext, client); err != nil {
+		} else if entry.ConfigMap != nil {
+			if resolver := l.configMapResolver(); resolver != nil {
+				if err := engineapi.LoadConfigMap(ctx, l.logger, entry, jsonContext, resolver); err != nil {
+					return err
+				}
+			}
+		} else if entry.APICall != nil {
+			if err := l.loadAPIData(ctx, client, entry, jsonContext); err != nil {
 				return err
 			}
 		}
@@ -78,3 +85,56 @@ func (l *mockContextLoader) Load(
 	}
 	return nil
 }
+
+// loadImageData loads image data from the mocks first, then from the registry if registry access is enabled
+func (l *mockContextLoader) loadImageData(ctx context.Context, entry kyvernov1.ContextEntry, jsonContext enginecontext.Interface) error {
+	hasRegistryAccess := GetRegistryAccess()
+	if mocks := GetMocks(); mocks != nil && len(mocks.ImageRegistries) > 0 {
+		err := engineapi.LoadImageDataWithFetcher(ctx, mocks.FetchImageData, l.logger, entry, jsonContext)
+		if err == nil || !errors.Is(err, ErrMockNotFound) {
+			return err
+		}
+		if !hasRegistryAccess {
+			l.logger.V(3).Info("skipping image registry context entry", "name", entry.Name, "reason", err.Error())
+			return nil
+		}
+	}
+	if hasRegistryAccess {
+		return engineapi.LoadImageData(ctx, GetRegistryClient(), l.logger, entry, jsonContext)
+	}
+	return nil
+}
+
+// loadAPIData loads api call data from the mocks first, then from the cluster if api calls are allowed
+func (l *mockContextLoader) loadAPIData(ctx context.Context, client dclient.Interface, entry kyvernov1.ContextEntry, jsonContext enginecontext.Interface) error {
+	if mocks := GetMocks(); mocks != nil && len(mocks.APICalls) > 0 {
+		err := engineapi.LoadAPIDataWithExecutor(ctx, l.logger, entry, jsonContext, mocks.ExecuteAPICall)
+		if err == nil || !errors.Is(err, ErrMockNotFound) {
+			return err
+		}
+		if !IsApiCallAllowed() {
+			l.logger.V(3).Info("skipping api call context entry", "name", entry.Name, "reason", err.Error())
+			return nil
+		}
+	}
+	if IsApiCallAllowed() {
+		return engineapi.LoadAPIData(ctx, l.logger, entry, jsonContext, client)
+	}
+	return nil
+}
+
+// configMapResolver returns a resolver looking up configmaps in the mocks first, then in the configured resolver
+func (l *mockContextLoader) configMapResolver() engineapi.ConfigmapResolver {
+	var resolvers []engineapi.ConfigmapResolver
+	if mocks := GetMocks(); mocks != nil && len(mocks.ConfigMaps) > 0 {
+		resolvers = append(resolvers, mocks)
+	}
+	if l.cmResolver != nil {
+		resolvers = append(resolvers, l.cmResolver)
+	}
+	if len(resolvers) == 0 {
+		return nil
+	}
+	resolver, _ := engineapi.NewNamespacedResourceResolver(resolvers...)
+	return resolver
+}
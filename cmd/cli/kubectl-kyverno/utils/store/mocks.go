@@ -0,0 +1,176 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Mocks contains mocked responses for context entries loading external data
+type Mocks struct {
+	// APICalls contains mocked responses for apiCall context entries
+	APICalls []APICallMock `json:"apiCalls,omitempty"`
+	// ImageRegistries contains mocked image data for imageRegistry context entries
+	ImageRegistries []ImageRegistryMock `json:"imageRegistries,omitempty"`
+	// ConfigMaps contains mocked configmaps for configMap context entries
+	ConfigMaps []ConfigMapMock `json:"configMaps,omitempty"`
+}
+
+// APICallMock is matched against the apiCall after variables have been substituted.
+// Either URLPath (Kubernetes API call) or URL (service call) must be set.
+type APICallMock struct {
+	// URLPath matches the urlPath of a Kubernetes API call
+	URLPath string `json:"urlPath,omitempty"`
+	// URL matches the url of a service call
+	URL string `json:"url,omitempty"`
+	// Method matches the method of a service call, defaults to GET
+	Method string `json:"method,omitempty"`
+	// Body matches the body of a POST service call, any body matches if not set
+	Body interface{} `json:"body,omitempty"`
+	// Response is returned as the API call response
+	Response interface{} `json:"response"`
+}
+
+// ImageRegistryMock is matched against the image reference after variables have been substituted
+type ImageRegistryMock struct {
+	// Reference is the image reference
+	Reference string `json:"reference"`
+	// Data is returned as the image data, it has the same structure as the data
+	// fetched from a registry (image, resolvedImage, registry, repository, identifier, manifest, configData)
+	Data map[string]interface{} `json:"data"`
+}
+
+// ConfigMapMock declares a configmap returned for configMap context entries
+type ConfigMapMock struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Data      map[string]string `json:"data"`
+}
+
+// ErrMockNotFound is returned when no mock matches a context entry
+var ErrMockNotFound = errors.New("no mock found")
+
+var mocks *Mocks
+
+func SetMocks(m *Mocks) {
+	mocks = m
+}
+
+func GetMocks() *Mocks {
+	return mocks
+}
+
+func (m *Mocks) match(call *kyvernov1.APICall) (*APICallMock, error) {
+	for i := range m.APICalls {
+		mock := &m.APICalls[i]
+		if call.URLPath != "" {
+			if mock.URLPath == call.URLPath {
+				return mock, nil
+			}
+			continue
+		}
+		if call.Service == nil || mock.URL != call.Service.URL {
+			continue
+		}
+		method := mock.Method
+		if method == "" {
+			method = "GET"
+		}
+		if !strings.EqualFold(method, string(call.Service.Method)) {
+			continue
+		}
+		if mock.Body != nil {
+			ok, err := jsonEqual(mock.Body, apicall.BuildPostData(call.Service.Data))
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		return mock, nil
+	}
+	return nil, nil
+}
+
+// ExecuteAPICall returns the mocked response for the given apiCall
+func (m *Mocks) ExecuteAPICall(call *kyvernov1.APICall) ([]byte, error) {
+	mock, err := m.match(call)
+	if err != nil {
+		return nil, err
+	}
+	if mock == nil {
+		if call.URLPath != "" {
+			return nil, fmt.Errorf("%w for API call %s", ErrMockNotFound, call.URLPath)
+		}
+		return nil, fmt.Errorf("%w for service call %s", ErrMockNotFound, call.Service.URL)
+	}
+	return json.Marshal(mock.Response)
+}
+
+// FetchImageData returns the mocked image data for the given reference
+func (m *Mocks) FetchImageData(_ context.Context, ref string) (interface{}, error) {
+	for _, mock := range m.ImageRegistries {
+		if mock.Reference == ref {
+			data := map[string]interface{}{"image": ref}
+			for k, v := range mock.Data {
+				data[k] = v
+			}
+			// convert to untyped data for jmespath evaluation
+			raw, err := json.Marshal(data)
+			if err != nil {
+				return nil, err
+			}
+			var untyped interface{}
+			if err := json.Unmarshal(raw, &untyped); err != nil {
+				return nil, err
+			}
+			return untyped, nil
+		}
+	}
+	return nil, fmt.Errorf("%w for image %s", ErrMockNotFound, ref)
+}
+
+// Get implements engineapi.ConfigmapResolver
+func (m *Mocks) Get(_ context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	for _, mock := range m.ConfigMaps {
+		ns := mock.Namespace
+		if ns == "" {
+			ns = "default"
+		}
+		if mock.Name == name && ns == namespace {
+			return &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: mock.Name, Namespace: ns},
+				Data:       mock.Data,
+			}, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(corev1.Resource("configmaps"), name)
+}
+
+func jsonEqual(a, b interface{}) (bool, error) {
+	var x, y interface{}
+	for _, v := range []struct {
+		in  interface{}
+		out *interface{}
+	}{{a, &x}, {b, &y}} {
+		raw, err := json.Marshal(v.in)
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal(raw, v.out); err != nil {
+			return false, err
+		}
+	}
+	return reflect.DeepEqual(x, y), nil
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 )
 
@@ -25,6 +26,7 @@ var (
 	allowApiCalls  bool
 	policies       []Policy
 	foreachElement int
+	cmResolver     engineapi.ConfigmapResolver
 )
 
 func SetMock(m bool) {
@@ -94,3 +96,11 @@ func AllowApiCall(allow bool) {
 func IsApiCallAllowed() bool {
 	return allowApiCalls
 }
+
+func SetConfigMapResolver(resolver engineapi.ConfigmapResolver) {
+	cmResolver = resolver
+}
+
+func GetConfigMapResolver() engineapi.ConfigmapResolver {
+	return cmResolver
+}
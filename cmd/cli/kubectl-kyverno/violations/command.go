@@ -0,0 +1,153 @@
+package violations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	sanitizederror "github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/sanitizedError"
+	"github.com/lensesio/tableprinter"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var exampleHelp = `
+To list the violations that appeared during the last 24 hours in all namespaces:
+        kyverno violations --since 24h
+
+To list the violations that appeared in a namespace since a given time:
+        kyverno violations --since 2023-02-01T00:00:00Z --namespace prod
+
+To list the high severity violations of the rules owned by a team:
+        kyverno violations --severity high --property owner=platform
+
+Violations are computed from the history recorded by the reports controller in the
+firstSeen and history properties of policy report results.
+`
+
+type options struct {
+	kubeConfig string
+	context    string
+	namespace  string
+	since      string
+	output     string
+	severity   string
+	category   string
+	properties []string
+}
+
+type row struct {
+	ID        int    `header:"#"`
+	Since     string `header:"since"`
+	Policy    string `header:"policy"`
+	Rule      string `header:"rule"`
+	Status    string `header:"status"`
+	Severity  string `header:"severity"`
+	Kind      string `header:"kind"`
+	Namespace string `header:"namespace"`
+	Name      string `header:"name"`
+}
+
+// Command returns the violations command
+func Command() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:     "violations",
+		Short:   "Lists the policy violations that appeared since a given time.",
+		Long:    "Lists the failing results of the policy reports in a cluster that started failing at or after a given time.",
+		Example: exampleHelp,
+		RunE: func(cmd *cobra.Command, _ []string) (err error) {
+			defer func() {
+				if err != nil {
+					if !sanitizederror.IsErrorSanitized(err) {
+						log.Log.Error(err, "failed to sanitize")
+						err = fmt.Errorf("internal error")
+					}
+				}
+			}()
+			return o.execute(cmd)
+		},
+	}
+	cmd.Flags().StringVarP(&o.since, "since", "", "24h", "Time in the RFC3339 format, or duration relative to now, after which violations are listed")
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "", "Restrict violations to the given namespace, all namespaces and cluster reports are considered when empty")
+	cmd.Flags().StringVarP(&o.severity, "severity", "", "", "Restrict violations to the given severity, one of low, medium or high")
+	cmd.Flags().StringVarP(&o.category, "category", "", "", "Restrict violations to the given category")
+	cmd.Flags().StringArrayVarP(&o.properties, "property", "", nil, "Restrict violations to results with the given property, in the key=value format (can be repeated)")
+	cmd.Flags().StringVarP(&o.output, "output", "o", "table", "Output format, one of table, yaml or json")
+	cmd.Flags().StringVarP(&o.kubeConfig, "kubeconfig", "", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVarP(&o.context, "context", "", "", "The name of the kubeconfig context to use")
+	return cmd
+}
+
+func (o options) execute(cmd *cobra.Command) error {
+	switch o.output {
+	case "table", "yaml", "json":
+	default:
+		return sanitizederror.NewWithError(fmt.Sprintf("unsupported output format %s", o.output), nil)
+	}
+	since, err := ParseSince(o.since, time.Now())
+	if err != nil {
+		return sanitizederror.NewWithError(fmt.Sprintf("invalid --since value %s", o.since), err)
+	}
+	properties, err := ParseProperties(o.properties...)
+	if err != nil {
+		return sanitizederror.NewWithError("invalid --property value", err)
+	}
+	filter := Filter{
+		Severity:   o.severity,
+		Category:   o.category,
+		Properties: properties,
+	}
+	client, err := common.NewKyvernoClient(o.kubeConfig, o.context)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to create cluster client", err)
+	}
+	reports, err := FetchReports(cmd.Context(), client, o.namespace)
+	if err != nil {
+		return sanitizederror.NewWithError("failed to list policy reports", err)
+	}
+	return o.print(since, NewViolations(since, filter, reports...))
+}
+
+func (o options) print(since time.Time, violations []Violation) error {
+	switch o.output {
+	case "yaml":
+		data, err := yaml.Marshal(violations)
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal violations", err)
+		}
+		fmt.Print(string(data))
+	case "json":
+		data, err := json.MarshalIndent(violations, "", "  ")
+		if err != nil {
+			return sanitizederror.NewWithError("failed to marshal violations", err)
+		}
+		fmt.Println(string(data))
+	default:
+		if len(violations) == 0 {
+			fmt.Printf("No new violation since %s.\n", since.UTC().Format(time.RFC3339))
+			return nil
+		}
+		var rows []row
+		for _, violation := range violations {
+			rows = append(rows, row{
+				ID:        len(rows) + 1,
+				Since:     violation.Since.UTC().Format(time.RFC3339),
+				Policy:    violation.Policy,
+				Rule:      violation.Rule,
+				Status:    string(violation.Status),
+				Severity:  string(violation.Severity),
+				Kind:      violation.Resource.Kind,
+				Namespace: violation.Resource.Namespace,
+				Name:      violation.Resource.Name,
+			})
+		}
+		printer := tableprinter.New(os.Stdout)
+		printer.Print(rows)
+		fmt.Printf("\n%d new violation(s) since %s\n", len(rows), since.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
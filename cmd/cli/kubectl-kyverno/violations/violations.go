@@ -0,0 +1,172 @@
+package violations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Violation is a failing policy report result
+type Violation struct {
+	Policy   string                              `json:"policy"`
+	Rule     string                              `json:"rule"`
+	Status   policyreportv1alpha2.PolicyResult   `json:"status"`
+	Resource corev1.ObjectReference              `json:"resource"`
+	Message  string                              `json:"message,omitempty"`
+	Severity policyreportv1alpha2.PolicySeverity `json:"severity,omitempty"`
+	Category string                              `json:"category,omitempty"`
+	// Properties are the result properties, history properties excepted
+	Properties map[string]string `json:"properties,omitempty"`
+	// Since is the time the result started failing
+	Since time.Time `json:"since"`
+	// FirstSeen is the time the result was first reported
+	FirstSeen time.Time `json:"firstSeen"`
+}
+
+// Filter restricts the results considered as violations, empty fields match all results
+type Filter struct {
+	Severity   string
+	Category   string
+	Properties map[string]string
+}
+
+// Matches returns true if the result matches the severity, the category and all the properties of the filter
+func (f Filter) Matches(result policyreportv1alpha2.PolicyReportResult) bool {
+	if f.Severity != "" && string(result.Severity) != f.Severity {
+		return false
+	}
+	if f.Category != "" && result.Category != f.Category {
+		return false
+	}
+	for key, value := range f.Properties {
+		if actual, ok := result.Properties[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseProperties parses properties given in the key=value format
+func ParseProperties(values ...string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	properties := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid property %s, expected key=value", value)
+		}
+		properties[key] = val
+	}
+	return properties, nil
+}
+
+// ParseSince parses a time in the RFC3339 format or a duration relative to now
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// FetchReports lists the policy reports managed by kyverno, in a namespace or in all namespaces
+// and the cluster scope when the namespace is empty
+func FetchReports(ctx context.Context, client versioned.Interface, namespace string) ([]kyvernov1alpha2.ReportInterface, error) {
+	var reports []kyvernov1alpha2.ReportInterface
+	polrs, err := client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range polrs.Items {
+		if controllerutils.IsManagedByKyverno(&polrs.Items[i]) {
+			reports = append(reports, &polrs.Items[i])
+		}
+	}
+	if namespace == "" {
+		cpolrs, err := client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range cpolrs.Items {
+			if controllerutils.IsManagedByKyverno(&cpolrs.Items[i]) {
+				reports = append(reports, &cpolrs.Items[i])
+			}
+		}
+	}
+	return reports, nil
+}
+
+// NewViolations returns the failing results of the reports matching the filter that started failing at or after
+// the given time, results without history are ignored
+func NewViolations(since time.Time, filter Filter, reports ...kyvernov1alpha2.ReportInterface) []Violation {
+	var violations []Violation
+	for _, report := range reports {
+		for _, result := range report.GetResults() {
+			if result.Result != policyreportv1alpha2.StatusFail && result.Result != policyreportv1alpha2.StatusError {
+				continue
+			}
+			// shadow policies don't produce violations
+			if reportutils.IsShadowResult(result) {
+				continue
+			}
+			if !filter.Matches(result) {
+				continue
+			}
+			start := reportutils.ResultSince(result)
+			if start.IsZero() || start.Before(since) {
+				continue
+			}
+			violation := Violation{
+				Policy:     result.Policy,
+				Rule:       result.Rule,
+				Status:     result.Result,
+				Message:    result.Message,
+				Severity:   result.Severity,
+				Category:   result.Category,
+				Properties: properties(result),
+				Since:      start,
+				FirstSeen:  reportutils.ResultFirstSeen(result),
+			}
+			if len(result.Resources) != 0 {
+				violation.Resource = result.Resources[0]
+			}
+			violations = append(violations, violation)
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		if !violations[i].Since.Equal(violations[j].Since) {
+			return violations[i].Since.Before(violations[j].Since)
+		}
+		if violations[i].Policy != violations[j].Policy {
+			return violations[i].Policy < violations[j].Policy
+		}
+		return violations[i].Rule < violations[j].Rule
+	})
+	return violations
+}
+
+func properties(result policyreportv1alpha2.PolicyReportResult) map[string]string {
+	var properties map[string]string
+	for key, value := range result.Properties {
+		switch key {
+		case reportutils.FirstSeenProperty, reportutils.LastSeenProperty, reportutils.HistoryProperty:
+			continue
+		}
+		if properties == nil {
+			properties = map[string]string{}
+		}
+		properties[key] = value
+	}
+	return properties
+}
@@ -0,0 +1,96 @@
+package violations
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2023, 2, 1, 12, 0, 0, 0, time.UTC)
+	since, err := ParseSince("2h", now)
+	assert.NilError(t, err)
+	assert.Equal(t, since, now.Add(-2*time.Hour))
+	since, err = ParseSince("2023-01-31T00:00:00Z", now)
+	assert.NilError(t, err)
+	assert.Equal(t, since, time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC))
+	_, err = ParseSince("yesterday", now)
+	assert.Assert(t, err != nil)
+}
+
+func TestNewViolations(t *testing.T) {
+	result := func(name string, status policyreportv1alpha2.PolicyResult, history string) policyreportv1alpha2.PolicyReportResult {
+		return policyreportv1alpha2.PolicyReportResult{
+			Policy:    "require-labels",
+			Rule:      "check-team",
+			Result:    status,
+			Resources: []corev1.ObjectReference{{Kind: "Pod", Namespace: "default", Name: name}},
+			Properties: map[string]string{
+				reportutils.FirstSeenProperty: "2023-01-01T00:00:00Z",
+				reportutils.HistoryProperty:   history,
+			},
+		}
+	}
+	report := &policyreportv1alpha2.PolicyReport{
+		Results: []policyreportv1alpha2.PolicyReportResult{
+			// failing for a long time
+			result("old", policyreportv1alpha2.StatusFail, "fail@2023-01-01T00:00:00Z"),
+			// started failing recently
+			result("new", policyreportv1alpha2.StatusFail, "pass@2023-01-01T00:00:00Z,fail@2023-02-01T10:00:00Z"),
+			// remediated recently
+			result("fixed", policyreportv1alpha2.StatusPass, "fail@2023-01-01T00:00:00Z,pass@2023-02-01T10:00:00Z"),
+			// no history recorded
+			{Policy: "require-labels", Rule: "check-team", Result: policyreportv1alpha2.StatusFail},
+		},
+	}
+	// shadow policies don't produce violations
+	shadow := result("shadow", policyreportv1alpha2.StatusFail, "pass@2023-01-01T00:00:00Z,fail@2023-02-01T10:00:00Z")
+	shadow.Properties[reportutils.ShadowProperty] = "true"
+	report.Results = append(report.Results, shadow)
+	since := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	violations := NewViolations(since, Filter{}, []kyvernov1alpha2.ReportInterface{report}...)
+	assert.Equal(t, len(violations), 1)
+	assert.Equal(t, violations[0].Resource.Name, "new")
+	assert.Equal(t, violations[0].Since, time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC))
+	assert.Equal(t, violations[0].FirstSeen, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestNewViolations_Filter(t *testing.T) {
+	result := func(name string, severity policyreportv1alpha2.PolicySeverity, owner string) policyreportv1alpha2.PolicyReportResult {
+		return policyreportv1alpha2.PolicyReportResult{
+			Policy:    "require-labels",
+			Rule:      "check-team",
+			Result:    policyreportv1alpha2.StatusFail,
+			Severity:  severity,
+			Category:  "Ownership",
+			Resources: []corev1.ObjectReference{{Kind: "Pod", Namespace: "default", Name: name}},
+			Properties: map[string]string{
+				"owner":                       owner,
+				reportutils.FirstSeenProperty: "2023-02-01T10:00:00Z",
+				reportutils.HistoryProperty:   "fail@2023-02-01T10:00:00Z",
+			},
+		}
+	}
+	report := &policyreportv1alpha2.PolicyReport{
+		Results: []policyreportv1alpha2.PolicyReportResult{
+			result("a", policyreportv1alpha2.SeverityHigh, "platform"),
+			result("b", policyreportv1alpha2.SeverityLow, "platform"),
+			result("c", policyreportv1alpha2.SeverityHigh, "security"),
+		},
+	}
+	since := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	properties, err := ParseProperties("owner=platform")
+	assert.NilError(t, err)
+	violations := NewViolations(since, Filter{Severity: "high", Category: "Ownership", Properties: properties}, report)
+	assert.Equal(t, len(violations), 1)
+	assert.Equal(t, violations[0].Resource.Name, "a")
+	assert.DeepEqual(t, violations[0].Properties, map[string]string{"owner": "platform"})
+	assert.Equal(t, len(NewViolations(since, Filter{Category: "Security"}, report)), 0)
+	_, err = ParseProperties("owner")
+	assert.Assert(t, err != nil)
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/cmd/internal"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	apiserverclient "github.com/kyverno/kyverno/pkg/clients/apiserver"
@@ -23,6 +24,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
 	configcontroller "github.com/kyverno/kyverno/pkg/controllers/config"
+	exceptionscontroller "github.com/kyverno/kyverno/pkg/controllers/exceptions"
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
@@ -48,7 +50,9 @@ import (
 	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/validation"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -140,6 +144,7 @@ func createNonLeaderControllers(
 
 func createrLeaderControllers(
 	admissionReports bool,
+	mutateReports bool,
 	serverIP string,
 	webhookTimeout int,
 	autoUpdateWebhooks bool,
@@ -152,6 +157,9 @@ func createrLeaderControllers(
 	certRenewer tls.CertRenewer,
 	runtime runtimeutils.Runtime,
 	servicePort int32,
+	eventGenerator event.Interface,
+	enablePolicyException bool,
+	exceptionExpiringWindow time.Duration,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	certManager := certmanager.NewController(
 		kubeKyvernoInformer.Core().V1().Secrets(),
@@ -176,6 +184,7 @@ func createrLeaderControllers(
 		servicePort,
 		autoUpdateWebhooks,
 		admissionReports,
+		mutateReports,
 		runtime,
 	)
 	exceptionWebhookController := genericwebhookcontroller.NewController(
@@ -198,16 +207,25 @@ func createrLeaderControllers(
 				admissionregistrationv1.Update,
 			},
 		}},
+		nil,
 		genericwebhookcontroller.Fail,
 		genericwebhookcontroller.None,
 	)
-	return []internal.Controller{
-			internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers),
-			internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
-			internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1),
-		},
-		nil,
-		nil
+	leaderControllers := []internal.Controller{
+		internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers),
+		internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
+		internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1),
+	}
+	if enablePolicyException {
+		exceptionsController := exceptionscontroller.NewController(
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V2alpha1().PolicyExceptions(),
+			eventGenerator,
+			exceptionExpiringWindow,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(exceptionscontroller.ControllerName, exceptionsController, exceptionscontroller.Workers))
+	}
+	return leaderControllers, nil, nil
 }
 
 func main() {
@@ -228,7 +246,14 @@ func main() {
 		leaderElectionRetryPeriod  time.Duration
 		enablePolicyException      bool
 		exceptionNamespace         string
+		exceptionExpiringWindow    time.Duration
 		servicePort                int
+		admissionReportsStore      string
+		admissionReportsUrl        string
+		mutateReports              bool
+		shadowSampleRate           float64
+		shadowQueueSize            int
+		shadowWorkers              int
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
@@ -247,7 +272,14 @@ func main() {
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
 	flagset.StringVar(&exceptionNamespace, "exceptionNamespace", "", "Configure the namespace to accept PolicyExceptions.")
 	flagset.BoolVar(&enablePolicyException, "enablePolicyException", false, "Enable PolicyException feature.")
+	flagset.DurationVar(&exceptionExpiringWindow, "exceptionExpiringWindow", 72*time.Hour, "Configure how long before expiry PolicyExceptions are reported as expiring.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
+	flagset.StringVar(&admissionReportsStore, "admissionReportsStore", admissionreports.StoreEtcd, "Where admission reports are stored, etcd or memory (in the reports controller).")
+	flagset.StringVar(&admissionReportsUrl, "admissionReportsUrl", "", "HTTPS URL of the reports controller API service admission reports are sent to, in the https://<name>.<namespace>.svc:<port> form, required when admissionReportsStore is memory.")
+	flagset.BoolVar(&mutateReports, "mutateReports", false, "Create admission reports with the results of the mutate rules applied to admitted resources, requires admissionReports.")
+	flagset.Float64Var(&shadowSampleRate, "shadowSampleRate", 1, "Fraction of admission requests evaluated against shadow policies, between 0 and 1, 0 disables shadow policies evaluation.")
+	flagset.IntVar(&shadowQueueSize, "shadowQueueSize", 1000, "Maximum admission requests queued for shadow policies evaluation, requests are dropped when the queue is full.")
+	flagset.IntVar(&shadowWorkers, "shadowWorkers", 2, "Workers evaluating shadow policies.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -297,6 +329,19 @@ func main() {
 		os.Exit(1)
 	}
 	secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister().Secrets(config.KyvernoNamespace())
+	// setup admission reports writer
+	var admissionReportsWriter, mutationReportsWriter admissionreports.Writer
+	if admissionReports {
+		writer, err := admissionreports.NewWriter(kyvernoClient, admissionReportsStore, admissionReportsUrl, admissionreports.NewRootCAProvider(secretLister, admissionReportsUrl))
+		if err != nil {
+			logger.Error(err, "invalid admission reports store configuration")
+			os.Exit(1)
+		}
+		admissionReportsWriter = writer
+		if mutateReports {
+			mutationReportsWriter = writer
+		}
+	}
 	// setup registry client
 	rclient, err := setupRegistryClient(signalCtx, logger, secretLister, imagePullSecrets, allowInsecureRegistry)
 	if err != nil {
@@ -418,6 +463,7 @@ func main() {
 			// create leader controllers
 			leaderControllers, warmup, err := createrLeaderControllers(
 				admissionReports,
+				admissionReports && mutateReports,
 				serverIP,
 				webhookTimeout,
 				autoUpdateWebhooks,
@@ -430,6 +476,9 @@ func main() {
 				certRenewer,
 				runtime,
 				int32(servicePort),
+				eventGenerator,
+				enablePolicyException,
+				exceptionExpiringWindow,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -479,6 +528,27 @@ func main() {
 		kyvernoClient,
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
 	)
+	// create shadow policies evaluator
+	shadow := validation.NewShadowEvaluator(
+		logger.WithName("shadow"),
+		eng,
+		policyCache,
+		webhookutils.NewPolicyContextBuilder(
+			configuration,
+			dClient,
+			kubeInformer.Rbac().V1().RoleBindings().Lister(),
+			kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
+		),
+		admissionReportsWriter,
+		validation.ShadowOptions{
+			SampleRate: shadowSampleRate,
+			QueueSize:  shadowQueueSize,
+			Workers:    shadowWorkers,
+		},
+	)
+	if shadow != nil {
+		go shadow.Run(signalCtx)
+	}
 	policyHandlers := webhookspolicy.NewHandlers(
 		dClient,
 		openApiManager,
@@ -498,11 +568,14 @@ func main() {
 		urgen,
 		eventGenerator,
 		openApiManager,
-		admissionReports,
+		admissionReportsWriter,
+		mutationReportsWriter,
+		shadow,
 	)
 	exceptionHandlers := webhooksexception.NewHandlers(exception.ValidationOptions{
-		Enabled:   enablePolicyException,
-		Namespace: exceptionNamespace,
+		Enabled:       enablePolicyException,
+		Namespace:     exceptionNamespace,
+		Configuration: configuration,
 	})
 	server := webhooks.NewServer(
 		policyHandlers,
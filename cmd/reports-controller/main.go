@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
 	"github.com/kyverno/kyverno/cmd/internal"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -19,20 +22,30 @@ import (
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/kyverno"
 	metadataclient "github.com/kyverno/kyverno/pkg/clients/metadata"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
 	admissionreportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/admission"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
+	compliancereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/compliance"
+	exceptionusagecontroller "github.com/kyverno/kyverno/pkg/controllers/report/exception"
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	reportutils "github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/exporter"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/reportsapi"
+	"github.com/kyverno/kyverno/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
@@ -66,12 +79,32 @@ func setupCosign(logger logr.Logger, imageSignatureRepository string) {
 	}
 }
 
+// checkStatefulSetPod verifies the current pod is managed by a StatefulSet, the background scan shard
+// index is taken from the pod ordinal and the random suffix of a Deployment pod is not an ordinal
+func checkStatefulSetPod(ctx context.Context, client kubernetes.Interface) error {
+	pod, err := client.CoreV1().Pods(config.KyvernoNamespace()).Get(ctx, config.KyvernoPodName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if owner := metav1.GetControllerOf(pod); owner == nil || owner.Kind != "StatefulSet" {
+		return fmt.Errorf("pod %s is not managed by a StatefulSet, deploy the reports controller as a StatefulSet or set the shard index explicitly", pod.GetName())
+	}
+	return nil
+}
+
 func createReportControllers(
 	eng engineapi.Engine,
 	backgroundScan bool,
 	admissionReports bool,
+	admissionReportsStore admissionreports.Store,
+	reportedRules reportutils.ReportedRules,
 	reportsChunkSize int,
+	perResourceReports bool,
+	reportHistorySize int,
 	backgroundScanWorkers int,
+	backgroundScanShard backgroundscancontroller.Shard,
+	backgroundScanNamespaceQPS float64,
+	backgroundScanNamespaceBurst int,
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	rclient registryclient.Client,
@@ -82,15 +115,23 @@ func createReportControllers(
 	backgroundScanInterval time.Duration,
 	configuration config.Configuration,
 	eventGenerator event.Interface,
+	enablePolicyException bool,
+	complianceReports bool,
+	resultsExporter exporter.Interface,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
 	kyvernoV1 := kyvernoInformer.Kyverno().V1()
 	if backgroundScan || admissionReports {
+		// admission reports are stored in etcd unless an in-memory store is provided
+		if admissionReportsStore == nil {
+			admissionReportsStore = admissionreports.NewClientStore(kyvernoClient, metadataFactory)
+		}
 		resourceReportController := resourcereportcontroller.NewController(
 			client,
 			kyvernoV1.Policies(),
 			kyvernoV1.ClusterPolicies(),
+			reportedRules,
 		)
 		warmups = append(warmups, func(ctx context.Context) error {
 			return resourceReportController.Warmup(ctx)
@@ -104,11 +145,14 @@ func createReportControllers(
 			aggregatereportcontroller.ControllerName,
 			aggregatereportcontroller.NewController(
 				kyvernoClient,
+				admissionReportsStore,
 				metadataFactory,
 				kyvernoV1.Policies(),
 				kyvernoV1.ClusterPolicies(),
 				resourceReportController,
 				reportsChunkSize,
+				perResourceReports,
+				reportHistorySize,
 			),
 			aggregatereportcontroller.Workers,
 		))
@@ -116,31 +160,57 @@ func createReportControllers(
 			ctrls = append(ctrls, internal.NewController(
 				admissionreportcontroller.ControllerName,
 				admissionreportcontroller.NewController(
-					kyvernoClient,
-					metadataFactory,
+					admissionReportsStore,
 					resourceReportController,
+					resultsExporter,
 				),
 				admissionreportcontroller.Workers,
 			))
 		}
-		if backgroundScan {
+		// sharded background scan runs on all replicas, see createShardedBackgroundScanControllers
+		if backgroundScan && backgroundScanShard.Count <= 1 {
+			ctrls = append(ctrls, createBackgroundScanController(
+				eng,
+				backgroundScanWorkers,
+				client,
+				kyvernoClient,
+				metadataFactory,
+				kubeInformer,
+				kyvernoInformer,
+				resourceReportController,
+				configMapResolver,
+				backgroundScanInterval,
+				configuration,
+				eventGenerator,
+				resultsExporter,
+				backgroundScanShard,
+				backgroundScanNamespaceQPS,
+				backgroundScanNamespaceBurst,
+			))
+		}
+		if enablePolicyException {
 			ctrls = append(ctrls, internal.NewController(
-				backgroundscancontroller.ControllerName,
-				backgroundscancontroller.NewController(
-					client,
+				exceptionusagecontroller.ControllerName,
+				exceptionusagecontroller.NewController(
 					kyvernoClient,
-					eng,
-					metadataFactory,
-					kyvernoV1.Policies(),
-					kyvernoV1.ClusterPolicies(),
-					kubeInformer.Core().V1().Namespaces(),
-					resourceReportController,
-					configMapResolver,
-					backgroundScanInterval,
-					configuration,
-					eventGenerator,
+					kyvernoInformer.Kyverno().V2alpha1().PolicyExceptions(),
+					kyvernoInformer.Wgpolicyk8s().V1alpha2().PolicyReports(),
+					kyvernoInformer.Wgpolicyk8s().V1alpha2().ClusterPolicyReports(),
 				),
-				backgroundScanWorkers,
+				exceptionusagecontroller.Workers,
+			))
+		}
+		if complianceReports {
+			ctrls = append(ctrls, internal.NewController(
+				compliancereportcontroller.ControllerName,
+				compliancereportcontroller.NewController(
+					kyvernoClient,
+					kyvernoInformer.Kyverno().V2alpha1().ComplianceFrameworks(),
+					kyvernoInformer.Kyverno().V2alpha1().ComplianceReports(),
+					kyvernoInformer.Wgpolicyk8s().V1alpha2().PolicyReports(),
+					kyvernoInformer.Wgpolicyk8s().V1alpha2().ClusterPolicyReports(),
+				),
+				compliancereportcontroller.Workers,
 			))
 		}
 	}
@@ -154,12 +224,119 @@ func createReportControllers(
 	}
 }
 
+func createBackgroundScanController(
+	eng engineapi.Engine,
+	backgroundScanWorkers int,
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	metadataFactory metadatainformers.SharedInformerFactory,
+	kubeInformer kubeinformers.SharedInformerFactory,
+	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	metadataCache resourcereportcontroller.MetadataCache,
+	configMapResolver engineapi.ConfigmapResolver,
+	backgroundScanInterval time.Duration,
+	configuration config.Configuration,
+	eventGenerator event.Interface,
+	resultsExporter exporter.Interface,
+	backgroundScanShard backgroundscancontroller.Shard,
+	backgroundScanNamespaceQPS float64,
+	backgroundScanNamespaceBurst int,
+) internal.Controller {
+	kyvernoV1 := kyvernoInformer.Kyverno().V1()
+	return internal.NewController(
+		backgroundscancontroller.ControllerName,
+		backgroundscancontroller.NewController(
+			client,
+			kyvernoClient,
+			eng,
+			metadataFactory,
+			kyvernoV1.Policies(),
+			kyvernoV1.ClusterPolicies(),
+			kubeInformer.Core().V1().Namespaces(),
+			metadataCache,
+			configMapResolver,
+			backgroundScanInterval,
+			configuration,
+			eventGenerator,
+			resultsExporter,
+			backgroundScanShard,
+			backgroundScanNamespaceQPS,
+			backgroundScanNamespaceBurst,
+		),
+		backgroundScanWorkers,
+	)
+}
+
+// createShardedBackgroundScanControllers creates the controllers scanning the namespaces of a shard,
+// they run on every replica instead of the leader only
+func createShardedBackgroundScanControllers(
+	eng engineapi.Engine,
+	backgroundScanWorkers int,
+	backgroundScanShard backgroundscancontroller.Shard,
+	backgroundScanNamespaceQPS float64,
+	backgroundScanNamespaceBurst int,
+	reportedRules reportutils.ReportedRules,
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	metadataFactory metadatainformers.SharedInformerFactory,
+	kubeInformer kubeinformers.SharedInformerFactory,
+	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	configMapResolver engineapi.ConfigmapResolver,
+	backgroundScanInterval time.Duration,
+	configuration config.Configuration,
+	eventGenerator event.Interface,
+	resultsExporter exporter.Interface,
+) ([]internal.Controller, func(context.Context) error) {
+	kyvernoV1 := kyvernoInformer.Kyverno().V1()
+	resourceReportController := resourcereportcontroller.NewController(
+		client,
+		kyvernoV1.Policies(),
+		kyvernoV1.ClusterPolicies(),
+		reportedRules,
+	)
+	return []internal.Controller{
+			internal.NewController(
+				resourcereportcontroller.ControllerName,
+				resourceReportController,
+				resourcereportcontroller.Workers,
+			),
+			createBackgroundScanController(
+				eng,
+				backgroundScanWorkers,
+				client,
+				kyvernoClient,
+				metadataFactory,
+				kubeInformer,
+				kyvernoInformer,
+				resourceReportController,
+				configMapResolver,
+				backgroundScanInterval,
+				configuration,
+				eventGenerator,
+				resultsExporter,
+				backgroundScanShard,
+				backgroundScanNamespaceQPS,
+				backgroundScanNamespaceBurst,
+			),
+		},
+		func(ctx context.Context) error {
+			return resourceReportController.Warmup(ctx)
+		}
+}
+
 func createrLeaderControllers(
 	eng engineapi.Engine,
 	backgroundScan bool,
 	admissionReports bool,
+	admissionReportsStore admissionreports.Store,
+	reportedRules reportutils.ReportedRules,
 	reportsChunkSize int,
+	perResourceReports bool,
+	reportHistorySize int,
 	backgroundScanWorkers int,
+	backgroundScanShard backgroundscancontroller.Shard,
+	backgroundScanNamespaceQPS float64,
+	backgroundScanNamespaceBurst int,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	metadataInformer metadatainformers.SharedInformerFactory,
@@ -170,13 +347,23 @@ func createrLeaderControllers(
 	eventGenerator event.Interface,
 	configMapResolver engineapi.ConfigmapResolver,
 	backgroundScanInterval time.Duration,
+	enablePolicyException bool,
+	complianceReports bool,
+	resultsExporter exporter.Interface,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
 		backgroundScan,
 		admissionReports,
+		admissionReportsStore,
+		reportedRules,
 		reportsChunkSize,
+		perResourceReports,
+		reportHistorySize,
 		backgroundScanWorkers,
+		backgroundScanShard,
+		backgroundScanNamespaceQPS,
+		backgroundScanNamespaceBurst,
 		dynamicClient,
 		kyvernoClient,
 		rclient,
@@ -187,24 +374,47 @@ func createrLeaderControllers(
 		backgroundScanInterval,
 		configuration,
 		eventGenerator,
+		enablePolicyException,
+		complianceReports,
+		resultsExporter,
 	)
 	return reportControllers, warmup, nil
 }
 
 func main() {
 	var (
-		leaderElectionRetryPeriod time.Duration
-		imagePullSecrets          string
-		imageSignatureRepository  string
-		allowInsecureRegistry     bool
-		backgroundScan            bool
-		admissionReports          bool
-		reportsChunkSize          int
-		backgroundScanWorkers     int
-		backgroundScanInterval    time.Duration
-		maxQueuedEvents           int
-		enablePolicyException     bool
-		exceptionNamespace        string
+		leaderElectionRetryPeriod    time.Duration
+		imagePullSecrets             string
+		imageSignatureRepository     string
+		allowInsecureRegistry        bool
+		backgroundScan               bool
+		admissionReports             bool
+		reportsChunkSize             int
+		perResourceReports           bool
+		reportHistorySize            int
+		backgroundScanWorkers        int
+		backgroundScanShards         int
+		backgroundScanShardIndex     int
+		backgroundScanNamespaceQPS   float64
+		backgroundScanNamespaceBurst int
+		backgroundScanInterval       time.Duration
+		maxQueuedEvents              int
+		enablePolicyException        bool
+		exceptionNamespace           string
+		resultsExportWebhook         string
+		resultsExportFile            string
+		resultsExportStdout          bool
+		resultsExportBufferSize      int
+		resultsExportBatchSize       int
+		resultsExportFlushPeriod     time.Duration
+		resultsExportMaxRetries      int
+		reportsApiAddress            string
+		admissionReportsStoreName    string
+		admissionReportsSANames      string
+		admissionReportsMaxSize      int
+		mutateReports                bool
+		generateReports              bool
+		complianceReports            bool
 	)
 	flagset := flag.NewFlagSet("reports-controller", flag.ExitOnError)
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
@@ -214,11 +424,31 @@ func main() {
 	flagset.BoolVar(&backgroundScan, "backgroundScan", true, "Enable or disable backgound scan.")
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
 	flagset.IntVar(&reportsChunkSize, "reportsChunkSize", 1000, "Max number of results in generated reports, reports will be split accordingly if there are more results to be stored.")
+	flagset.BoolVar(&perResourceReports, "perResourceReports", false, "Generate one policy report per resource, owned by the resource, and a summary report per namespace instead of one report per policy and namespace.")
+	flagset.IntVar(&reportHistorySize, "reportHistorySize", 10, "Max number of status transitions recorded in the history of each policy report result.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&backgroundScanInterval, "backgroundScanInterval", time.Hour, "Configure background scan interval.")
+	flagset.IntVar(&backgroundScanShards, "backgroundScanShards", 1, "Number of shards background scanning is split into by namespace hash, when greater than one every replica scans the namespaces of its shard instead of the leader scanning everything.")
+	flagset.IntVar(&backgroundScanShardIndex, "backgroundScanShardIndex", -1, "Shard scanned by this replica, defaults to the ordinal suffix of the pod name when running in a StatefulSet.")
+	flagset.Float64Var(&backgroundScanNamespaceQPS, "backgroundScanNamespaceQPS", 0, "Maximum number of resources scanned per second in a namespace by the background scan, zero disables the limit.")
+	flagset.IntVar(&backgroundScanNamespaceBurst, "backgroundScanNamespaceBurst", 10, "Maximum burst of resources scanned in a namespace by the background scan when a rate limit is configured.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&exceptionNamespace, "exceptionNamespace", "", "Configure the namespace to accept PolicyExceptions.")
 	flagset.BoolVar(&enablePolicyException, "enablePolicyException", false, "Enable PolicyException feature.")
+	flagset.StringVar(&resultsExportWebhook, "resultsExportWebhook", "", "URL of an http endpoint receiving policy report results as batches of CloudEvents.")
+	flagset.StringVar(&resultsExportFile, "resultsExportFile", "", "Path of a file policy report results are appended to as JSON lines CloudEvents.")
+	flagset.BoolVar(&resultsExportStdout, "resultsExportStdout", false, "Write policy report results to the standard output as JSON lines CloudEvents.")
+	flagset.IntVar(&resultsExportBufferSize, "resultsExportBufferSize", 10000, "Maximum number of policy report results queued per export sink, results are dropped when the queue is full.")
+	flagset.IntVar(&resultsExportBatchSize, "resultsExportBatchSize", 100, "Maximum number of policy report results sent at once to an export sink.")
+	flagset.DurationVar(&resultsExportFlushPeriod, "resultsExportFlushPeriod", 5*time.Second, "Maximum time policy report results are held before being sent to an export sink.")
+	flagset.IntVar(&resultsExportMaxRetries, "resultsExportMaxRetries", 5, "Maximum number of retries when sending policy report results to an export sink fails.")
+	flagset.StringVar(&reportsApiAddress, "reportsApiAddress", "", "Address the read-only policy reports summary API listens on, for example :8080, the API is disabled when empty.")
+	flagset.StringVar(&admissionReportsSANames, "admissionReportsServiceAccountNames", "kyverno-admission-controller,kyverno-background-controller", "Comma separated names of the service accounts, in the Kyverno namespace, allowed to send admission reports to the reports API when admissionReportsStore is memory.")
+	flagset.IntVar(&admissionReportsMaxSize, "admissionReportsMaxSize", 10000, "Maximum number of admission reports held by the memory store, new reports are rejected until the stored ones are aggregated, 0 means unbounded.")
+	flagset.StringVar(&admissionReportsStoreName, "admissionReportsStore", admissionreports.StoreEtcd, "Where admission reports are stored, etcd or memory. The memory store receives reports through the reports API, requires reportsApiAddress and a single replica, and is lost on restart.")
+	flagset.BoolVar(&mutateReports, "mutateReports", false, "Watch the resources matched by mutate rules so that the mutate rules results reported by the admission and background controllers are aggregated in policy reports.")
+	flagset.BoolVar(&generateReports, "generateReports", false, "Watch the resources matched by generate rules so that the generate rules results reported by the background controller are aggregated in policy reports.")
+	flagset.BoolVar(&complianceReports, "complianceReports", false, "Compute the compliance scores of the ComplianceFrameworks from the policy reports and publish them in ComplianceReports.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -304,6 +534,68 @@ func main() {
 			exceptionsLister = lister
 		}
 	}
+	// setup results exporter
+	var sinks []exporter.Sink
+	if resultsExportWebhook != "" {
+		sinks = append(sinks, exporter.NewWebhookSink(resultsExportWebhook, 10*time.Second))
+	}
+	if resultsExportFile != "" {
+		sink, err := exporter.NewFileSink(resultsExportFile)
+		if err != nil {
+			logger.Error(err, "failed to create results export file sink")
+			os.Exit(1)
+		}
+		sinks = append(sinks, sink)
+	}
+	if resultsExportStdout {
+		sinks = append(sinks, exporter.NewStdoutSink())
+	}
+	resultsExporter := exporter.NewExporter(
+		logging.WithName("ResultsExporter"),
+		exporter.Options{
+			BufferSize:    resultsExportBufferSize,
+			BatchSize:     resultsExportBatchSize,
+			FlushInterval: resultsExportFlushPeriod,
+			MaxRetries:    resultsExportMaxRetries,
+			RetryBackoff:  time.Second,
+		},
+		sinks...,
+	)
+	// setup admission reports store
+	if err := admissionreports.ValidateStore(admissionReportsStoreName); err != nil {
+		logger.Error(err, "invalid admission reports store")
+		os.Exit(1)
+	}
+	var admissionReportsStore admissionreports.Store
+	if admissionReportsStoreName == admissionreports.StoreMemory {
+		if reportsApiAddress == "" {
+			logger.Error(errors.New("reportsApiAddress is required"), "the memory admission reports store receives reports through the reports api")
+			os.Exit(1)
+		}
+		admissionReportsStore = admissionreports.NewMemoryStore(admissionReportsMaxSize)
+	}
+	reportedRules := reportutils.ReportedRules{
+		Mutate:   mutateReports,
+		Generate: generateReports,
+	}
+	// setup reports api
+	var reportsApiHandler *httprouter.Router
+	var reportsApiAuth reportsapi.Auth
+	if reportsApiAddress != "" {
+		// decisions are cached and shared by the reports api and the admission reports endpoint
+		reportsApiAuth = reportsapi.NewCachedAuth(reportsapi.NewAuth(
+			kubeClient.AuthenticationV1().TokenReviews(),
+			kubeClient.AuthorizationV1().SubjectAccessReviews(),
+		))
+		reportsApiHandler = reportsapi.NewHandler(
+			logging.WithName("ReportsApi"),
+			reportsapi.NewIndex(
+				kyvernoInformer.Wgpolicyk8s().V1alpha2().PolicyReports(),
+				kyvernoInformer.Wgpolicyk8s().V1alpha2().ClusterPolicyReports(),
+			),
+			reportsApiAuth,
+		)
+	}
 	// start informers and wait for cache sync
 	if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kyvernoInformer, kubeKyvernoInformer, cacheInformer) {
 		logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -311,6 +603,10 @@ func main() {
 	}
 	// start event generator
 	go eventGenerator.Run(ctx, 3)
+	// start results exporter
+	if resultsExporter != nil {
+		go resultsExporter.Run(ctx)
+	}
 	eng := engine.NewEngine(
 		configuration,
 		dClient,
@@ -318,6 +614,64 @@ func main() {
 		engineapi.DefaultContextLoaderFactory(configMapResolver),
 		exceptionsLister,
 	)
+	if backgroundScanShards > 1 && backgroundScanShardIndex < 0 {
+		if err := checkStatefulSetPod(ctx, kubeClient); err != nil {
+			logger.Error(err, "failed to setup background scan sharding")
+			os.Exit(1)
+		}
+	}
+	backgroundScanShard, err := backgroundscancontroller.NewShard(backgroundScanShards, backgroundScanShardIndex, config.KyvernoPodName())
+	if err != nil {
+		logger.Error(err, "failed to setup background scan sharding")
+		os.Exit(1)
+	}
+	// start sharded background scan controllers
+	if backgroundScan && backgroundScanShard.Count > 1 {
+		logger := logger.WithName("shard")
+		// create shard factories
+		kubeInformer := kubeinformers.NewSharedInformerFactory(kubeClient, resyncPeriod)
+		kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+		metadataInformer := metadatainformers.NewSharedInformerFactory(metadataClient, 15*time.Minute)
+		// create shard controllers
+		shardControllers, warmup := createShardedBackgroundScanControllers(
+			eng,
+			backgroundScanWorkers,
+			backgroundScanShard,
+			backgroundScanNamespaceQPS,
+			backgroundScanNamespaceBurst,
+			reportedRules,
+			dClient,
+			kyvernoClient,
+			metadataInformer,
+			kubeInformer,
+			kyvernoInformer,
+			configMapResolver,
+			backgroundScanInterval,
+			configuration,
+			eventGenerator,
+			resultsExporter,
+		)
+		// start informers and wait for cache sync
+		if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kyvernoInformer, kubeInformer) {
+			logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+			os.Exit(1)
+		}
+		internal.StartInformers(ctx, metadataInformer)
+		if !internal.CheckCacheSync(logger, metadataInformer.WaitForCacheSync(ctx.Done())) {
+			logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+			os.Exit(1)
+		}
+		if err := warmup(ctx); err != nil {
+			logger.Error(err, "failed to run warmup")
+			os.Exit(1)
+		}
+		// start shard controllers
+		var wg sync.WaitGroup
+		for _, controller := range shardControllers {
+			controller.Run(ctx, logger.WithName("controllers"), &wg)
+		}
+		defer wg.Wait()
+	}
 	// setup leader election
 	le, err := leaderelection.New(
 		logger.WithName("leader-election"),
@@ -338,8 +692,15 @@ func main() {
 				eng,
 				backgroundScan,
 				admissionReports,
+				admissionReportsStore,
+				reportedRules,
 				reportsChunkSize,
+				perResourceReports,
+				reportHistorySize,
 				backgroundScanWorkers,
+				backgroundScanShard,
+				backgroundScanNamespaceQPS,
+				backgroundScanNamespaceBurst,
 				kubeInformer,
 				kyvernoInformer,
 				metadataInformer,
@@ -350,11 +711,34 @@ func main() {
 				eventGenerator,
 				configMapResolver,
 				backgroundScanInterval,
+				enablePolicyException,
+				complianceReports,
+				resultsExporter,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
 				os.Exit(1)
 			}
+			// the reports api certificates are managed by the leader
+			if reportsApiHandler != nil {
+				secretLister := kubeKyvernoInformer.Core().V1().Secrets().Lister().Secrets(config.KyvernoNamespace())
+				renewer := tls.NewCertRenewer(
+					kubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
+					secretLister,
+					tls.CertRenewalInterval,
+					tls.CAValidityDuration,
+					tls.TLSValidityDuration,
+					"",
+				)
+				leaderControllers = append(leaderControllers, internal.NewController(
+					certmanager.ControllerName,
+					certmanager.NewController(
+						kubeKyvernoInformer.Core().V1().Secrets(),
+						renewer,
+					),
+					certmanager.Workers,
+				))
+			}
 			// start informers and wait for cache sync
 			if !internal.StartInformersAndWaitForCacheSync(ctx, logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
 				logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
@@ -383,6 +767,43 @@ func main() {
 		logger.Error(err, "failed to initialize leader election")
 		os.Exit(1)
 	}
+	// start reports api
+	if reportsApiHandler != nil {
+		// admission reports are consumed by the leader controllers, other replicas reject them
+		// and only the admission and background controllers service accounts can send them
+		if admissionReportsStore != nil {
+			var users []string
+			for _, name := range strings.Split(admissionReportsSANames, ",") {
+				users = append(users, "system:serviceaccount:"+config.KyvernoNamespace()+":"+strings.TrimSpace(name))
+			}
+			admissionreports.Register(
+				logging.WithName("AdmissionReports"),
+				reportsApiHandler,
+				admissionReportsStore,
+				le.IsLeader,
+				reportsApiAuth,
+				users...,
+			)
+		}
+		reportsApiServer := reportsapi.NewServer(
+			logging.WithName("ReportsApi"),
+			reportsApiAddress,
+			reportsApiHandler,
+			func() ([]byte, []byte, error) {
+				secret, err := secretLister.Get(tls.GenerateTLSPairSecretName())
+				if err != nil {
+					return nil, nil, err
+				}
+				return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+			},
+		)
+		reportsApiServer.Run()
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			reportsApiServer.Stop(ctx)
+		}()
+	}
 	for {
 		select {
 		case <-ctx.Done():
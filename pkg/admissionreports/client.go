@@ -0,0 +1,134 @@
+package admissionreports
+
+import (
+	"context"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const listLimit = 1000
+
+type clientWriter struct {
+	client versioned.Interface
+}
+
+// NewClientWriter creates a writer creating reports in the API server
+func NewClientWriter(client versioned.Interface) Writer {
+	return &clientWriter{client: client}
+}
+
+func (w *clientWriter) Create(ctx context.Context, report kyvernov1alpha2.ReportInterface) (kyvernov1alpha2.ReportInterface, error) {
+	return reportutils.CreateReport(ctx, report, w.client)
+}
+
+type clientStore struct {
+	clientWriter
+	admrInformer  cache.SharedIndexInformer
+	cadmrInformer cache.SharedIndexInformer
+	admrLister    cache.GenericLister
+	cadmrLister   cache.GenericLister
+}
+
+// NewClientStore creates a store backed by the API server, using metadata informers to watch reports
+func NewClientStore(client versioned.Interface, metadataFactory metadatainformers.SharedInformerFactory) Store {
+	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
+	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
+	return &clientStore{
+		clientWriter:  clientWriter{client: client},
+		admrInformer:  admrInformer.Informer(),
+		cadmrInformer: cadmrInformer.Informer(),
+		admrLister:    admrInformer.Lister(),
+		cadmrLister:   cadmrInformer.Lister(),
+	}
+}
+
+func (s *clientStore) Get(ctx context.Context, namespace, name string) (kyvernov1alpha2.ReportInterface, error) {
+	if namespace == "" {
+		return s.client.KyvernoV1alpha2().ClusterAdmissionReports().Get(ctx, name, metav1.GetOptions{})
+	} else {
+		return s.client.KyvernoV1alpha2().AdmissionReports(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+}
+
+func (s *clientStore) Update(ctx context.Context, report kyvernov1alpha2.ReportInterface) (kyvernov1alpha2.ReportInterface, error) {
+	return reportutils.UpdateReport(ctx, report, s.client)
+}
+
+func (s *clientStore) Delete(ctx context.Context, namespace, name string) error {
+	if namespace == "" {
+		return s.client.KyvernoV1alpha2().ClusterAdmissionReports().Delete(ctx, name, metav1.DeleteOptions{})
+	} else {
+		return s.client.KyvernoV1alpha2().AdmissionReports(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+}
+
+func (s *clientStore) ListMetadata(selector labels.Selector) ([]metav1.Object, error) {
+	var results []metav1.Object
+	admrs, err := s.admrLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, admr := range admrs {
+		results = append(results, admr.(metav1.Object))
+	}
+	cadmrs, err := s.cadmrLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, cadmr := range cadmrs {
+		results = append(results, cadmr.(metav1.Object))
+	}
+	return results, nil
+}
+
+func (s *clientStore) List(ctx context.Context, namespace string, selector labels.Selector) ([]kyvernov1alpha2.ReportInterface, error) {
+	var reports []kyvernov1alpha2.ReportInterface
+	next := ""
+	for {
+		options := metav1.ListOptions{
+			LabelSelector: selector.String(),
+			Limit:         listLimit,
+			Continue:      next,
+		}
+		if namespace == "" {
+			cadms, err := s.client.KyvernoV1alpha2().ClusterAdmissionReports().List(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			next = cadms.Continue
+			for i := range cadms.Items {
+				reports = append(reports, &cadms.Items[i])
+			}
+		} else {
+			adms, err := s.client.KyvernoV1alpha2().AdmissionReports(namespace).List(ctx, options)
+			if err != nil {
+				return nil, err
+			}
+			next = adms.Continue
+			for i := range adms.Items {
+				reports = append(reports, &adms.Items[i])
+			}
+		}
+		if next == "" {
+			return reports, nil
+		}
+	}
+}
+
+func (s *clientStore) AddEventHandler(handler EventHandler) {
+	for _, informer := range []cache.SharedIndexInformer{s.admrInformer, s.cadmrInformer} {
+		controllerutils.AddEventHandlersT(
+			informer,
+			func(obj metav1.Object) { handler(obj) },
+			func(_, obj metav1.Object) { handler(obj) },
+			func(obj metav1.Object) { handler(obj) },
+		)
+	}
+}
@@ -0,0 +1,92 @@
+package admissionreports
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/reportsapi"
+	"golang.org/x/exp/slices"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// maxReportSize is the maximum size of a report received by the handler
+const maxReportSize = 3 * 1024 * 1024
+
+// Register adds the endpoint receiving admission reports to the router.
+// Callers are authenticated with their bearer token, only the given users are accepted and it must be allowed
+// to create the admission reports it sends. Reports are rejected with a service unavailable status when isLeader
+// returns false, only the leader runs the controllers consuming the store, and the connection is closed so that
+// the client retry can reach another replica.
+func Register(logger logr.Logger, router *httprouter.Router, store Writer, isLeader func() bool, auth reportsapi.Auth, users ...string) {
+	router.HandlerFunc(http.MethodPost, Path, func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userInfo, err := auth.Authenticate(ctx, r)
+		if err != nil {
+			if !errors.Is(err, reportsapi.ErrUnauthenticated) {
+				logger.Error(err, "failed to authenticate request")
+			}
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if !slices.Contains(users, userInfo.Username) {
+			http.Error(w, "user "+userInfo.Username+" cannot send admission reports", http.StatusForbidden)
+			return
+		}
+		if isLeader != nil && !isLeader() {
+			w.Header().Set("Connection", "close")
+			http.Error(w, "not the leader", http.StatusServiceUnavailable)
+			return
+		}
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxReportSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		report, err := decode(data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resource := "admissionreports"
+		if report.GetNamespace() == "" {
+			resource = "clusteradmissionreports"
+		}
+		allowed, err := auth.Authorize(ctx, userInfo, authorizationv1.ResourceAttributes{
+			Namespace: report.GetNamespace(),
+			Verb:      "create",
+			Group:     kyvernov1alpha2.SchemeGroupVersion.Group,
+			Resource:  resource,
+		})
+		if err != nil {
+			logger.Error(err, "failed to authorize request", "user", userInfo.Username)
+			http.Error(w, "failed to authorize request", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, "user "+userInfo.Username+" cannot create "+resource+" in namespace "+report.GetNamespace(), http.StatusForbidden)
+			return
+		}
+		created, err := store.Create(ctx, report)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if apierrors.IsAlreadyExists(err) {
+				status = http.StatusConflict
+			} else if apierrors.IsServiceUnavailable(err) {
+				status = http.StatusServiceUnavailable
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(created); err != nil {
+			logger.Error(err, "failed to write response")
+		}
+	})
+}
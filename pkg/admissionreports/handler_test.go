@@ -0,0 +1,179 @@
+package admissionreports
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	"github.com/kyverno/kyverno/pkg/reportsapi"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const serviceAccount = "system:serviceaccount:kyverno:kyverno-admission-controller"
+
+// fakeAuth maps bearer tokens to users and allows the given users to create admission reports
+type fakeAuth struct {
+	users   map[string]string
+	allowed map[string]bool
+}
+
+func (a fakeAuth) Authenticate(_ context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	user, ok := a.users[r.Header.Get("Authorization")]
+	if !ok {
+		return authenticationv1.UserInfo{}, reportsapi.ErrUnauthenticated
+	}
+	return authenticationv1.UserInfo{Username: user}, nil
+}
+
+func (a fakeAuth) Authorize(_ context.Context, user authenticationv1.UserInfo, _ authorizationv1.ResourceAttributes) (bool, error) {
+	return a.allowed[user.Username], nil
+}
+
+var testAuth = fakeAuth{
+	users: map[string]string{
+		"Bearer kyverno":   serviceAccount,
+		"Bearer other":     "system:serviceaccount:default:other",
+		"Bearer unallowed": "system:serviceaccount:kyverno:unallowed",
+	},
+	allowed: map[string]bool{
+		serviceAccount:                            true,
+		"system:serviceaccount:default:other":     true,
+		"system:serviceaccount:kyverno:unallowed": false,
+	},
+}
+
+// newTestWriter starts a TLS server with the admission reports endpoint and returns a writer sending reports to it
+func newTestWriter(t *testing.T, router *httprouter.Router, token string) Writer {
+	server := httptest.NewTLSServer(router)
+	t.Cleanup(server.Close)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NilError(t, os.WriteFile(tokenFile, []byte(token), 0o600))
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	writer := NewRemoteWriter(server.URL, func() ([]byte, error) { return ca, nil }, tokenFile).(*remoteWriter)
+	writer.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	return writer
+}
+
+func Test_RemoteWriter(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+	leader := true
+	router := httprouter.New()
+	Register(logr.Discard(), router, store, func() bool { return leader }, testAuth, serviceAccount)
+	writer := newTestWriter(t, router, "kyverno")
+	// namespaced report
+	created, err := writer.Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.NilError(t, err)
+	assert.Assert(t, created.GetResourceVersion() != "")
+	stored, err := store.Get(ctx, "ns", "r1")
+	assert.NilError(t, err)
+	assert.Equal(t, len(stored.GetResults()), 1)
+	// cluster report
+	_, err = writer.Create(ctx, newReport("", "r2", "uid-2"))
+	assert.NilError(t, err)
+	_, err = store.Get(ctx, "", "r2")
+	assert.NilError(t, err)
+	// duplicate
+	_, err = writer.Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.ErrorContains(t, err, "409")
+	// not the leader
+	leader = false
+	_, err = writer.Create(ctx, newReport("ns", "r3", "uid-3"))
+	assert.ErrorContains(t, err, "503")
+	_, err = store.Get(ctx, "ns", "r3")
+	assert.Assert(t, err != nil)
+}
+
+func Test_RemoteWriter_Retry(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+	attempts := 0
+	router := httprouter.New()
+	// the leadership is acquired after two rejected attempts
+	Register(logr.Discard(), router, store, func() bool { attempts++; return attempts > 2 }, testAuth, serviceAccount)
+	writer := newTestWriter(t, router, "kyverno")
+	_, err := writer.Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.NilError(t, err)
+	assert.Equal(t, attempts, 3)
+	_, err = store.Get(ctx, "ns", "r1")
+	assert.NilError(t, err)
+}
+
+func Test_Register_Auth(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+	router := httprouter.New()
+	Register(logr.Discard(), router, store, nil, testAuth, serviceAccount)
+	// unknown token
+	_, err := newTestWriter(t, router, "unknown").Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.ErrorContains(t, err, "401")
+	// not the admission controller service account
+	_, err = newTestWriter(t, router, "other").Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.ErrorContains(t, err, "403")
+	// not allowed to create admission reports
+	router = httprouter.New()
+	Register(logr.Discard(), router, store, nil, testAuth, "system:serviceaccount:kyverno:unallowed")
+	_, err = newTestWriter(t, router, "unallowed").Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.ErrorContains(t, err, "403")
+	_, err = store.Get(ctx, "ns", "r1")
+	assert.Assert(t, err != nil)
+}
+
+func Test_Register_invalidBody(t *testing.T) {
+	router := httprouter.New()
+	Register(logr.Discard(), router, NewMemoryStore(0), nil, testAuth, serviceAccount)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	req, err := http.NewRequest(http.MethodPost, server.URL+Path, nil)
+	assert.NilError(t, err)
+	req.Header.Set("Authorization", "Bearer kyverno")
+	resp, err := http.DefaultClient.Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusBadRequest)
+}
+
+// countingAuth counts the authentications made by the wrapped Auth
+type countingAuth struct {
+	reportsapi.Auth
+	authentications int
+}
+
+func (a *countingAuth) Authenticate(ctx context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	a.authentications++
+	return a.Auth.Authenticate(ctx, r)
+}
+
+func Test_Register_CachedAuth(t *testing.T) {
+	ctx := context.Background()
+	auth := &countingAuth{Auth: testAuth}
+	router := httprouter.New()
+	Register(logr.Discard(), router, NewMemoryStore(0), nil, reportsapi.NewCachedAuth(auth), serviceAccount)
+	writer := newTestWriter(t, router, "kyverno")
+	_, err := writer.Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.NilError(t, err)
+	_, err = writer.Create(ctx, newReport("ns", "r2", "uid-2"))
+	assert.NilError(t, err)
+	assert.Equal(t, auth.authentications, 1)
+}
+
+func Test_Register_StoreFull(t *testing.T) {
+	ctx := context.Background()
+	router := httprouter.New()
+	Register(logr.Discard(), router, NewMemoryStore(1), nil, testAuth, serviceAccount)
+	writer := newTestWriter(t, router, "kyverno")
+	_, err := writer.Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.NilError(t, err)
+	_, err = writer.Create(ctx, newReport("ns", "r2", "uid-2"))
+	assert.ErrorContains(t, err, "503")
+}
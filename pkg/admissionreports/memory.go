@@ -0,0 +1,203 @@
+package admissionreports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/logging"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+var (
+	admrResource  = kyvernov1alpha2.Resource("admissionreports")
+	cadmrResource = kyvernov1alpha2.Resource("clusteradmissionreports")
+)
+
+type memoryStore struct {
+	lock            sync.RWMutex
+	reports         map[string]kyvernov1alpha2.ReportInterface
+	maxReports      int
+	resourceVersion uint64
+	handlers        []EventHandler
+	metrics         storeMetrics
+}
+
+// NewMemoryStore creates a store keeping reports in memory.
+// Reports are lost when the process restarts, they are rebuilt from new admission requests and background scans.
+// When the store holds maxReports reports new reports are rejected with a service unavailable error until
+// the controllers consume them, zero means unbounded.
+func NewMemoryStore(maxReports int) Store {
+	return &memoryStore{
+		reports:    map[string]kyvernov1alpha2.ReportInterface{},
+		maxReports: maxReports,
+		metrics:    newStoreMetrics(logging.WithName("AdmissionReportsStore")),
+	}
+}
+
+func storeKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func groupResource(namespace string) schema.GroupResource {
+	if namespace == "" {
+		return cadmrResource
+	}
+	return admrResource
+}
+
+func (s *memoryStore) Create(ctx context.Context, report kyvernov1alpha2.ReportInterface) (kyvernov1alpha2.ReportInterface, error) {
+	if err := checkType(report); err != nil {
+		return nil, err
+	}
+	report = reportutils.DeepCopy(report)
+	key := storeKey(report.GetNamespace(), report.GetName())
+	s.lock.Lock()
+	if _, exists := s.reports[key]; exists {
+		s.lock.Unlock()
+		return nil, apierrors.NewAlreadyExists(groupResource(report.GetNamespace()), report.GetName())
+	}
+	if s.maxReports > 0 && len(s.reports) >= s.maxReports {
+		s.lock.Unlock()
+		s.metrics.recordRejected(ctx)
+		return nil, apierrors.NewServiceUnavailable(fmt.Sprintf("the admission reports store is full, it holds %d reports", s.maxReports))
+	}
+	s.resourceVersion++
+	report.SetResourceVersion(strconv.FormatUint(s.resourceVersion, 10))
+	report.SetUID(uuid.NewUUID())
+	report.SetCreationTimestamp(metav1.Now())
+	s.reports[key] = report
+	handlers := s.handlers
+	s.lock.Unlock()
+	notify(handlers, report)
+	return reportutils.DeepCopy(report), nil
+}
+
+func (s *memoryStore) Get(_ context.Context, namespace, name string) (kyvernov1alpha2.ReportInterface, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	report, exists := s.reports[storeKey(namespace, name)]
+	if !exists {
+		return nil, apierrors.NewNotFound(groupResource(namespace), name)
+	}
+	return reportutils.DeepCopy(report), nil
+}
+
+func (s *memoryStore) Update(_ context.Context, report kyvernov1alpha2.ReportInterface) (kyvernov1alpha2.ReportInterface, error) {
+	if err := checkType(report); err != nil {
+		return nil, err
+	}
+	report = reportutils.DeepCopy(report)
+	key := storeKey(report.GetNamespace(), report.GetName())
+	s.lock.Lock()
+	current, exists := s.reports[key]
+	if !exists {
+		s.lock.Unlock()
+		return nil, apierrors.NewNotFound(groupResource(report.GetNamespace()), report.GetName())
+	}
+	if report.GetResourceVersion() != "" && report.GetResourceVersion() != current.GetResourceVersion() {
+		s.lock.Unlock()
+		return nil, apierrors.NewConflict(groupResource(report.GetNamespace()), report.GetName(), errors.New("the object has been modified"))
+	}
+	s.resourceVersion++
+	report.SetResourceVersion(strconv.FormatUint(s.resourceVersion, 10))
+	report.SetUID(current.GetUID())
+	report.SetCreationTimestamp(current.GetCreationTimestamp())
+	s.reports[key] = report
+	handlers := s.handlers
+	s.lock.Unlock()
+	notify(handlers, report)
+	return reportutils.DeepCopy(report), nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, namespace, name string) error {
+	key := storeKey(namespace, name)
+	s.lock.Lock()
+	report, exists := s.reports[key]
+	if !exists {
+		s.lock.Unlock()
+		return apierrors.NewNotFound(groupResource(namespace), name)
+	}
+	delete(s.reports, key)
+	handlers := s.handlers
+	s.lock.Unlock()
+	notify(handlers, report)
+	return nil
+}
+
+func (s *memoryStore) ListMetadata(selector labels.Selector) ([]metav1.Object, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var results []metav1.Object
+	for _, report := range s.reports {
+		if selector.Matches(labels.Set(report.GetLabels())) {
+			results = append(results, metadataOnly(report))
+		}
+	}
+	return results, nil
+}
+
+func (s *memoryStore) List(_ context.Context, namespace string, selector labels.Selector) ([]kyvernov1alpha2.ReportInterface, error) {
+	s.lock.RLock()
+	var reports []kyvernov1alpha2.ReportInterface
+	for _, report := range s.reports {
+		if report.GetNamespace() == namespace && selector.Matches(labels.Set(report.GetLabels())) {
+			reports = append(reports, reportutils.DeepCopy(report))
+		}
+	}
+	s.lock.RUnlock()
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].GetName() < reports[j].GetName()
+	})
+	return reports, nil
+}
+
+func (s *memoryStore) AddEventHandler(handler EventHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+func checkType(report kyvernov1alpha2.ReportInterface) error {
+	switch report.(type) {
+	case *kyvernov1alpha2.AdmissionReport:
+		if report.GetNamespace() == "" {
+			return errors.New("admission report must be namespaced")
+		}
+		return nil
+	case *kyvernov1alpha2.ClusterAdmissionReport:
+		if report.GetNamespace() != "" {
+			return errors.New("cluster admission report must not be namespaced")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported report type %T", report)
+}
+
+func notify(handlers []EventHandler, report kyvernov1alpha2.ReportInterface) {
+	if len(handlers) == 0 {
+		return
+	}
+	meta := metadataOnly(report)
+	for _, handler := range handlers {
+		handler(meta)
+	}
+}
+
+func metadataOnly(report kyvernov1alpha2.ReportInterface) metav1.Object {
+	meta := &metav1.PartialObjectMetadata{}
+	report.(metav1.ObjectMetaAccessor).GetObjectMeta().(*metav1.ObjectMeta).DeepCopyInto(&meta.ObjectMeta)
+	return meta
+}
@@ -0,0 +1,100 @@
+package admissionreports
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newReport(namespace, name string, uid types.UID) kyvernov1alpha2.ReportInterface {
+	report := reportutils.NewAdmissionReport(namespace, name, "owner", uid, metav1.GroupVersionKind{Version: "v1", Kind: "Pod"})
+	reportutils.SetResults(report, policyreportv1alpha2.PolicyReportResult{Policy: "policy", Rule: "rule", Result: policyreportv1alpha2.StatusPass})
+	return report
+}
+
+func Test_memoryStore_CRUD(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+	var events []string
+	store.AddEventHandler(func(obj metav1.Object) { events = append(events, obj.GetName()) })
+	created, err := store.Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.NilError(t, err)
+	assert.Assert(t, created.GetResourceVersion() != "")
+	timestamp := created.GetCreationTimestamp()
+	assert.Assert(t, !timestamp.IsZero())
+	_, err = store.Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.Assert(t, apierrors.IsAlreadyExists(err))
+	_, err = store.Create(ctx, newReport("", "r2", "uid-2"))
+	assert.NilError(t, err)
+	fetched, err := store.Get(ctx, "ns", "r1")
+	assert.NilError(t, err)
+	assert.Equal(t, len(fetched.GetResults()), 1)
+	_, err = store.Get(ctx, "", "r1")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	// updates with a stale resource version are rejected
+	fetched.SetResults(nil)
+	updated, err := store.Update(ctx, fetched)
+	assert.NilError(t, err)
+	assert.Assert(t, updated.GetResourceVersion() != fetched.GetResourceVersion())
+	_, err = store.Update(ctx, fetched)
+	assert.Assert(t, apierrors.IsConflict(err))
+	assert.NilError(t, store.Delete(ctx, "ns", "r1"))
+	assert.Assert(t, apierrors.IsNotFound(store.Delete(ctx, "ns", "r1")))
+	assert.DeepEqual(t, events, []string{"r1", "r2", "r1", "r1"})
+}
+
+func Test_memoryStore_List(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+	for _, report := range []kyvernov1alpha2.ReportInterface{
+		newReport("ns", "b", "uid-1"),
+		newReport("ns", "a", "uid-2"),
+		newReport("other", "c", "uid-1"),
+		newReport("", "d", "uid-1"),
+	} {
+		_, err := store.Create(ctx, report)
+		assert.NilError(t, err)
+	}
+	reports, err := store.List(ctx, "ns", labels.Everything())
+	assert.NilError(t, err)
+	assert.Equal(t, len(reports), 2)
+	assert.Equal(t, reports[0].GetName(), "a")
+	assert.Equal(t, reports[1].GetName(), "b")
+	reports, err = store.List(ctx, "", labels.Everything())
+	assert.NilError(t, err)
+	assert.Equal(t, len(reports), 1)
+	_, isCluster := reports[0].(*kyvernov1alpha2.ClusterAdmissionReport)
+	assert.Assert(t, isCluster)
+	selector, err := reportutils.SelectorResourceUidEquals("uid-1")
+	assert.NilError(t, err)
+	metas, err := store.ListMetadata(selector)
+	assert.NilError(t, err)
+	assert.Equal(t, len(metas), 3)
+}
+
+func Test_memoryStore_rejectsOtherReports(t *testing.T) {
+	store := NewMemoryStore(0)
+	_, err := store.Create(context.Background(), &kyvernov1alpha2.BackgroundScanReport{})
+	assert.Assert(t, err != nil)
+}
+
+func Test_memoryStore_MaxReports(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(1)
+	_, err := store.Create(ctx, newReport("ns", "r1", "uid-1"))
+	assert.NilError(t, err)
+	_, err = store.Create(ctx, newReport("ns", "r2", "uid-2"))
+	assert.Assert(t, apierrors.IsServiceUnavailable(err))
+	// reports are accepted again once the stored ones are consumed
+	assert.NilError(t, store.Delete(ctx, "ns", "r1"))
+	_, err = store.Create(ctx, newReport("ns", "r2", "uid-2"))
+	assert.NilError(t, err)
+}
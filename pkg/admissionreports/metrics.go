@@ -0,0 +1,34 @@
+package admissionreports
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+type storeMetrics struct {
+	rejectedTotal syncint64.Counter
+}
+
+func newStoreMetrics(logger logr.Logger) storeMetrics {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	rejectedTotal, err := meter.SyncInt64().Counter(
+		"kyverno_admission_reports_store_rejected",
+		instrument.WithDescription("can be used to track admission reports rejected because the memory store is full"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_reports_store_rejected")
+	}
+	return storeMetrics{
+		rejectedTotal: rejectedTotal,
+	}
+}
+
+func (m storeMetrics) recordRejected(ctx context.Context) {
+	if m.rejectedTotal != nil {
+		m.rejectedTotal.Add(ctx, 1)
+	}
+}
@@ -0,0 +1,202 @@
+package admissionreports
+
+import (
+	"bytes"
+	"context"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/tls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	k8stransport "k8s.io/client-go/transport"
+)
+
+// Path is the path of the reports controller API endpoint receiving admission reports
+const Path = "/api/v1/admissionreports"
+
+// TokenFile is the service account token presented to the reports controller API
+const TokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// CAProvider returns the PEM encoded root CA of the reports controller API
+type CAProvider func() ([]byte, error)
+
+// backoff is used to retry reports rejected by a replica not leading or not reachable,
+// for example while the leadership moves or the reports controller restarts
+var backoff = wait.Backoff{
+	Duration: 200 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    6,
+}
+
+// statusError is returned when the reports controller API answers with an unexpected status
+type statusError struct {
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("failed to create admission report (%d): %s", e.code, e.message)
+}
+
+// NewRootCAProvider returns a CAProvider reading the root CA of the reports controller API at the given base url,
+// the reports controller stores it in a secret named after the host of its service
+func NewRootCAProvider(lister corev1listers.SecretNamespaceLister, apiUrl string) CAProvider {
+	return func() ([]byte, error) {
+		u, err := url.Parse(apiUrl)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := lister.Get(tls.RootCASecretName(u.Hostname()))
+		if err != nil {
+			return nil, err
+		}
+		ca := secret.Data[corev1.TLSCertKey]
+		if len(ca) == 0 {
+			return nil, fmt.Errorf("%s in secret %s/%s", tls.ErrorsNotFound, secret.GetNamespace(), secret.GetName())
+		}
+		return ca, nil
+	}
+}
+
+type remoteWriter struct {
+	url        string
+	caProvider CAProvider
+	tokenFile  string
+	backoff    wait.Backoff
+	lock       sync.Mutex
+	ca         []byte
+	client     *http.Client
+}
+
+// NewRemoteWriter creates a writer sending reports over TLS to the reports controller API at the given base url,
+// authenticated with the service account token read from tokenFile
+func NewRemoteWriter(url string, caProvider CAProvider, tokenFile string) Writer {
+	return &remoteWriter{
+		url:        strings.TrimSuffix(url, "/") + Path,
+		caProvider: caProvider,
+		tokenFile:  tokenFile,
+		backoff:    backoff,
+	}
+}
+
+func (w *remoteWriter) Create(ctx context.Context, report kyvernov1alpha2.ReportInterface) (kyvernov1alpha2.ReportInterface, error) {
+	if err := checkType(report); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	backoff := w.backoff
+	for {
+		created, err := w.create(ctx, data)
+		if err == nil || !isRetriable(err) || backoff.Steps <= 1 {
+			return created, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+func (w *remoteWriter) create(ctx context.Context, data []byte) (kyvernov1alpha2.ReportInterface, error) {
+	client, err := w.getClient()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return nil, &statusError{code: resp.StatusCode, message: strings.TrimSpace(string(body))}
+	}
+	return decode(body)
+}
+
+// getClient returns the http client trusting the current root CA of the reports controller API,
+// the client is recreated when the CA is renewed
+func (w *remoteWriter) getClient() (*http.Client, error) {
+	ca, err := w.caProvider()
+	if err != nil {
+		return nil, err
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.client != nil && bytes.Equal(ca, w.ca) {
+		return w.client, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("failed to parse the reports controller root CA")
+	}
+	var transport http.RoundTripper = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: &cryptotls.Config{
+			RootCAs:    pool,
+			MinVersion: cryptotls.VersionTLS12,
+		},
+	}
+	// the token file is read again when the projected token is rotated
+	transport, err = k8stransport.NewBearerAuthWithRefreshRoundTripper("", w.tokenFile, transport)
+	if err != nil {
+		return nil, err
+	}
+	w.ca = ca
+	w.client = &http.Client{
+		Transport: transport,
+		Timeout:   10 * time.Second,
+	}
+	return w.client, nil
+}
+
+// isRetriable returns true for the errors caused by a replica that is not leading or not reachable
+func isRetriable(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		return status.code == http.StatusServiceUnavailable
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// decode unmarshals an admission report, reports without a namespace are returned as ClusterAdmissionReport
+func decode(data []byte) (kyvernov1alpha2.ReportInterface, error) {
+	var report kyvernov1alpha2.AdmissionReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	if report.GetNamespace() != "" {
+		return &report, nil
+	}
+	return &kyvernov1alpha2.ClusterAdmissionReport{
+		ObjectMeta: report.ObjectMeta,
+		Spec:       report.Spec,
+	}, nil
+}
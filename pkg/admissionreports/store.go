@@ -0,0 +1,70 @@
+package admissionreports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// StoreEtcd keeps admission reports in the API server
+	StoreEtcd = "etcd"
+	// StoreMemory keeps admission reports in the memory of the reports controller
+	StoreMemory = "memory"
+)
+
+// Writer persists the admission reports built when admission requests are processed
+type Writer interface {
+	// Create persists a new report
+	Create(context.Context, kyvernov1alpha2.ReportInterface) (kyvernov1alpha2.ReportInterface, error)
+}
+
+// EventHandler is called when a report is created, updated or deleted
+type EventHandler func(metav1.Object)
+
+// Store holds the AdmissionReport and ClusterAdmissionReport objects, namespaced reports are stored
+// as AdmissionReport and reports without a namespace as ClusterAdmissionReport
+type Store interface {
+	Writer
+	// Get returns a report, or a not found error
+	Get(ctx context.Context, namespace, name string) (kyvernov1alpha2.ReportInterface, error)
+	// Update updates an existing report
+	Update(context.Context, kyvernov1alpha2.ReportInterface) (kyvernov1alpha2.ReportInterface, error)
+	// Delete deletes a report, or returns a not found error
+	Delete(ctx context.Context, namespace, name string) error
+	// ListMetadata returns the metadata of the reports matching the selector, in all namespaces and the cluster scope
+	ListMetadata(labels.Selector) ([]metav1.Object, error)
+	// List returns the reports of a namespace matching the selector, the cluster wide reports when the namespace is empty
+	List(ctx context.Context, namespace string, selector labels.Selector) ([]kyvernov1alpha2.ReportInterface, error)
+	// AddEventHandler registers a handler called on report changes
+	AddEventHandler(EventHandler)
+}
+
+// ValidateStore checks the name of an admission reports store
+func ValidateStore(name string) error {
+	switch name {
+	case StoreEtcd, StoreMemory:
+		return nil
+	}
+	return fmt.Errorf("unsupported admission reports store %s, supported stores are %s and %s", name, StoreEtcd, StoreMemory)
+}
+
+// NewWriter creates the writer for a store, reports are sent to the reports controller API at url
+// with the memory store and created in the API server with the etcd store
+func NewWriter(client versioned.Interface, store, url string, caProvider CAProvider) (Writer, error) {
+	if err := ValidateStore(store); err != nil {
+		return nil, err
+	}
+	if store == StoreMemory {
+		if url == "" {
+			return nil, errors.New("the reports controller url is required with the memory store")
+		}
+		return NewRemoteWriter(url, caProvider, TokenFile), nil
+	}
+	return NewClientWriter(client), nil
+}
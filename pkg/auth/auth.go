@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 
+	authenticationv1 "k8s.io/api/authentication/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -55,16 +56,9 @@ func NewCanI(discovery Discovery, ssarClient authorizationv1client.SelfSubjectAc
 // - If disallowed, the reason and evaluationError is available in the logs
 // - each can generates a SelfSubjectAccessReview resource and response is evaluated for permissions
 func (o *canIOptions) RunAccessCheck(ctx context.Context) (bool, error) {
-	// get GroupVersionResource from RESTMapper
-	// get GVR from kind
-	gvr, err := o.discovery.GetGVRFromKind(o.kind)
+	gvr, err := resolveGVR(o.discovery, o.kind)
 	if err != nil {
-		return false, fmt.Errorf("failed to get GVR for kind %s", o.kind)
-	}
-
-	if reflect.DeepEqual(gvr, schema.GroupVersionResource{}) {
-		// cannot find GVR
-		return false, fmt.Errorf("failed to get the Group Version Resource for kind %s", o.kind)
+		return false, err
 	}
 
 	sar := &authorizationv1.SelfSubjectAccessReview{
@@ -101,3 +95,78 @@ func (o *canIOptions) RunAccessCheck(ctx context.Context) (bool, error) {
 
 	return resp.Status.Allowed, nil
 }
+
+type userCanIOptions struct {
+	namespace   string
+	verb        string
+	kind        string
+	subresource string
+	user        authenticationv1.UserInfo
+	discovery   Discovery
+	sarClient   authorizationv1client.SubjectAccessReviewInterface
+}
+
+// NewCanIForUser returns a new instance of operation access controller evaluator
+// checking the permissions of the given user instead of the caller ones
+func NewCanIForUser(discovery Discovery, sarClient authorizationv1client.SubjectAccessReviewInterface, user authenticationv1.UserInfo, kind, namespace, verb, subresource string) CanIOptions {
+	return &userCanIOptions{
+		namespace:   namespace,
+		verb:        verb,
+		kind:        kind,
+		subresource: subresource,
+		user:        user,
+		discovery:   discovery,
+		sarClient:   sarClient,
+	}
+}
+
+// RunAccessCheck checks if the user can perform the operation
+// - works the same as the caller check but generates a SubjectAccessReview resource for the user
+func (o *userCanIOptions) RunAccessCheck(ctx context.Context) (bool, error) {
+	gvr, err := resolveGVR(o.discovery, o.kind)
+	if err != nil {
+		return false, err
+	}
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range o.user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   o.namespace,
+				Verb:        o.verb,
+				Group:       gvr.Group,
+				Resource:    gvr.Resource,
+				Subresource: o.subresource,
+			},
+			User:   o.user.Username,
+			Groups: o.user.Groups,
+			UID:    o.user.UID,
+			Extra:  extra,
+		},
+	}
+	logger := logger.WithValues("user", o.user.Username, "kind", o.kind, "namespace", o.namespace, "verb", o.verb)
+	resp, err := o.sarClient.Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		logger.Error(err, "failed to create resource")
+		return false, err
+	}
+	if !resp.Status.Allowed {
+		logger.Info("disallowed operation", "reason", resp.Status.Reason, "evaluationError", resp.Status.EvaluationError)
+	}
+	return resp.Status.Allowed, nil
+}
+
+// resolveGVR determines the group version resource from the kind using the discovery client REST mapper
+func resolveGVR(discovery Discovery, kind string) (schema.GroupVersionResource, error) {
+	gvr, err := discovery.GetGVRFromKind(kind)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("failed to get GVR for kind %s", kind)
+	}
+	if reflect.DeepEqual(gvr, schema.GroupVersionResource{}) {
+		// cannot find GVR
+		return schema.GroupVersionResource{}, fmt.Errorf("failed to get the Group Version Resource for kind %s", kind)
+	}
+	return gvr, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -184,3 +185,39 @@ func TestCanIOptions_RunAccessCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestUserCanIOptions_RunAccessCheck(t *testing.T) {
+	user := authenticationv1.UserInfo{
+		Username: "system:serviceaccount:default:cleanup",
+		Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:default", "system:authenticated"},
+	}
+	tests := []struct {
+		name    string
+		kind    string
+		want    bool
+		wantErr bool
+	}{{
+		name:    "deployments",
+		kind:    "Deployment",
+		want:    false,
+		wantErr: false,
+	}, {
+		name:    "unknown",
+		kind:    "Unknown",
+		want:    false,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dclient.NewEmptyFakeClient()
+			o := NewCanIForUser(client.Discovery(), client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), user, tt.kind, "default", "delete", "")
+			got, err := o.RunAccessCheck(context.TODO())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -236,6 +236,8 @@ func generateRules(spec *kyvernov1.Spec, controllers string) []kyvernov1.Rule {
 }
 
 func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
+	// report metadata is not subject to the references rewriting below
+	reportMetadata := rule.ReportMetadata.DeepCopy()
 	if bytes, err := json.Marshal(rule); err != nil {
 		return nil, err
 	} else {
@@ -253,8 +255,9 @@ func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 	}
 
 	out := kyvernov1.Rule{
-		Name:         rule.Name,
-		VerifyImages: rule.VerifyImages,
+		Name:           rule.Name,
+		VerifyImages:   rule.VerifyImages,
+		ReportMetadata: reportMetadata,
 	}
 	if rule.MatchResources != nil {
 		out.MatchResources = *rule.MatchResources
@@ -805,3 +805,19 @@ func Test_PodSecurityWithNoExceptions(t *testing.T) {
 	rules := computeRules(policies[0])
 	assert.Equal(t, 3, len(rules))
 }
+
+func Test_ReportMetadata(t *testing.T) {
+	policy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"pod-security"},"spec":{"validationFailureAction":"enforce","rules":[{"name":"restricted","match":{"all":[{"resources":{"kinds":["Pod"]}}]},"validate":{"podSecurity":{"level":"restricted","version":"v1.24"}},"reportMetadata":{"severity":"high","properties":{"docs":"https://example.com/metadata"}}}]}}`)
+	policies, err := yamlutils.GetPolicy([]byte(policy))
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(policies))
+
+	rules := computeRules(policies[0])
+	assert.Equal(t, 3, len(rules))
+	for _, rule := range rules {
+		assert.DeepEqual(t, rule.ReportMetadata, &kyverno.ReportMetadata{
+			Severity:   "high",
+			Properties: map[string]string{"docs": "https://example.com/metadata"},
+		})
+	}
+}
@@ -29,6 +29,7 @@ type kyvernoRule struct {
 	Mutation         *kyvernov1.Mutation           `json:"mutate,omitempty"`
 	Validation       *kyvernov1.Validation         `json:"validate,omitempty"`
 	VerifyImages     []kyvernov1.ImageVerification `json:"verifyImages,omitempty" yaml:"verifyImages,omitempty"`
+	ReportMetadata   *kyvernov1.ReportMetadata     `json:"reportMetadata,omitempty"`
 }
 
 func createRule(rule *kyvernov1.Rule) *kyvernoRule {
@@ -65,6 +66,9 @@ func createRule(rule *kyvernov1.Rule) *kyvernoRule {
 	if len(rule.Context) > 0 {
 		jsonFriendlyStruct.Context = &rule.DeepCopy().Context
 	}
+	if rule.ReportMetadata != nil {
+		jsonFriendlyStruct.ReportMetadata = rule.ReportMetadata.DeepCopy()
+	}
 	return &jsonFriendlyStruct
 }
 
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// CreateReport creates an admission report with the results of the rules applied to the trigger of an update request,
+// nothing is reported when the writer is nil
+func CreateReport(ctx context.Context, writer admissionreports.Writer, trigger unstructured.Unstructured, policy kyvernov1.PolicyInterface, rules ...engineapi.RuleResponse) error {
+	if writer == nil || len(rules) == 0 {
+		return nil
+	}
+	if !reportutils.IsGvkSupported(trigger.GroupVersionKind()) {
+		return nil
+	}
+	response := &engineapi.EngineResponse{
+		Resource: trigger,
+		Policy:   policy,
+		PolicyResponse: engineapi.PolicyResponse{
+			Rules: rules,
+		},
+	}
+	report := reportutils.BuildResourceAdmissionReport(string(uuid.NewUUID()), trigger, response)
+	if len(report.GetResults()) == 0 {
+		return nil
+	}
+	_, err := writer.Create(ctx, report)
+	return err
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
@@ -52,6 +53,9 @@ type GenerateController struct {
 	configuration config.Configuration
 	eventGen      event.Interface
 
+	// reports persists the results of the rules, nil when they are not reported
+	reports admissionreports.Writer
+
 	log logr.Logger
 }
 
@@ -67,6 +71,7 @@ func NewGenerateController(
 	nsLister corev1listers.NamespaceLister,
 	dynamicConfig config.Configuration,
 	eventGen event.Interface,
+	reports admissionreports.Writer,
 	log logr.Logger,
 ) *GenerateController {
 	c := GenerateController{
@@ -80,6 +85,7 @@ func NewGenerateController(
 		nsLister:      nsLister,
 		configuration: dynamicConfig,
 		eventGen:      eventGen,
+		reports:       reports,
 		log:           log,
 	}
 	return &c
@@ -179,9 +185,11 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	}
 
 	var applicableRules []string
+	var results []engineapi.RuleResponse
 	// Removing UR if rule is failed. Used when the generate condition failed but ur exist
 	for _, r := range engineResponse.PolicyResponse.Rules {
 		if r.Status != engineapi.RuleStatusPass {
+			results = append(results, r)
 			logger.V(4).Info("querying all update requests")
 			selector := labels.SelectorFromSet(labels.Set(map[string]string{
 				kyvernov1beta1.URGeneratePolicyLabel:       engineResponse.Policy.GetName(),
@@ -207,7 +215,56 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	}
 
 	// Apply the generate rule on resource
-	return c.ApplyGeneratePolicy(logger, policyContext, ur, applicableRules)
+	genResources, processExisting, err := c.ApplyGeneratePolicy(logger, policyContext, ur, applicableRules)
+	for _, r := range engineResponse.PolicyResponse.Rules {
+		if !slices.Contains(applicableRules, r.Name) {
+			continue
+		}
+		if err != nil {
+			r.Status = engineapi.RuleStatusError
+			r.Message = fmt.Sprintf("failed to generate target resources: %v", err)
+		} else if len(genResources) > 0 {
+			r.Message = fmt.Sprintf("generated target resources: %s", genResourcesToString(genResources))
+		}
+		results = append(results, r)
+	}
+	c.reportResults(logger, ur, resource, results...)
+	return genResources, processExisting, err
+}
+
+// reportResults creates an admission report owned by the trigger with the results of the generate rules
+func (c *GenerateController) reportResults(logger logr.Logger, ur kyvernov1beta1.UpdateRequest, trigger unstructured.Unstructured, results ...engineapi.RuleResponse) {
+	if c.reports == nil {
+		return
+	}
+	// the policy spec used to apply the rules is wrapped in a cluster policy, the report needs the original policy
+	policy, err := c.getPolicy(ur.Spec.Policy)
+	if err != nil {
+		logger.Error(err, "failed to get policy, generate results are not reported")
+		return
+	}
+	if err := common.CreateReport(context.TODO(), c.reports, trigger, policy, results...); err != nil {
+		logger.Error(err, "failed to create report")
+	}
+}
+
+func (c *GenerateController) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
+	pNamespace, pName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if pNamespace != "" {
+		return c.npolicyLister.Policies(pNamespace).Get(pName)
+	}
+	return c.policyLister.Get(pName)
+}
+
+func genResourcesToString(genResources []kyvernov1.ResourceSpec) string {
+	var names []string
+	for _, r := range genResources {
+		names = append(names, r.String())
+	}
+	return strings.Join(names, ", ")
 }
 
 // cleanupClonedResource deletes cloned resource if sync is not enabled for the clone policy
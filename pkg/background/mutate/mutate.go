@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/background/common"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -40,6 +41,9 @@ type MutateExistingController struct {
 	configuration config.Configuration
 	eventGen      event.Interface
 
+	// reports persists the results of the rules, nil when they are not reported
+	reports admissionreports.Writer
+
 	log logr.Logger
 }
 
@@ -53,6 +57,7 @@ func NewMutateExistingController(
 	nsLister corev1listers.NamespaceLister,
 	dynamicConfig config.Configuration,
 	eventGen event.Interface,
+	reports admissionreports.Writer,
 	log logr.Logger,
 ) *MutateExistingController {
 	c := MutateExistingController{
@@ -64,6 +69,7 @@ func NewMutateExistingController(
 		nsLister:      nsLister,
 		configuration: dynamicConfig,
 		eventGen:      eventGen,
+		reports:       reports,
 		log:           log,
 	}
 	return &c
@@ -72,6 +78,8 @@ func NewMutateExistingController(
 func (c *MutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) error {
 	logger := c.log.WithValues("name", ur.GetName(), "policy", ur.Spec.GetPolicyKey(), "resource", ur.Spec.GetResource().String())
 	var errs []error
+	var results []engineapi.RuleResponse
+	var reportedTrigger *unstructured.Unstructured
 
 	policy, err := c.getPolicy(ur.Spec.Policy)
 	if err != nil {
@@ -100,6 +108,7 @@ func (c *MutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 		}
 
 		er := c.engine.Mutate(context.TODO(), policyContext)
+		reportedTrigger = trigger
 		for _, r := range er.PolicyResponse.Rules {
 			patched := r.PatchedTarget
 			patchedTargetSubresourceName := r.PatchedTargetSubresourceName
@@ -109,6 +118,7 @@ func (c *MutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 				logger.Error(err, "")
 				errs = append(errs, err)
 				c.report(err, ur.Spec.Policy, rule.Name, patched)
+				results = appendResult(results, r)
 
 			case engineapi.RuleStatusSkip:
 				logger.Info("mutate existing rule skipped", "rule", r.Name, "message", r.Message)
@@ -154,11 +164,22 @@ func (c *MutateExistingController) ProcessUR(ur *kyvernov1beta1.UpdateRequest) e
 					}
 
 					c.report(updateErr, ur.Spec.Policy, rule.Name, patched)
+					if updateErr != nil {
+						r.Status = engineapi.RuleStatusError
+						r.Message = fmt.Sprintf("failed to update target resource: %v", updateErr)
+					}
+					results = appendResult(results, r)
 				}
 			}
 		}
 	}
 
+	if reportedTrigger != nil {
+		if err := common.CreateReport(context.TODO(), c.reports, *reportedTrigger, policy, results...); err != nil {
+			logger.Error(err, "failed to create report")
+		}
+	}
+
 	err = multierr.Combine(errs...)
 	return updateURStatus(c.statusControl, *ur, err)
 }
@@ -192,6 +213,17 @@ func (c *MutateExistingController) report(err error, policy, rule string, target
 	c.eventGen.Add(events...)
 }
 
+// appendResult appends the response of a rule unless the rule was already reported,
+// the engine returns the responses of all the rules each time the policy is applied
+func appendResult(results []engineapi.RuleResponse, result engineapi.RuleResponse) []engineapi.RuleResponse {
+	for _, r := range results {
+		if r.Name == result.Name {
+			return results
+		}
+	}
+	return append(results, result)
+}
+
 func updateURStatus(statusControl common.StatusControlInterface, ur kyvernov1beta1.UpdateRequest, err error) error {
 	if err != nil {
 		if _, err := statusControl.Failed(ur.GetName(), err.Error(), nil); err != nil {
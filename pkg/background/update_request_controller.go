@@ -8,6 +8,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	common "github.com/kyverno/kyverno/pkg/background/common"
 	"github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/background/mutate"
@@ -62,6 +63,11 @@ type controller struct {
 	eventGen               event.Interface
 	configuration          config.Configuration
 	informerCacheResolvers engineapi.ConfigmapResolver
+
+	// mutateReports persists the results of mutate existing rules, nil when they are not reported
+	mutateReports admissionreports.Writer
+	// generateReports persists the results of generate rules, nil when they are not reported
+	generateReports admissionreports.Writer
 }
 
 // NewController returns an instance of the Generate-Request Controller
@@ -76,6 +82,8 @@ func NewController(
 	eventGen event.Interface,
 	dynamicConfig config.Configuration,
 	informerCacheResolvers engineapi.ConfigmapResolver,
+	mutateReports admissionreports.Writer,
+	generateReports admissionreports.Writer,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
@@ -90,6 +98,8 @@ func NewController(
 		eventGen:               eventGen,
 		configuration:          dynamicConfig,
 		informerCacheResolvers: informerCacheResolvers,
+		mutateReports:          mutateReports,
+		generateReports:        generateReports,
 	}
 	_, _ = urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
@@ -389,10 +399,10 @@ func (c *controller) processUR(ur *kyvernov1beta1.UpdateRequest) error {
 	statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister)
 	switch ur.Spec.GetRequestType() {
 	case kyvernov1beta1.Mutate:
-		ctrl := mutate.NewMutateExistingController(c.client, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, logger)
+		ctrl := mutate.NewMutateExistingController(c.client, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, c.mutateReports, logger)
 		return ctrl.ProcessUR(ur)
 	case kyvernov1beta1.Generate:
-		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger)
+		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, c.generateReports, logger)
 		return ctrl.ProcessUR(ur)
 	}
 	return nil
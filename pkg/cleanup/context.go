@@ -0,0 +1,63 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// loadContext loads the context entries of a cleanup policy for the given target resource,
+// config maps and api calls are resolved with the client the policy executes with
+func loadContext(
+	ctx context.Context,
+	logger logr.Logger,
+	client dclient.Interface,
+	references ReferenceResolver,
+	entries []kyvernov2alpha1.CleanupContextEntry,
+	enginectx enginecontext.Interface,
+	target unstructured.Unstructured,
+) error {
+	for _, entry := range entries {
+		switch {
+		case entry.References != nil:
+			if references == nil {
+				return fmt.Errorf("references lookups are not supported, context entry %s", entry.Name)
+			}
+			referencing, err := references.Referencing(ctx, entry.References.Kind, target)
+			if err != nil {
+				return fmt.Errorf("failed to lookup references for context entry %s: %w", entry.Name, err)
+			}
+			items := make([]interface{}, 0, len(referencing))
+			for _, resource := range referencing {
+				items = append(items, resource.Object)
+			}
+			if err := enginectx.AddVariable(entry.Name, items); err != nil {
+				return fmt.Errorf("failed to add references for context entry %s: %w", entry.Name, err)
+			}
+		case entry.ConfigMap != nil:
+			resolver, err := resolvers.NewClientBasedResolver(client.GetKubeClient())
+			if err != nil {
+				return err
+			}
+			if err := engineapi.LoadConfigMap(ctx, logger, entry.ContextEntry(), enginectx, resolver); err != nil {
+				return err
+			}
+		case entry.APICall != nil:
+			if err := engineapi.LoadAPIData(ctx, logger, entry.ContextEntry(), enginectx, client); err != nil {
+				return err
+			}
+		case entry.Variable != nil:
+			if err := engineapi.LoadVariable(logger, entry.ContextEntry(), enginectx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package cleanup
+
+import (
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// LabelCleanupPolicy holds the name of the cleanup policy a dry run report belongs to
+	LabelCleanupPolicy = "cleanup.kyverno.io/policy"
+	// ValueCleanupController is the managed-by value of dry run reports, it differs from the kyverno
+	// value so that dry run reports are not garbage collected by the reports controller
+	ValueCleanupController = "kyverno-cleanup-controller"
+	// DryRunRule is the rule name used in dry run report results
+	DryRunRule = "cleanup"
+	// MaxDryRunStatusResources is the maximum number of resources listed in the policy status
+	MaxDryRunStatusResources = 100
+	// MaxDryRunReportResults is the maximum number of results stored in a dry run report
+	MaxDryRunReportResults = 1000
+)
+
+// DryRunReportName returns the name of the dry run report of a cleanup policy
+func DryRunReportName(policy kyvernov2alpha1.CleanupPolicyInterface) string {
+	return "cleanup-" + policy.GetName()
+}
+
+// NewDryRunStatus builds the dry run status of a cleanup policy from the resources it selected
+func NewDryRunStatus(resources []unstructured.Unstructured, now time.Time) *kyvernov2alpha1.DryRunStatus {
+	status := &kyvernov2alpha1.DryRunStatus{
+		LastExecutionTime: metav1.NewTime(now),
+		Count:             len(resources),
+	}
+	for i := range resources {
+		if i == MaxDryRunStatusResources {
+			break
+		}
+		status.Resources = append(status.Resources, kyvernov1.ResourceSpec{
+			APIVersion: resources[i].GetAPIVersion(),
+			Kind:       resources[i].GetKind(),
+			Namespace:  resources[i].GetNamespace(),
+			Name:       resources[i].GetName(),
+		})
+	}
+	return status
+}
+
+// NewDryRunReport builds the policy report of a cleanup policy dry run, the report contains one
+// warn result per resource that would have been deleted.
+// A ClusterCleanupPolicy produces a ClusterPolicyReport, a CleanupPolicy produces a PolicyReport.
+func NewDryRunReport(policy kyvernov2alpha1.CleanupPolicyInterface, resources []unstructured.Unstructured, now time.Time) kyvernov1alpha2.ReportInterface {
+	var report kyvernov1alpha2.ReportInterface
+	kind := "CleanupPolicy"
+	if policy.GetNamespace() == "" {
+		report = &policyreportv1alpha2.ClusterPolicyReport{
+			TypeMeta: metav1.TypeMeta{APIVersion: policyreportv1alpha2.SchemeGroupVersion.String(), Kind: "ClusterPolicyReport"},
+		}
+		kind = "ClusterCleanupPolicy"
+	} else {
+		report = &policyreportv1alpha2.PolicyReport{
+			TypeMeta: metav1.TypeMeta{APIVersion: policyreportv1alpha2.SchemeGroupVersion.String(), Kind: "PolicyReport"},
+		}
+	}
+	report.SetName(DryRunReportName(policy))
+	report.SetNamespace(policy.GetNamespace())
+	controllerutils.SetLabel(report, kyvernov1.LabelAppManagedBy, ValueCleanupController)
+	controllerutils.SetLabel(report, LabelCleanupPolicy, policy.GetName())
+	if policy.GetUID() != "" {
+		controllerutils.SetOwner(report, kyvernov2alpha1.SchemeGroupVersion.String(), kind, policy.GetName(), policy.GetUID())
+	}
+	results := make([]policyreportv1alpha2.PolicyReportResult, 0, len(resources))
+	for i := range resources {
+		if i == MaxDryRunReportResults {
+			break
+		}
+		resource := resources[i]
+		results = append(results, policyreportv1alpha2.PolicyReportResult{
+			Source:  "kyverno",
+			Policy:  policy.GetName(),
+			Rule:    DryRunRule,
+			Message: "resource would be deleted",
+			Result:  policyreportv1alpha2.StatusWarn,
+			Resources: []corev1.ObjectReference{{
+				APIVersion: resource.GetAPIVersion(),
+				Kind:       resource.GetKind(),
+				Namespace:  resource.GetNamespace(),
+				Name:       resource.GetName(),
+				UID:        resource.GetUID(),
+			}},
+			Timestamp: metav1.Timestamp{Seconds: now.Unix()},
+		})
+	}
+	reportutils.SetResults(report, results...)
+	return report
+}
@@ -0,0 +1,48 @@
+package cleanup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func pods(count int) []unstructured.Unstructured {
+	var resources []unstructured.Unstructured
+	for i := 0; i < count; i++ {
+		var pod unstructured.Unstructured
+		pod.SetAPIVersion("v1")
+		pod.SetKind("Pod")
+		pod.SetNamespace("default")
+		pod.SetName(fmt.Sprintf("pod-%d", i))
+		resources = append(resources, pod)
+	}
+	return resources
+}
+
+func Test_NewDryRunStatus(t *testing.T) {
+	now := time.Now()
+	status := NewDryRunStatus(pods(MaxDryRunStatusResources+10), now)
+	assert.Equal(t, status.Count, MaxDryRunStatusResources+10)
+	assert.Equal(t, len(status.Resources), MaxDryRunStatusResources)
+	assert.Equal(t, status.Resources[0].Name, "pod-0")
+	assert.Equal(t, status.LastExecutionTime, metav1.NewTime(now))
+}
+
+func Test_NewDryRunReport(t *testing.T) {
+	policy := &kyvernov2alpha1.CleanupPolicy{ObjectMeta: metav1.ObjectMeta{Name: "old-pods", Namespace: "default", UID: "uid"}}
+	report := NewDryRunReport(policy, pods(2), time.Now())
+	policyReport, ok := report.(*policyreportv1alpha2.PolicyReport)
+	assert.Assert(t, ok)
+	assert.Equal(t, policyReport.Name, "cleanup-old-pods")
+	assert.Equal(t, policyReport.Namespace, "default")
+	assert.Equal(t, policyReport.Labels[LabelCleanupPolicy], "old-pods")
+	assert.Equal(t, policyReport.OwnerReferences[0].Kind, "CleanupPolicy")
+	assert.Equal(t, len(policyReport.Results), 2)
+	assert.Equal(t, policyReport.Summary.Warn, 2)
+}
@@ -0,0 +1,232 @@
+package cleanup
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"github.com/kyverno/kyverno/pkg/utils/match"
+	"go.uber.org/multierr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// NamespaceLabelsFunc returns the labels of a namespace
+type NamespaceLabelsFunc = func(namespace string) (map[string]string, error)
+
+// WalkFunc is called for each resource selected by a cleanup policy with the kind, as declared in the policy,
+// it was listed for, returning false stops the walk
+type WalkFunc = func(kind string, resource unstructured.Unstructured) bool
+
+// Evaluate returns the resources selected by the match, exclude and conditions of a cleanup policy.
+// Resources that failed to be evaluated are skipped and the corresponding errors are returned
+// along with the selected resources.
+func Evaluate(
+	ctx context.Context,
+	logger logr.Logger,
+	client dclient.Interface,
+	nsLabels NamespaceLabelsFunc,
+	references ReferenceResolver,
+	policy kyvernov2alpha1.CleanupPolicyInterface,
+	cfg config.Configuration,
+) ([]unstructured.Unstructured, error) {
+	var selected []unstructured.Unstructured
+	err := Walk(ctx, logger, client, nsLabels, references, policy, cfg, nil, func(_ string, resource unstructured.Unstructured) bool {
+		selected = append(selected, resource)
+		return true
+	})
+	return selected, err
+}
+
+// Walk calls fn for each resource selected by the match, exclude and conditions of a cleanup policy.
+// Kinds are processed in order and resources are listed by pages of the policy page size.
+// Resources that failed to be evaluated are skipped and the corresponding errors are returned.
+// When resumeAfter is set the walk starts with the resources listed after it, the API server lists
+// resources in the order of their keys.
+// The references resolver is used by the references lookups of the policy context.
+func Walk(
+	ctx context.Context,
+	logger logr.Logger,
+	client dclient.Interface,
+	nsLabels NamespaceLabelsFunc,
+	references ReferenceResolver,
+	policy kyvernov2alpha1.CleanupPolicyInterface,
+	cfg config.Configuration,
+	resumeAfter *kyvernov2alpha1.CleanupResumePosition,
+	fn WalkFunc,
+) error {
+	spec := policy.GetSpec()
+	kinds := sets.List(sets.New(spec.MatchResources.GetKinds()...))
+	var pageSize int64
+	if spec.PageSize != nil {
+		pageSize = *spec.PageSize
+	}
+	debug := logger.V(4)
+	var errs []error
+	for _, kind := range kinds {
+		if resumeAfter != nil && kind < resumeAfter.Kind {
+			continue
+		}
+		debug := debug.WithValues("kind", kind)
+		debug.Info("processing...")
+		options := metav1.ListOptions{Limit: pageSize}
+		for {
+			list, err := client.ListResourceWithOptions(ctx, "", kind, policy.GetNamespace(), options)
+			if err != nil {
+				debug.Error(err, "failed to list resources")
+				errs = append(errs, err)
+				break
+			}
+			for i := range list.Items {
+				resource := list.Items[i]
+				if resumeAfter != nil && kind == resumeAfter.Kind && ResourceKey(resource) <= resumeAfter.Key {
+					continue
+				}
+				selected, err := selectResource(ctx, logger, client, nsLabels, references, policy, cfg, resource)
+				if err != nil {
+					errs = append(errs, err)
+				}
+				if !selected {
+					continue
+				}
+				if !fn(kind, resource) {
+					return multierr.Combine(errs...)
+				}
+			}
+			if options.Continue = list.GetContinue(); options.Continue == "" {
+				break
+			}
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+// ResourceKey returns the key of a resource, namespace/name or name for cluster wide resources
+func ResourceKey(resource unstructured.Unstructured) string {
+	if resource.GetNamespace() == "" {
+		return resource.GetName()
+	}
+	return resource.GetNamespace() + "/" + resource.GetName()
+}
+
+// UserInfo returns the user info of the service account a cleanup policy executes with,
+// the second returned value is false when the policy doesn't declare a service account
+func UserInfo(policy kyvernov2alpha1.CleanupPolicyInterface) (authenticationv1.UserInfo, bool) {
+	name := policy.GetSpec().ServiceAccountName
+	if name == "" || policy.GetNamespace() == "" {
+		return authenticationv1.UserInfo{}, false
+	}
+	return kyvernov2alpha1.ServiceAccountUserInfo(policy.GetNamespace(), name), true
+}
+
+// requestInfo returns the request info used to match the user info clauses of a cleanup policy
+func requestInfo(policy kyvernov2alpha1.CleanupPolicyInterface) kyvernov1beta1.RequestInfo {
+	userInfo, _ := UserInfo(policy)
+	return kyvernov1beta1.RequestInfo{AdmissionUserInfo: userInfo}
+}
+
+// selectResource checks if a resource is selected by the match, exclude and conditions of a cleanup policy
+func selectResource(
+	ctx context.Context,
+	logger logr.Logger,
+	client dclient.Interface,
+	nsLabels NamespaceLabelsFunc,
+	references ReferenceResolver,
+	policy kyvernov2alpha1.CleanupPolicyInterface,
+	cfg config.Configuration,
+	resource unstructured.Unstructured,
+) (bool, error) {
+	// terminating resources are already being deleted, they don't count towards the deletions limit
+	if resource.GetDeletionTimestamp() != nil {
+		return false, nil
+	}
+	if controllerutils.IsManagedByKyverno(&resource) {
+		return false, nil
+	}
+	spec := policy.GetSpec()
+	namespace := resource.GetNamespace()
+	debug := logger.V(4).WithValues("kind", resource.GetKind(), "name", resource.GetName(), "namespace", namespace)
+	var labels map[string]string
+	if namespace != "" {
+		var err error
+		labels, err = nsLabels(namespace)
+		if err != nil {
+			debug.Error(err, "failed to get namespace labels")
+			return false, err
+		}
+	}
+	// match namespaces
+	if err := match.CheckNamespace(policy.GetNamespace(), resource); err != nil {
+		debug.Info("resource namespace didn't match policy namespace", "result", err)
+		return false, nil
+	}
+	// match resource with match/exclude clause
+	matched := match.CheckMatchesResources(
+		resource,
+		spec.MatchResources,
+		labels,
+		nil,
+		"",
+		requestInfo(policy),
+		nil,
+	)
+	if matched != nil {
+		debug.Info("resource/match didn't match", "result", matched)
+		return false, nil
+	}
+	if spec.ExcludeResources != nil {
+		excluded := match.CheckMatchesResources(
+			resource,
+			*spec.ExcludeResources,
+			labels,
+			nil,
+			"",
+			requestInfo(policy),
+			nil,
+		)
+		if excluded == nil {
+			debug.Info("resource/exclude matched")
+			return false, nil
+		} else {
+			debug.Info("resource/exclude didn't match", "result", excluded)
+		}
+	}
+	// check conditions
+	if spec.Conditions != nil {
+		enginectx := enginecontext.NewContext()
+		if err := enginectx.AddTargetResource(resource.Object); err != nil {
+			debug.Error(err, "failed to add resource in context")
+			return false, err
+		}
+		if err := enginectx.AddNamespace(resource.GetNamespace()); err != nil {
+			debug.Error(err, "failed to add namespace in context")
+			return false, err
+		}
+		if err := enginectx.AddImageInfos(&resource, cfg); err != nil {
+			debug.Error(err, "failed to add image infos in context")
+			return false, err
+		}
+		if err := loadContext(ctx, logger, client, references, spec.Context, enginectx, resource); err != nil {
+			debug.Error(err, "failed to load context")
+			return false, err
+		}
+		passed, err := variables.CheckAnyAllConditions(logger, enginectx, *spec.Conditions)
+		if err != nil {
+			debug.Error(err, "failed to check condition")
+			return false, err
+		}
+		if !passed {
+			debug.Info("conditions did not pass")
+			return false, nil
+		}
+	}
+	return true, nil
+}
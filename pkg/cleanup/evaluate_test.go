@@ -0,0 +1,74 @@
+package cleanup
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_selectResource_Terminating(t *testing.T) {
+	var resource unstructured.Unstructured
+	resource.SetAPIVersion("v1")
+	resource.SetKind("Pod")
+	resource.SetName("pod")
+	now := metav1.Now()
+	resource.SetDeletionTimestamp(&now)
+	selected, err := selectResource(context.TODO(), logr.Discard(), nil, nil, nil, &kyvernov2alpha1.ClusterCleanupPolicy{}, nil, resource)
+	assert.NilError(t, err)
+	assert.Equal(t, selected, false)
+}
+
+func Test_Walk_ResumeAfter(t *testing.T) {
+	newConfigMap := func(namespace, name string) *unstructured.Unstructured {
+		var configMap unstructured.Unstructured
+		configMap.SetAPIVersion("v1")
+		configMap.SetKind("ConfigMap")
+		configMap.SetNamespace(namespace)
+		configMap.SetName(name)
+		return &configMap
+	}
+	client, err := dclient.NewFakeClient(
+		runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Version: "v1", Resource: "configmaps"}: "ConfigMapList"},
+		newConfigMap("a", "one"),
+		newConfigMap("a", "two"),
+		newConfigMap("b", "one"),
+	)
+	assert.NilError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	policy := &kyvernov2alpha1.ClusterCleanupPolicy{
+		Spec: kyvernov2alpha1.CleanupPolicySpec{
+			MatchResources: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}}}},
+			},
+		},
+	}
+	nsLabels := func(string) (map[string]string, error) { return nil, nil }
+	walk := func(resumeAfter *kyvernov2alpha1.CleanupResumePosition) []string {
+		var keys []string
+		err := Walk(context.TODO(), logr.Discard(), client, nsLabels, nil, policy, nil, resumeAfter, func(kind string, resource unstructured.Unstructured) bool {
+			assert.Equal(t, kind, "ConfigMap")
+			keys = append(keys, ResourceKey(resource))
+			return true
+		})
+		assert.NilError(t, err)
+		sort.Strings(keys)
+		return keys
+	}
+	assert.DeepEqual(t, walk(nil), []string{"a/one", "a/two", "b/one"})
+	// resources listed up to the resume position are skipped
+	assert.DeepEqual(t, walk(&kyvernov2alpha1.CleanupResumePosition{Kind: "ConfigMap", Key: "a/two"}), []string{"b/one"})
+	// kinds listed before the resume position are skipped
+	assert.Assert(t, len(walk(&kyvernov2alpha1.CleanupResumePosition{Kind: "Secret", Key: "a/one"})) == 0)
+}
@@ -0,0 +1,373 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ReferencesIndex is the name of the informers index holding the references of each resource
+const ReferencesIndex = "references"
+
+// ReferenceResolver returns the resources of a kind referencing a target resource
+type ReferenceResolver interface {
+	Referencing(ctx context.Context, kind string, target unstructured.Unstructured) ([]unstructured.Unstructured, error)
+}
+
+// podSpecPaths are the paths of the pod spec in pods and pod controllers
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// referenceKey returns the key identifying a referenced resource by kind, namespace and name
+func referenceKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// ownerKey returns the key identifying an owner resource by uid
+func ownerKey(uid string) string {
+	return "uid:" + uid
+}
+
+// TargetKeys returns the keys the references to a resource are indexed with
+func TargetKeys(target unstructured.Unstructured) []string {
+	keys := []string{referenceKey(target.GetKind(), target.GetNamespace(), target.GetName())}
+	if uid := target.GetUID(); uid != "" {
+		keys = append(keys, ownerKey(string(uid)))
+	}
+	return keys
+}
+
+// References returns the keys of the resources referenced by a resource,
+// through owner references and pod spec config maps, secrets, persistent volume claims and service accounts
+func References(resource unstructured.Unstructured) ([]string, error) {
+	var keys []string
+	for _, owner := range resource.GetOwnerReferences() {
+		keys = append(keys, ownerKey(string(owner.UID)))
+	}
+	path, ok := podSpecPaths[resource.GetKind()]
+	if !ok {
+		return keys, nil
+	}
+	obj, found, err := unstructured.NestedMap(resource.Object, path...)
+	if err != nil || !found {
+		return keys, err
+	}
+	var spec corev1.PodSpec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &spec); err != nil {
+		return keys, fmt.Errorf("failed to convert pod spec of %s/%s: %w", resource.GetNamespace(), resource.GetName(), err)
+	}
+	namespace := resource.GetNamespace()
+	add := func(kind, name string) {
+		if name != "" {
+			keys = append(keys, referenceKey(kind, namespace, name))
+		}
+	}
+	add("ServiceAccount", spec.ServiceAccountName)
+	for _, secret := range spec.ImagePullSecrets {
+		add("Secret", secret.Name)
+	}
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			add("ConfigMap", volume.ConfigMap.Name)
+		}
+		if volume.Secret != nil {
+			add("Secret", volume.Secret.SecretName)
+		}
+		if volume.PersistentVolumeClaim != nil {
+			add("PersistentVolumeClaim", volume.PersistentVolumeClaim.ClaimName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					add("ConfigMap", source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					add("Secret", source.Secret.Name)
+				}
+			}
+		}
+	}
+	envs := func(envFrom []corev1.EnvFromSource, env []corev1.EnvVar) {
+		for _, source := range envFrom {
+			if source.ConfigMapRef != nil {
+				add("ConfigMap", source.ConfigMapRef.Name)
+			}
+			if source.SecretRef != nil {
+				add("Secret", source.SecretRef.Name)
+			}
+		}
+		for _, variable := range env {
+			if variable.ValueFrom == nil {
+				continue
+			}
+			if variable.ValueFrom.ConfigMapKeyRef != nil {
+				add("ConfigMap", variable.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if variable.ValueFrom.SecretKeyRef != nil {
+				add("Secret", variable.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	for _, container := range spec.InitContainers {
+		envs(container.EnvFrom, container.Env)
+	}
+	for _, container := range spec.Containers {
+		envs(container.EnvFrom, container.Env)
+	}
+	for _, container := range spec.EphemeralContainers {
+		envs(container.EnvFrom, container.Env)
+	}
+	return keys, nil
+}
+
+// isReferencing returns true if the resource references one of the given target keys
+func isReferencing(resource unstructured.Unstructured, targetKeys []string) (bool, error) {
+	keys, err := References(resource)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range keys {
+		for _, targetKey := range targetKeys {
+			if key == targetKey {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+type clientReferenceResolver struct {
+	client dclient.Interface
+}
+
+// NewClientReferenceResolver returns a reference resolver listing the referencing resources with the given client
+func NewClientReferenceResolver(client dclient.Interface) ReferenceResolver {
+	return &clientReferenceResolver{
+		client: client,
+	}
+}
+
+func (r *clientReferenceResolver) Referencing(ctx context.Context, kind string, target unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	list, err := r.client.ListResource(ctx, "", kind, target.GetNamespace(), nil)
+	if err != nil {
+		return nil, err
+	}
+	targetKeys := TargetKeys(target)
+	referencing := []unstructured.Unstructured{}
+	for _, resource := range list.Items {
+		if ok, err := isReferencing(resource, targetKeys); err != nil {
+			return nil, err
+		} else if ok {
+			referencing = append(referencing, resource)
+		}
+	}
+	return referencing, nil
+}
+
+type informerReferenceResolver struct {
+	ctx    context.Context
+	client dclient.Interface
+	resync time.Duration
+
+	lock      sync.Mutex
+	informers map[schema.GroupVersionResource]*referenceInformer
+}
+
+// informerSyncTimeout is how long a lookup waits for the cache sync of an informer before failing
+const informerSyncTimeout = 30 * time.Second
+
+// referenceInformer is an indexed informer, synced is closed once its cache has synced and failed is
+// closed with err set when the informer was stopped because the resources are forbidden to watch
+type referenceInformer struct {
+	informer cache.SharedIndexInformer
+	synced   chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+	failed   chan struct{}
+	err      error
+}
+
+// fail stops the informer and records the error returned to the lookups waiting for it
+func (i *referenceInformer) fail(err error) {
+	i.stopOnce.Do(func() {
+		i.err = err
+		close(i.failed)
+		close(i.stop)
+	})
+}
+
+// NewInformerReferenceResolver returns a reference resolver backed by dynamic informers indexing the
+// references of each resource. Informers are started on demand, the first time a kind is looked up,
+// with the permissions of the given client, and run until the given context is done. An informer that
+// cannot watch its resources or sync in time is stopped, it is started again by the next lookup.
+func NewInformerReferenceResolver(ctx context.Context, client dclient.Interface, resync time.Duration) ReferenceResolver {
+	return &informerReferenceResolver{
+		ctx:       ctx,
+		client:    client,
+		resync:    resync,
+		informers: map[schema.GroupVersionResource]*referenceInformer{},
+	}
+}
+
+func (r *informerReferenceResolver) Referencing(ctx context.Context, kind string, target unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	informer, err := r.informer(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]struct{}{}
+	referencing := []unstructured.Unstructured{}
+	for _, key := range TargetKeys(target) {
+		objs, err := informer.GetIndexer().ByIndex(ReferencesIndex, key)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			resource, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			// owner references are not namespace bound, keep resources from the target namespace only
+			if target.GetNamespace() != "" && resource.GetNamespace() != target.GetNamespace() {
+				continue
+			}
+			id := string(resource.GetUID())
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			referencing = append(referencing, *resource.DeepCopy())
+		}
+	}
+	return referencing, nil
+}
+
+// informer returns the indexed informer of a kind, starting it if needed
+func (r *informerReferenceResolver) informer(ctx context.Context, kind string) (cache.SharedIndexInformer, error) {
+	gvr, err := r.client.Discovery().GetGVRFromKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if gvr.Empty() {
+		return nil, fmt.Errorf("failed to get the Group Version Resource for kind %s", kind)
+	}
+	informer := r.getOrStart(gvr)
+	// the lock is not held while waiting, lookups of other kinds are not blocked by a syncing informer
+	timeout := time.NewTimer(informerSyncTimeout)
+	defer timeout.Stop()
+	select {
+	case <-informer.synced:
+		return informer.informer, nil
+	case <-informer.failed:
+		return nil, fmt.Errorf("failed to watch %s: %w", strings.ToLower(kind), informer.err)
+	case <-timeout.C:
+		r.stop(gvr, informer, fmt.Errorf("cache sync timed out after %s", informerSyncTimeout))
+	case <-ctx.Done():
+	case <-r.ctx.Done():
+	}
+	return nil, fmt.Errorf("failed to wait for %s cache sync", strings.ToLower(kind))
+}
+
+// stop stops a failed informer and forgets it, the next lookup of its kind starts a new one
+func (r *informerReferenceResolver) stop(gvr schema.GroupVersionResource, informer *referenceInformer, err error) {
+	informer.fail(err)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.informers[gvr] == informer {
+		delete(r.informers, gvr)
+	}
+}
+
+// getOrStart returns the informer of a resource, starting it and waiting for its cache sync in the background if needed
+func (r *informerReferenceResolver) getOrStart(gvr schema.GroupVersionResource) *referenceInformer {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if informer, ok := r.informers[gvr]; ok {
+		return informer
+	}
+	informer := &referenceInformer{
+		informer: dynamicinformer.NewFilteredDynamicInformer(
+			r.client.GetDynamicInterface(),
+			gvr,
+			"",
+			r.resync,
+			cache.Indexers{ReferencesIndex: referencesIndexFunc},
+			nil,
+		).Informer(),
+		synced: make(chan struct{}),
+		stop:   make(chan struct{}),
+		failed: make(chan struct{}),
+	}
+	// the informer would retry forever when the resources are forbidden, lookups report the error instead
+	_ = informer.informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			r.stop(gvr, informer, err)
+		}
+	})
+	go func() {
+		select {
+		case <-r.ctx.Done():
+			informer.fail(r.ctx.Err())
+		case <-informer.stop:
+		}
+	}()
+	go informer.informer.Run(informer.stop)
+	go func() {
+		if cache.WaitForCacheSync(informer.stop, informer.informer.HasSynced) {
+			close(informer.synced)
+		}
+	}()
+	r.informers[gvr] = informer
+	return informer
+}
+
+func referencesIndexFunc(obj interface{}) ([]string, error) {
+	resource, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, nil
+	}
+	return References(*resource)
+}
+
+type authorizedReferenceResolver struct {
+	inner     ReferenceResolver
+	authorize func(ctx context.Context, kind, namespace string) (bool, error)
+}
+
+// NewAuthorizedReferenceResolver returns a reference resolver checking the referencing kind can be listed
+// in the target namespace before delegating the lookup to the given resolver
+func NewAuthorizedReferenceResolver(inner ReferenceResolver, authorize func(ctx context.Context, kind, namespace string) (bool, error)) ReferenceResolver {
+	return &authorizedReferenceResolver{
+		inner:     inner,
+		authorize: authorize,
+	}
+}
+
+func (r *authorizedReferenceResolver) Referencing(ctx context.Context, kind string, target unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	allowed, err := r.authorize(ctx, kind, target.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, fmt.Errorf("not allowed to list kind %s in namespace %s", kind, target.GetNamespace())
+	}
+	return r.inner.Referencing(ctx, kind, target)
+}
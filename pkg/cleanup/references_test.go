@@ -0,0 +1,91 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func Test_References(t *testing.T) {
+	var deployment unstructured.Unstructured
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetNamespace("default")
+	deployment.SetName("app")
+	deployment.SetOwnerReferences([]metav1.OwnerReference{{UID: types.UID("owner")}})
+	deployment.Object["spec"] = map[string]interface{}{
+		"template": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"serviceAccountName": "app",
+				"imagePullSecrets":   []interface{}{map[string]interface{}{"name": "registry"}},
+				"containers": []interface{}{map[string]interface{}{
+					"name":    "app",
+					"envFrom": []interface{}{map[string]interface{}{"configMapRef": map[string]interface{}{"name": "env"}}},
+					"env": []interface{}{map[string]interface{}{
+						"name":      "PASSWORD",
+						"valueFrom": map[string]interface{}{"secretKeyRef": map[string]interface{}{"name": "password", "key": "value"}},
+					}},
+				}},
+				"volumes": []interface{}{
+					map[string]interface{}{"name": "data", "persistentVolumeClaim": map[string]interface{}{"claimName": "data"}},
+					map[string]interface{}{"name": "config", "configMap": map[string]interface{}{"name": "config"}},
+				},
+			},
+		},
+	}
+	keys, err := References(deployment)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []string{
+		"uid:owner",
+		"ServiceAccount/default/app",
+		"Secret/default/registry",
+		"PersistentVolumeClaim/default/data",
+		"ConfigMap/default/config",
+		"ConfigMap/default/env",
+		"Secret/default/password",
+	})
+}
+
+func Test_TargetKeys(t *testing.T) {
+	var configMap unstructured.Unstructured
+	configMap.SetKind("ConfigMap")
+	configMap.SetNamespace("default")
+	configMap.SetName("config")
+	configMap.SetUID(types.UID("uid"))
+	assert.DeepEqual(t, TargetKeys(configMap), []string{"ConfigMap/default/config", "uid:uid"})
+}
+
+func Test_informerReferenceResolver_Forbidden(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gvr: "ConfigMapList"})
+	dynamicClient.PrependReactor("list", "configmaps", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(gvr.GroupResource(), "", errors.New("forbidden"))
+	})
+	client := dclient.NewClientWithDiscovery(dynamicClient, kubefake.NewSimpleClientset(), dclient.NewFakeDiscoveryClient(nil))
+	resolver := NewInformerReferenceResolver(ctx, client, time.Minute).(*informerReferenceResolver)
+	target := unstructured.Unstructured{}
+	target.SetNamespace("default")
+	target.SetName("target")
+	// the lookup fails instead of waiting for a cache sync that never happens
+	_, err := resolver.Referencing(ctx, "ConfigMap", target)
+	assert.Assert(t, apierrors.IsForbidden(err), err)
+	// the failed informer is forgotten, the next lookup starts a new one
+	resolver.lock.Lock()
+	defer resolver.lock.Unlock()
+	assert.Equal(t, len(resolver.informers), 0)
+}
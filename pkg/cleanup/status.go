@@ -0,0 +1,43 @@
+package cleanup
+
+import (
+	"context"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// UpdateStatus updates the status of a cleanup policy, the latest version of the policy is fetched and the
+// update is retried on conflicts because both the scheduler and the policy execution update the status
+func UpdateStatus(ctx context.Context, client versioned.Interface, policy kyvernov2alpha1.CleanupPolicyInterface, build func(*kyvernov2alpha1.CleanupPolicyStatus)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		switch policy.(type) {
+		case *kyvernov2alpha1.ClusterCleanupPolicy:
+			policies := client.KyvernoV2alpha1().ClusterCleanupPolicies()
+			latest, err := policies.Get(ctx, policy.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			_, err = controllerutils.UpdateStatus(ctx, latest, policies, func(policy *kyvernov2alpha1.ClusterCleanupPolicy) error {
+				build(&policy.Status)
+				return nil
+			})
+			return err
+		case *kyvernov2alpha1.CleanupPolicy:
+			policies := client.KyvernoV2alpha1().CleanupPolicies(policy.GetNamespace())
+			latest, err := policies.Get(ctx, policy.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			_, err = controllerutils.UpdateStatus(ctx, latest, policies, func(policy *kyvernov2alpha1.CleanupPolicy) error {
+				build(&policy.Status)
+				return nil
+			})
+			return err
+		}
+		return nil
+	})
+}
@@ -0,0 +1,168 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	"context"
+	"time"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	scheme "github.com/kyverno/kyverno/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ComplianceReportsGetter has a method to return a ComplianceReportInterface.
+// A group's client should implement this interface.
+type ComplianceReportsGetter interface {
+	ComplianceReports() ComplianceReportInterface
+}
+
+// ComplianceReportInterface has methods to work with ComplianceReport resources.
+type ComplianceReportInterface interface {
+	Create(ctx context.Context, complianceReport *v2alpha1.ComplianceReport, opts v1.CreateOptions) (*v2alpha1.ComplianceReport, error)
+	Update(ctx context.Context, complianceReport *v2alpha1.ComplianceReport, opts v1.UpdateOptions) (*v2alpha1.ComplianceReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v2alpha1.ComplianceReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v2alpha1.ComplianceReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ComplianceReport, err error)
+	ComplianceReportExpansion
+}
+
+// complianceReports implements ComplianceReportInterface
+type complianceReports struct {
+	client rest.Interface
+}
+
+// newComplianceReports returns a ComplianceReports
+func newComplianceReports(c *KyvernoV2alpha1Client) *complianceReports {
+	return &complianceReports{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the complianceReport, and returns the corresponding complianceReport object, and an error if there is any.
+func (c *complianceReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ComplianceReport, err error) {
+	result = &v2alpha1.ComplianceReport{}
+	err = c.client.Get().
+		Resource("compliancereports").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ComplianceReports that match those selectors.
+func (c *complianceReports) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ComplianceReportList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v2alpha1.ComplianceReportList{}
+	err = c.client.Get().
+		Resource("compliancereports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested complianceReports.
+func (c *complianceReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("compliancereports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a complianceReport and creates it.  Returns the server's representation of the complianceReport, and an error, if there is any.
+func (c *complianceReports) Create(ctx context.Context, complianceReport *v2alpha1.ComplianceReport, opts v1.CreateOptions) (result *v2alpha1.ComplianceReport, err error) {
+	result = &v2alpha1.ComplianceReport{}
+	err = c.client.Post().
+		Resource("compliancereports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(complianceReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a complianceReport and updates it. Returns the server's representation of the complianceReport, and an error, if there is any.
+func (c *complianceReports) Update(ctx context.Context, complianceReport *v2alpha1.ComplianceReport, opts v1.UpdateOptions) (result *v2alpha1.ComplianceReport, err error) {
+	result = &v2alpha1.ComplianceReport{}
+	err = c.client.Put().
+		Resource("compliancereports").
+		Name(complianceReport.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(complianceReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the complianceReport and deletes it. Returns an error if one occurs.
+func (c *complianceReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("compliancereports").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *complianceReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("compliancereports").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched complianceReport.
+func (c *complianceReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ComplianceReport, err error) {
+	result = &v2alpha1.ComplianceReport{}
+	err = c.client.Patch(pt).
+		Resource("compliancereports").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeComplianceFrameworks implements ComplianceFrameworkInterface
+type FakeComplianceFrameworks struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var complianceframeworksResource = schema.GroupVersionResource{Group: "kyverno.io", Version: "v2alpha1", Resource: "complianceframeworks"}
+
+var complianceframeworksKind = schema.GroupVersionKind{Group: "kyverno.io", Version: "v2alpha1", Kind: "ComplianceFramework"}
+
+// Get takes name of the complianceFramework, and returns the corresponding complianceFramework object, and an error if there is any.
+func (c *FakeComplianceFrameworks) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ComplianceFramework, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(complianceframeworksResource, name), &v2alpha1.ComplianceFramework{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceFramework), err
+}
+
+// List takes label and field selectors, and returns the list of ComplianceFrameworks that match those selectors.
+func (c *FakeComplianceFrameworks) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ComplianceFrameworkList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(complianceframeworksResource, complianceframeworksKind, opts), &v2alpha1.ComplianceFrameworkList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.ComplianceFrameworkList{ListMeta: obj.(*v2alpha1.ComplianceFrameworkList).ListMeta}
+	for _, item := range obj.(*v2alpha1.ComplianceFrameworkList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested complianceFrameworks.
+func (c *FakeComplianceFrameworks) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(complianceframeworksResource, opts))
+}
+
+// Create takes the representation of a complianceFramework and creates it.  Returns the server's representation of the complianceFramework, and an error, if there is any.
+func (c *FakeComplianceFrameworks) Create(ctx context.Context, complianceFramework *v2alpha1.ComplianceFramework, opts v1.CreateOptions) (result *v2alpha1.ComplianceFramework, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(complianceframeworksResource, complianceFramework), &v2alpha1.ComplianceFramework{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceFramework), err
+}
+
+// Update takes the representation of a complianceFramework and updates it. Returns the server's representation of the complianceFramework, and an error, if there is any.
+func (c *FakeComplianceFrameworks) Update(ctx context.Context, complianceFramework *v2alpha1.ComplianceFramework, opts v1.UpdateOptions) (result *v2alpha1.ComplianceFramework, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(complianceframeworksResource, complianceFramework), &v2alpha1.ComplianceFramework{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceFramework), err
+}
+
+// Delete takes name of the complianceFramework and deletes it. Returns an error if one occurs.
+func (c *FakeComplianceFrameworks) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(complianceframeworksResource, name, opts), &v2alpha1.ComplianceFramework{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeComplianceFrameworks) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(complianceframeworksResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.ComplianceFrameworkList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched complianceFramework.
+func (c *FakeComplianceFrameworks) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ComplianceFramework, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(complianceframeworksResource, name, pt, data, subresources...), &v2alpha1.ComplianceFramework{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceFramework), err
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeComplianceReports implements ComplianceReportInterface
+type FakeComplianceReports struct {
+	Fake *FakeKyvernoV2alpha1
+}
+
+var compliancereportsResource = schema.GroupVersionResource{Group: "kyverno.io", Version: "v2alpha1", Resource: "compliancereports"}
+
+var compliancereportsKind = schema.GroupVersionKind{Group: "kyverno.io", Version: "v2alpha1", Kind: "ComplianceReport"}
+
+// Get takes name of the complianceReport, and returns the corresponding complianceReport object, and an error if there is any.
+func (c *FakeComplianceReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v2alpha1.ComplianceReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(compliancereportsResource, name), &v2alpha1.ComplianceReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceReport), err
+}
+
+// List takes label and field selectors, and returns the list of ComplianceReports that match those selectors.
+func (c *FakeComplianceReports) List(ctx context.Context, opts v1.ListOptions) (result *v2alpha1.ComplianceReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(compliancereportsResource, compliancereportsKind, opts), &v2alpha1.ComplianceReportList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v2alpha1.ComplianceReportList{ListMeta: obj.(*v2alpha1.ComplianceReportList).ListMeta}
+	for _, item := range obj.(*v2alpha1.ComplianceReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested complianceReports.
+func (c *FakeComplianceReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(compliancereportsResource, opts))
+}
+
+// Create takes the representation of a complianceReport and creates it.  Returns the server's representation of the complianceReport, and an error, if there is any.
+func (c *FakeComplianceReports) Create(ctx context.Context, complianceReport *v2alpha1.ComplianceReport, opts v1.CreateOptions) (result *v2alpha1.ComplianceReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(compliancereportsResource, complianceReport), &v2alpha1.ComplianceReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceReport), err
+}
+
+// Update takes the representation of a complianceReport and updates it. Returns the server's representation of the complianceReport, and an error, if there is any.
+func (c *FakeComplianceReports) Update(ctx context.Context, complianceReport *v2alpha1.ComplianceReport, opts v1.UpdateOptions) (result *v2alpha1.ComplianceReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(compliancereportsResource, complianceReport), &v2alpha1.ComplianceReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceReport), err
+}
+
+// Delete takes name of the complianceReport and deletes it. Returns an error if one occurs.
+func (c *FakeComplianceReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(compliancereportsResource, name, opts), &v2alpha1.ComplianceReport{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeComplianceReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(compliancereportsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v2alpha1.ComplianceReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched complianceReport.
+func (c *FakeComplianceReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v2alpha1.ComplianceReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(compliancereportsResource, name, pt, data, subresources...), &v2alpha1.ComplianceReport{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2alpha1.ComplianceReport), err
+}
@@ -36,6 +36,14 @@ func (c *FakeKyvernoV2alpha1) ClusterCleanupPolicies() v2alpha1.ClusterCleanupPo
 	return &FakeClusterCleanupPolicies{c}
 }
 
+func (c *FakeKyvernoV2alpha1) ComplianceFrameworks() v2alpha1.ComplianceFrameworkInterface {
+	return &FakeComplianceFrameworks{c}
+}
+
+func (c *FakeKyvernoV2alpha1) ComplianceReports() v2alpha1.ComplianceReportInterface {
+	return &FakeComplianceReports{c}
+}
+
 func (c *FakeKyvernoV2alpha1) PolicyExceptions(namespace string) v2alpha1.PolicyExceptionInterface {
 	return &FakePolicyExceptions{c, namespace}
 }
@@ -22,4 +22,8 @@ type CleanupPolicyExpansion interface{}
 
 type ClusterCleanupPolicyExpansion interface{}
 
+type ComplianceFrameworkExpansion interface{}
+
+type ComplianceReportExpansion interface{}
+
 type PolicyExceptionExpansion interface{}
@@ -30,6 +30,8 @@ type KyvernoV2alpha1Interface interface {
 	RESTClient() rest.Interface
 	CleanupPoliciesGetter
 	ClusterCleanupPoliciesGetter
+	ComplianceFrameworksGetter
+	ComplianceReportsGetter
 	PolicyExceptionsGetter
 }
 
@@ -46,6 +48,14 @@ func (c *KyvernoV2alpha1Client) ClusterCleanupPolicies() ClusterCleanupPolicyInt
 	return newClusterCleanupPolicies(c)
 }
 
+func (c *KyvernoV2alpha1Client) ComplianceFrameworks() ComplianceFrameworkInterface {
+	return newComplianceFrameworks(c)
+}
+
+func (c *KyvernoV2alpha1Client) ComplianceReports() ComplianceReportInterface {
+	return newComplianceReports(c)
+}
+
 func (c *KyvernoV2alpha1Client) PolicyExceptions(namespace string) PolicyExceptionInterface {
 	return newPolicyExceptions(c, namespace)
 }
@@ -28,6 +28,10 @@ type Interface interface {
 	CleanupPolicies() CleanupPolicyInformer
 	// ClusterCleanupPolicies returns a ClusterCleanupPolicyInformer.
 	ClusterCleanupPolicies() ClusterCleanupPolicyInformer
+	// ComplianceFrameworks returns a ComplianceFrameworkInformer.
+	ComplianceFrameworks() ComplianceFrameworkInformer
+	// ComplianceReports returns a ComplianceReportInformer.
+	ComplianceReports() ComplianceReportInformer
 	// PolicyExceptions returns a PolicyExceptionInformer.
 	PolicyExceptions() PolicyExceptionInformer
 }
@@ -53,6 +57,16 @@ func (v *version) ClusterCleanupPolicies() ClusterCleanupPolicyInformer {
 	return &clusterCleanupPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ComplianceFrameworks returns a ComplianceFrameworkInformer.
+func (v *version) ComplianceFrameworks() ComplianceFrameworkInformer {
+	return &complianceFrameworkInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ComplianceReports returns a ComplianceReportInformer.
+func (v *version) ComplianceReports() ComplianceReportInformer {
+	return &complianceReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // PolicyExceptions returns a PolicyExceptionInformer.
 func (v *version) PolicyExceptions() PolicyExceptionInformer {
 	return &policyExceptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
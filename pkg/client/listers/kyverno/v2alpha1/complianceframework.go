@@ -0,0 +1,68 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v2alpha1
+
+import (
+	v2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ComplianceFrameworkLister helps list ComplianceFrameworks.
+// All objects returned here must be treated as read-only.
+type ComplianceFrameworkLister interface {
+	// List lists all ComplianceFrameworks in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v2alpha1.ComplianceFramework, err error)
+	// Get retrieves the ComplianceFramework from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v2alpha1.ComplianceFramework, error)
+	ComplianceFrameworkListerExpansion
+}
+
+// complianceFrameworkLister implements the ComplianceFrameworkLister interface.
+type complianceFrameworkLister struct {
+	indexer cache.Indexer
+}
+
+// NewComplianceFrameworkLister returns a new ComplianceFrameworkLister.
+func NewComplianceFrameworkLister(indexer cache.Indexer) ComplianceFrameworkLister {
+	return &complianceFrameworkLister{indexer: indexer}
+}
+
+// List lists all ComplianceFrameworks in the indexer.
+func (s *complianceFrameworkLister) List(selector labels.Selector) (ret []*v2alpha1.ComplianceFramework, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v2alpha1.ComplianceFramework))
+	})
+	return ret, err
+}
+
+// Get retrieves the ComplianceFramework from the index for a given name.
+func (s *complianceFrameworkLister) Get(name string) (*v2alpha1.ComplianceFramework, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v2alpha1.Resource("complianceframework"), name)
+	}
+	return obj.(*v2alpha1.ComplianceFramework), nil
+}
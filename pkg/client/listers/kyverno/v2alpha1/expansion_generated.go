@@ -30,6 +30,14 @@ type CleanupPolicyNamespaceListerExpansion interface{}
 // ClusterCleanupPolicyLister.
 type ClusterCleanupPolicyListerExpansion interface{}
 
+// ComplianceFrameworkListerExpansion allows custom methods to be added to
+// ComplianceFrameworkLister.
+type ComplianceFrameworkListerExpansion interface{}
+
+// ComplianceReportListerExpansion allows custom methods to be added to
+// ComplianceReportLister.
+type ComplianceReportListerExpansion interface{}
+
 // PolicyExceptionListerExpansion allows custom methods to be added to
 // PolicyExceptionLister.
 type PolicyExceptionListerExpansion interface{}
@@ -39,8 +39,12 @@ type Interface interface {
 	// ListResource returns the list of resources in unstructured/json format
 	// Access items using []Items
 	ListResource(ctx context.Context, apiVersion string, kind string, namespace string, lselector *metav1.LabelSelector) (*unstructured.UnstructuredList, error)
+	// ListResourceWithOptions returns the list of resources using the given list options, it supports paginated listing
+	ListResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, options metav1.ListOptions) (*unstructured.UnstructuredList, error)
 	// DeleteResource deletes the specified resource
 	DeleteResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, dryRun bool) error
+	// DeleteResourceWithOptions deletes the specified resource using the given delete options
+	DeleteResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, options metav1.DeleteOptions) error
 	// CreateResource creates object for the specified resource/namespace
 	CreateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool) (*unstructured.Unstructured, error)
 	// UpdateResource updates object for the specified resource/namespace
@@ -84,6 +88,22 @@ func NewClient(
 	return &client, nil
 }
 
+// NewClientWithDiscovery creates a new instance of client sharing an existing discovery client,
+// it doesn't poll the discovery cache itself
+func NewClientWithDiscovery(
+	dyn dynamic.Interface,
+	kube kubernetes.Interface,
+	discoveryClient IDiscovery,
+) Interface {
+	client := client{
+		dyn:  dyn,
+		kube: kube,
+		rest: kube.Discovery().RESTClient(),
+	}
+	client.SetDiscovery(discoveryClient)
+	return &client
+}
+
 // NewDynamicSharedInformerFactory returns a new instance of DynamicSharedInformerFactory
 func (c *client) NewDynamicSharedInformerFactory(defaultResync time.Duration) dynamicinformer.DynamicSharedInformerFactory {
 	return dynamicinformer.NewDynamicSharedInformerFactory(c.dyn, defaultResync)
@@ -159,6 +179,11 @@ func (c *client) ListResource(ctx context.Context, apiVersion string, kind strin
 	return c.getResourceInterface(apiVersion, kind, namespace).List(ctx, options)
 }
 
+// ListResourceWithOptions returns the list of resources using the given list options
+func (c *client) ListResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, options metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return c.getResourceInterface(apiVersion, kind, namespace).List(ctx, options)
+}
+
 // DeleteResource deletes the specified resource
 func (c *client) DeleteResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, dryRun bool) error {
 	options := metav1.DeleteOptions{}
@@ -168,6 +193,11 @@ func (c *client) DeleteResource(ctx context.Context, apiVersion string, kind str
 	return c.getResourceInterface(apiVersion, kind, namespace).Delete(ctx, name, options)
 }
 
+// DeleteResourceWithOptions deletes the specified resource using the given delete options
+func (c *client) DeleteResourceWithOptions(ctx context.Context, apiVersion string, kind string, namespace string, name string, options metav1.DeleteOptions) error {
+	return c.getResourceInterface(apiVersion, kind, namespace).Delete(ctx, name, options)
+}
+
 // CreateResource creates object for the specified resource/namespace
 func (c *client) CreateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool) (*unstructured.Unstructured, error) {
 	options := metav1.CreateOptions{}
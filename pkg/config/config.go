@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"strconv"
+	"strings"
 	"sync"
 
 	valid "github.com/asaskevich/govalidator"
@@ -26,6 +27,8 @@ const (
 	ExceptionValidatingWebhookConfigurationName = "kyverno-exception-validating-webhook-cfg"
 	// CleanupValidatingWebhookConfigurationName ...
 	CleanupValidatingWebhookConfigurationName = "kyverno-cleanup-validating-webhook-cfg"
+	// TTLValidatingWebhookConfigurationName ...
+	TTLValidatingWebhookConfigurationName = "kyverno-ttl-validating-webhook-cfg"
 	// PolicyMutatingWebhookConfigurationName default policy mutating webhook configuration name
 	PolicyMutatingWebhookConfigurationName = "kyverno-policy-mutating-webhook-cfg"
 	// MutatingWebhookConfigurationName default resource mutating webhook configuration name
@@ -58,6 +61,8 @@ const (
 	ExceptionValidatingWebhookServicePath = "/exceptionvalidate"
 	// CleanupValidatingWebhookServicePath is the path for cleanup policy validation webhook(used to validate cleanup policy resource)
 	CleanupValidatingWebhookServicePath = "/validate"
+	// TTLValidatingWebhookServicePath is the path for ttl label validation webhook(used to validate the cleanup ttl label of resources)
+	TTLValidatingWebhookServicePath = "/validatettl"
 	// PolicyMutatingWebhookServicePath is the path for policy mutation webhook(used to default)
 	PolicyMutatingWebhookServicePath = "/policymutate"
 	// MutatingWebhookServicePath is the path for mutation webhook
@@ -147,6 +152,8 @@ type Configuration interface {
 	FilterNamespaces(namespaces []string) []string
 	// GetWebhooks returns the webhook configs
 	GetWebhooks() []WebhookConfig
+	// GetPolicyExceptionRequiredFields returns the approval fields policy exceptions must set
+	GetPolicyExceptionRequiredFields() []string
 	// Load loads configuration from a configmap
 	Load(cm *corev1.ConfigMap)
 }
@@ -161,6 +168,7 @@ type configuration struct {
 	generateSuccessEvents         bool
 	mux                           sync.RWMutex
 	webhooks                      []WebhookConfig
+	exceptionRequiredFields       []string
 }
 
 // NewDefaultConfiguration ...
@@ -248,6 +256,12 @@ func (cd *configuration) GetWebhooks() []WebhookConfig {
 	return cd.webhooks
 }
 
+func (cd *configuration) GetPolicyExceptionRequiredFields() []string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.exceptionRequiredFields
+}
+
 func (cd *configuration) Load(cm *corev1.ConfigMap) {
 	if cm != nil {
 		cd.load(cm)
@@ -269,6 +283,7 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	cd.excludeUsername = []string{}
 	cd.generateSuccessEvents = false
 	cd.webhooks = nil
+	cd.exceptionRequiredFields = nil
 	// load filters
 	cd.filters = parseKinds(cm.Data["resourceFilters"])
 	newDefaultRegistry, ok := cm.Data["defaultRegistry"]
@@ -318,6 +333,12 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			cd.webhooks = webhooks
 		}
 	}
+	// load policyExceptionRequiredFields
+	for _, field := range strings.Split(cm.Data["policyExceptionRequiredFields"], ",") {
+		if field := strings.TrimSpace(field); field != "" {
+			cd.exceptionRequiredFields = append(cd.exceptionRequiredFields, field)
+		}
+	}
 }
 
 func (cd *configuration) unload() {
@@ -330,5 +351,6 @@ func (cd *configuration) unload() {
 	cd.excludeUsername = []string{}
 	cd.generateSuccessEvents = false
 	cd.webhooks = nil
+	cd.exceptionRequiredFields = nil
 	cd.excludeGroupRole = append(cd.excludeGroupRole, defaultExcludeGroupRole...)
 }
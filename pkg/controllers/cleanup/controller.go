@@ -3,10 +3,12 @@ package cleanup
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
 	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/config"
@@ -28,9 +30,13 @@ const (
 	CleanupServicePath = "/cleanup"
 )
 
+// CleanupFunc executes the cleanup policy with the given key
+type CleanupFunc = func(ctx context.Context, logger logr.Logger, policy string, now time.Time) error
+
 type controller struct {
 	// clients
-	client kubernetes.Interface
+	client        kubernetes.Interface
+	kyvernoClient versioned.Interface
 
 	// listers
 	cpolLister kyvernov2alpha1listers.ClusterCleanupPolicyLister
@@ -40,23 +46,37 @@ type controller struct {
 	// queue
 	queue   workqueue.RateLimitingInterface
 	enqueue controllerutils.EnqueueFuncT[kyvernov2alpha1.CleanupPolicyInterface]
+	// executions holds the policies due for execution, they run outside of the scheduling loop
+	executions workqueue.RateLimitingInterface
+
+	// lastExecutions holds the last execution of the policies scheduled by this controller
+	lock           sync.Mutex
+	lastExecutions map[string]execution
 
 	// config
 	cleanupService string
+	cronJobs       bool
+	cleanup        CleanupFunc
 }
 
 const (
 	maxRetries     = 10
 	Workers        = 3
 	ControllerName = "cleanup-controller"
+	executionsName = "cleanup-executions"
 )
 
+// NewController creates the cleanup policies controller, policies are executed by the in-process scheduler
+// with the given cleanup func, or by CronJobs calling the cleanup service when cronJobs is true
 func NewController(
 	client kubernetes.Interface,
+	kyvernoClient versioned.Interface,
 	cpolInformer kyvernov2alpha1informers.ClusterCleanupPolicyInformer,
 	polInformer kyvernov2alpha1informers.CleanupPolicyInformer,
 	cjInformer batchv1informers.CronJobInformer,
 	cleanupService string,
+	cronJobs bool,
+	cleanup CleanupFunc,
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	keyFunc := controllerutils.MetaNamespaceKeyT[kyvernov2alpha1.CleanupPolicyInterface]
@@ -78,12 +98,17 @@ func NewController(
 	}
 	c := &controller{
 		client:         client,
+		kyvernoClient:  kyvernoClient,
 		cpolLister:     cpolInformer.Lister(),
 		polLister:      polInformer.Lister(),
 		cjLister:       cjInformer.Lister(),
 		queue:          queue,
 		cleanupService: cleanupService,
+		cronJobs:       cronJobs,
+		cleanup:        cleanup,
 		enqueue:        baseEnqueueFunc,
+		executions:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), executionsName),
+		lastExecutions: map[string]execution{},
 	}
 	controllerutils.AddEventHandlersT(
 		cpolInformer.Informer(),
@@ -107,7 +132,9 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, func(ctx context.Context, _ logr.Logger) {
+		controllerutils.Run(ctx, logger.WithName("executions").V(3), executionsName, time.Second, c.executions, workers, 0, c.execute)
+	})
 }
 
 func (c *controller) enqueueCronJob(n *batchv1.CronJob) {
@@ -237,15 +264,19 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	policy, err := c.getPolicy(namespace, name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			c.forgetLastExecution(key)
 			return nil
 		}
 		logger.Error(err, "unable to get the policy from policy informer")
 		return err
 	}
-	cronjobNs := namespace
-	if namespace == "" {
-		cronjobNs = config.KyvernoNamespace()
+	if !c.cronJobs {
+		if err := c.deleteCronJob(ctx, policy); err != nil {
+			return err
+		}
+		return c.schedule(ctx, logger, key, policy, time.Now())
 	}
+	cronjobNs := cronJobNamespace(policy)
 	observed, err := c.getCronjob(cronjobNs, string(policy.GetUID()))
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -272,3 +303,26 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 		return err
 	}
 }
+
+func cronJobNamespace(policy kyvernov2alpha1.CleanupPolicyInterface) string {
+	if policy.GetNamespace() == "" {
+		return config.KyvernoNamespace()
+	}
+	return policy.GetNamespace()
+}
+
+// deleteCronJob removes the CronJob created for a policy when the CronJob mode was enabled
+func (c *controller) deleteCronJob(ctx context.Context, policy kyvernov2alpha1.CleanupPolicyInterface) error {
+	namespace := cronJobNamespace(policy)
+	if _, err := c.getCronjob(namespace, string(policy.GetUID())); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	err := c.client.BatchV1().CronJobs(namespace).Delete(ctx, string(policy.GetUID()), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
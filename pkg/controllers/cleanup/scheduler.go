@@ -0,0 +1,115 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/cleanup"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// execution is the last execution of a policy scheduled by this controller
+type execution struct {
+	uid  types.UID
+	time time.Time
+}
+
+// nextExecutionTime returns the next execution time of a policy, it is computed from the last execution
+// time or from the policy creation time if the policy was never executed. The last execution recorded in
+// memory is used when it is more recent than the one in the policy status, the status read from the
+// informer cache can be stale right after it was updated.
+func nextExecutionTime(schedule cron.Schedule, policy kyvernov2alpha1.CleanupPolicyInterface, executed time.Time) time.Time {
+	last := policy.GetCreationTimestamp().Time
+	if status := policy.GetStatus(); status.LastExecutionTime != nil {
+		last = status.LastExecutionTime.Time
+	}
+	if executed.After(last) {
+		last = executed
+	}
+	return schedule.Next(last)
+}
+
+// lastExecution returns the last execution of a policy recorded in memory
+func (c *controller) lastExecution(key string) (execution, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.lastExecutions[key]
+	return e, ok
+}
+
+func (c *controller) setLastExecution(key string, e execution) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lastExecutions[key] = e
+}
+
+func (c *controller) forgetLastExecution(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.lastExecutions, key)
+}
+
+// schedule queues the policy for execution when its next execution time is reached and requeues it until its
+// next execution. The execution is recorded in memory and in the policy status before it is queued, a failed
+// status update prevents the policy from running for this schedule until the update succeeds.
+func (c *controller) schedule(ctx context.Context, logger logr.Logger, key string, policy kyvernov2alpha1.CleanupPolicyInterface, now time.Time) error {
+	schedule, err := cron.ParseStandard(policy.GetSpec().Schedule)
+	if err != nil {
+		// the schedule is validated at admission, retrying will not fix it
+		logger.Error(err, "failed to parse policy schedule")
+		return nil
+	}
+	previous, ok := c.lastExecution(key)
+	if ok && previous.uid != policy.GetUID() {
+		// the policy was recreated
+		previous, ok = execution{}, false
+	}
+	next := nextExecutionTime(schedule, policy, previous.time)
+	if now.Before(next) {
+		if status := policy.GetStatus(); status.NextExecutionTime == nil || !status.NextExecutionTime.Time.Equal(next) {
+			if err := cleanup.UpdateStatus(ctx, c.kyvernoClient, policy, func(status *kyvernov2alpha1.CleanupPolicyStatus) {
+				status.NextExecutionTime = &metav1.Time{Time: next}
+			}); err != nil {
+				return err
+			}
+		}
+		c.queue.AddAfter(key, next.Sub(now))
+		return nil
+	}
+	next = schedule.Next(now)
+	c.setLastExecution(key, execution{uid: policy.GetUID(), time: now})
+	if err := cleanup.UpdateStatus(ctx, c.kyvernoClient, policy, func(status *kyvernov2alpha1.CleanupPolicyStatus) {
+		status.LastExecutionTime = &metav1.Time{Time: now}
+		status.NextExecutionTime = &metav1.Time{Time: next}
+	}); err != nil {
+		if ok {
+			c.setLastExecution(key, previous)
+		} else {
+			c.forgetLastExecution(key)
+		}
+		return err
+	}
+	logger.Info("queueing cleanup policy execution...", "next", next)
+	c.executions.Add(key)
+	c.queue.AddAfter(key, time.Until(next))
+	return nil
+}
+
+// execute runs a policy queued for execution by the scheduler, executions are not retried
+func (c *controller) execute(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	e, ok := c.lastExecution(key)
+	if !ok {
+		// the policy was deleted
+		return nil
+	}
+	logger.Info("executing cleanup policy...")
+	if err := c.cleanup(ctx, logger, key, e.time); err != nil {
+		// failures are recorded in the policy status and events, the policy runs again at its next execution
+		logger.Error(err, "failed to execute cleanup policy")
+	}
+	return nil
+}
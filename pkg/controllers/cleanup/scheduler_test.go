@@ -0,0 +1,64 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"github.com/robfig/cron"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func Test_nextExecutionTime(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 * * * *")
+	assert.NilError(t, err)
+	created := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+	policy := &kyvernov2alpha1.ClusterCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+	// never executed, computed from the creation time
+	assert.Equal(t, nextExecutionTime(schedule, policy, time.Time{}), time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC))
+	// computed from the last execution time
+	policy.Status.LastExecutionTime = &metav1.Time{Time: time.Date(2023, 1, 2, 15, 0, 0, 0, time.UTC)}
+	assert.Equal(t, nextExecutionTime(schedule, policy, time.Time{}), time.Date(2023, 1, 2, 16, 0, 0, 0, time.UTC))
+	// the execution recorded in memory is more recent than the stale status
+	assert.Equal(t, nextExecutionTime(schedule, policy, time.Date(2023, 1, 2, 16, 0, 0, 0, time.UTC)), time.Date(2023, 1, 2, 17, 0, 0, 0, time.UTC))
+	// the execution recorded in memory is older than the status
+	assert.Equal(t, nextExecutionTime(schedule, policy, time.Date(2023, 1, 1, 16, 0, 0, 0, time.UTC)), time.Date(2023, 1, 2, 16, 0, 0, 0, time.UTC))
+}
+
+func Test_schedule(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := &kyvernov2alpha1.ClusterCleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test",
+			UID:               "uid",
+			CreationTimestamp: metav1.NewTime(now.Add(-90 * time.Minute)),
+		},
+		Spec: kyvernov2alpha1.CleanupPolicySpec{Schedule: "0 * * * *"},
+	}
+	c := &controller{
+		kyvernoClient:  fake.NewSimpleClientset(policy.DeepCopy()),
+		queue:          workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		executions:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		lastExecutions: map[string]execution{},
+	}
+	defer c.queue.ShutDown()
+	defer c.executions.ShutDown()
+	assert.NilError(t, c.schedule(context.TODO(), logr.Discard(), "test", policy, now))
+	assert.Equal(t, c.executions.Len(), 1)
+	// the policy from the informer cache doesn't have the updated status yet
+	assert.NilError(t, c.schedule(context.TODO(), logr.Discard(), "test", policy, now.Add(time.Second)))
+	assert.Equal(t, c.executions.Len(), 1)
+	e, ok := c.lastExecution("test")
+	assert.Assert(t, ok)
+	assert.Equal(t, e.time, now)
+}
@@ -0,0 +1,135 @@
+package ttl
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/event"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// controller deletes the expired resources of a single kind, resources that are not expired yet
+// are requeued with a delay corresponding to their remaining time to live
+type controller struct {
+	// clients
+	client metadata.Getter
+
+	// listers
+	lister cache.GenericLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	name     string
+	gvr      schema.GroupVersionResource
+	gvk      schema.GroupVersionKind
+	recorder record.EventRecorder
+	metrics  ttlMetrics
+	logger   logr.Logger
+}
+
+func newController(
+	client metadata.Getter,
+	informer informers.GenericInformer,
+	gvr schema.GroupVersionResource,
+	gvk schema.GroupVersionKind,
+	recorder record.EventRecorder,
+	metrics ttlMetrics,
+	logger logr.Logger,
+) *controller {
+	// each resource has its own queue, they are named after the resource to keep their metrics apart
+	name := ControllerName + "-" + gvr.String()
+	c := &controller{
+		name:     name,
+		client:   client,
+		lister:   informer.Lister(),
+		queue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), name),
+		gvr:      gvr,
+		gvk:      gvk,
+		recorder: recorder,
+		metrics:  metrics,
+		logger:   logger,
+	}
+	controllerutils.AddDefaultEventHandlers(logger, informer.Informer(), c.queue)
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, c.logger, c.name, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) get(namespace, name string) (runtime.Object, error) {
+	if namespace == "" {
+		return c.lister.Get(name)
+	}
+	return c.lister.ByNamespace(namespace).Get(name)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	obj, err := c.get(namespace, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	metaObj, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	value, ok := metaObj.GetLabels()[LabelTTL]
+	if !ok || metaObj.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	expiration, err := ParseTTL(value, metaObj.GetCreationTimestamp().Time)
+	if err != nil {
+		// the label can't be fixed by retrying, the resource will be requeued when updated
+		logger.Error(err, "failed to parse ttl label")
+		c.metrics.recordError(ctx, c.gvr, namespace)
+		return nil
+	}
+	if remaining := time.Until(expiration); remaining > 0 {
+		logger.V(4).Info("resource not expired yet", "expiration", expiration)
+		c.queue.AddAfter(key, remaining)
+		return nil
+	}
+	uid := metaObj.GetUID()
+	propagation := metav1.DeletePropagationBackground
+	options := metav1.DeleteOptions{
+		Preconditions:     &metav1.Preconditions{UID: &uid},
+		PropagationPolicy: &propagation,
+	}
+	ref := &corev1.ObjectReference{
+		APIVersion: c.gvk.GroupVersion().String(),
+		Kind:       c.gvk.Kind,
+		Namespace:  namespace,
+		Name:       name,
+		UID:        uid,
+	}
+	if err := c.client.Namespace(namespace).Delete(ctx, name, options); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		logger.Error(err, "failed to delete expired resource")
+		c.metrics.recordError(ctx, c.gvr, namespace)
+		c.recorder.Eventf(ref, corev1.EventTypeWarning, string(event.ResourceExpired), "failed to delete resource expired at %s: %s", expiration.Format(time.RFC3339), err)
+		return err
+	}
+	logger.Info("deleted expired resource", "expiration", expiration)
+	c.metrics.recordDeletedObject(ctx, c.gvr, namespace)
+	c.recorder.Eventf(ref, corev1.EventTypeNormal, string(event.ResourceExpired), "resource expired at %s and was deleted", expiration.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,67 @@
+package ttl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LabelTTL is the label holding the expiration of a resource, the value is either a duration relative to the
+// resource creation time (e.g. 72h, 7d) or an absolute time (e.g. 2023-01-31 or 2023-01-31T153000Z)
+const LabelTTL = "cleanup.kyverno.io/ttl"
+
+// timeLayouts are the supported absolute time layouts, label values can't contain ':' so RFC3339 is not supported
+var timeLayouts = []string{
+	"2006-01-02",
+	"2006-01-02T150405Z",
+}
+
+// ParseTTL returns the expiration time of a resource created at the given time, given the value of its ttl label
+func ParseTTL(value string, creationTimestamp time.Time) (time.Time, error) {
+	if duration, ok, err := parseDuration(value); ok {
+		if err != nil {
+			return time.Time{}, err
+		}
+		return creationTimestamp.Add(duration), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid %s label value %q, expected a duration (e.g. 72h, 7d) or a time (e.g. 2023-01-31, 2023-01-31T153000Z)", LabelTTL, value)
+}
+
+// Validate checks the ttl label of a resource if present
+func Validate(labels map[string]string) error {
+	if value, ok := labels[LabelTTL]; ok {
+		if _, err := ParseTTL(value, time.Now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDuration parses a go duration, with support for a days unit.
+// The second returned value is false when the value doesn't look like a duration.
+func parseDuration(value string) (time.Duration, bool, error) {
+	var duration time.Duration
+	if strings.HasSuffix(value, "d") {
+		count, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil {
+			return 0, false, nil
+		}
+		duration = time.Duration(count) * 24 * time.Hour
+	} else {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, false, nil
+		}
+		duration = d
+	}
+	if duration <= 0 {
+		return 0, true, fmt.Errorf("invalid %s label value %q, duration must be positive", LabelTTL, value)
+	}
+	return duration, true, nil
+}
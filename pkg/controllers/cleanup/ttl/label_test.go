@@ -0,0 +1,76 @@
+package ttl
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestParseTTL(t *testing.T) {
+	creation := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{{
+		name:  "hours",
+		value: "72h",
+		want:  creation.Add(72 * time.Hour),
+	}, {
+		name:  "minutes and seconds",
+		value: "1h30m15s",
+		want:  creation.Add(time.Hour + 30*time.Minute + 15*time.Second),
+	}, {
+		name:  "days",
+		value: "7d",
+		want:  creation.Add(7 * 24 * time.Hour),
+	}, {
+		name:  "date",
+		value: "2023-01-31",
+		want:  time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:  "date and time",
+		value: "2023-01-31T153000Z",
+		want:  time.Date(2023, 1, 31, 15, 30, 0, 0, time.UTC),
+	}, {
+		name:    "zero duration",
+		value:   "0s",
+		wantErr: true,
+	}, {
+		name:    "negative days",
+		value:   "-1d",
+		wantErr: true,
+	}, {
+		name:    "unknown unit",
+		value:   "3w",
+		wantErr: true,
+	}, {
+		name:    "invalid date",
+		value:   "2023-13-01",
+		wantErr: true,
+	}, {
+		name:    "empty",
+		value:   "",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTTL(tt.value, creation)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NilError(t, Validate(nil))
+	assert.NilError(t, Validate(map[string]string{"app": "test"}))
+	assert.NilError(t, Validate(map[string]string{LabelTTL: "24h"}))
+	assert.Assert(t, Validate(map[string]string{LabelTTL: "tomorrow"}) != nil)
+}
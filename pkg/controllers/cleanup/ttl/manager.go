@@ -0,0 +1,224 @@
+package ttl
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"golang.org/x/exp/slices"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// Workers is the number of workers of each per kind controller
+	Workers        = 3
+	ControllerName = "ttl-controller"
+	maxRetries     = 10
+	// DiscoveryInterval is the interval at which the manager looks for new or removed kinds
+	DiscoveryInterval = 5 * time.Minute
+)
+
+var requiredVerbs = []string{"list", "watch", "delete"}
+
+type resourceController struct {
+	gvk    schema.GroupVersionKind
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (c *resourceController) stop() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+// manager watches the resources carrying the ttl label across all the kinds the controller is
+// allowed to list, watch and delete, it runs one controller per kind
+type manager struct {
+	// clients
+	metadataClient  metadata.Interface
+	discoveryClient discovery.DiscoveryInterface
+	ssarClient      authorizationv1client.SelfSubjectAccessReviewInterface
+
+	// config
+	recorder record.EventRecorder
+	metrics  ttlMetrics
+	interval time.Duration
+
+	lock        sync.Mutex
+	controllers map[schema.GroupVersionResource]*resourceController
+}
+
+func NewManager(
+	metadataClient metadata.Interface,
+	discoveryClient discovery.DiscoveryInterface,
+	ssarClient authorizationv1client.SelfSubjectAccessReviewInterface,
+	recorder record.EventRecorder,
+	interval time.Duration,
+) controllers.Controller {
+	return &manager{
+		metadataClient:  metadataClient,
+		discoveryClient: discoveryClient,
+		ssarClient:      ssarClient,
+		recorder:        recorder,
+		metrics:         newTTLMetrics(logger),
+		interval:        interval,
+		controllers:     map[schema.GroupVersionResource]*resourceController{},
+	}
+}
+
+func (m *manager) Run(ctx context.Context, workers int) {
+	logger.Info("starting ...")
+	defer logger.Info("stopped")
+	defer m.stopAll()
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.reconcile(ctx, workers); err != nil {
+			logger.Error(err, "failed to reconcile resource controllers")
+		}
+	}, m.interval)
+}
+
+func (m *manager) reconcile(ctx context.Context, workers int) error {
+	resources, err := m.discoverResources()
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for gvr := range resources {
+		// permissions are checked only once per kind to limit the number of access reviews
+		if _, ok := m.controllers[gvr]; !ok && !m.isAllowed(ctx, gvr) {
+			delete(resources, gvr)
+		}
+	}
+	for gvr, c := range m.controllers {
+		if _, ok := resources[gvr]; !ok {
+			logger.Info("stopping resource controller", "gvr", gvr)
+			c.stop()
+			delete(m.controllers, gvr)
+		}
+	}
+	for gvr, gvk := range resources {
+		if _, ok := m.controllers[gvr]; !ok {
+			logger.Info("starting resource controller", "gvr", gvr)
+			m.controllers[gvr] = m.start(ctx, gvr, gvk, workers)
+		}
+	}
+	return nil
+}
+
+func (m *manager) start(ctx context.Context, gvr schema.GroupVersionResource, gvk schema.GroupVersionKind, workers int) *resourceController {
+	logger := logger.WithValues("gvr", gvr)
+	ctx, cancel := context.WithCancel(ctx)
+	informer := metadatainformer.NewFilteredMetadataInformer(
+		m.metadataClient,
+		gvr,
+		metav1.NamespaceAll,
+		0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = LabelTTL
+		},
+	)
+	controller := newController(m.metadataClient.Resource(gvr), informer, gvr, gvk, m.recorder, m.metrics, logger)
+	rc := &resourceController{
+		gvk:    gvk,
+		cancel: cancel,
+	}
+	rc.wg.Add(2)
+	go func() {
+		defer rc.wg.Done()
+		informer.Informer().Run(ctx.Done())
+	}()
+	go func() {
+		defer rc.wg.Done()
+		if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+			logger.Info("failed to wait for cache sync")
+			return
+		}
+		controller.Run(ctx, workers)
+	}()
+	return rc
+}
+
+func (m *manager) stopAll() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for gvr, c := range m.controllers {
+		c.stop()
+		delete(m.controllers, gvr)
+	}
+}
+
+// discoverResources returns the resources supporting the list, watch and delete verbs
+func (m *manager) discoverResources() (map[schema.GroupVersionResource]schema.GroupVersionKind, error) {
+	lists, err := discovery.ServerPreferredResources(m.discoveryClient)
+	if err != nil {
+		// partial discovery failures still return the resources of the groups that were discovered
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		logger.Error(err, "failed to discover some api groups")
+	}
+	resources := map[schema.GroupVersionResource]schema.GroupVersionKind{}
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			logger.Error(err, "failed to parse group version", "groupVersion", list.GroupVersion)
+			continue
+		}
+		for _, resource := range list.APIResources {
+			// skip subresources
+			if strings.Contains(resource.Name, "/") || !hasVerbs(resource.Verbs) {
+				continue
+			}
+			resources[gv.WithResource(resource.Name)] = gv.WithKind(resource.Kind)
+		}
+	}
+	return resources, nil
+}
+
+func hasVerbs(verbs metav1.Verbs) bool {
+	for _, verb := range requiredVerbs {
+		if !slices.Contains(verbs, verb) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAllowed checks the controller has the permissions to list, watch and delete the given resource
+func (m *manager) isAllowed(ctx context.Context, gvr schema.GroupVersionResource) bool {
+	for _, verb := range requiredVerbs {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Group:    gvr.Group,
+					Version:  gvr.Version,
+					Resource: gvr.Resource,
+					Verb:     verb,
+				},
+			},
+		}
+		result, err := m.ssarClient.Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			logger.Error(err, "failed to check permissions", "gvr", gvr, "verb", verb)
+			return false
+		}
+		if !result.Status.Allowed {
+			logger.V(4).Info("missing permissions", "gvr", gvr, "verb", verb)
+			return false
+		}
+	}
+	return true
+}
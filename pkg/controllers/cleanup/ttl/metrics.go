@@ -0,0 +1,50 @@
+package ttl
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type ttlMetrics struct {
+	deletedObjectsTotal syncint64.Counter
+	errorsTotal         syncint64.Counter
+}
+
+func newTTLMetrics(logger logr.Logger) ttlMetrics {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	deletedObjectsTotal, err := meter.SyncInt64().Counter(
+		"kyverno_ttl_controller_deletedobjects",
+		instrument.WithDescription("can be used to track number of objects deleted by the ttl controller"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_ttl_controller_deletedobjects_total")
+	}
+	errorsTotal, err := meter.SyncInt64().Counter(
+		"kyverno_ttl_controller_errors",
+		instrument.WithDescription("can be used to track number of errors encountered by the ttl controller"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_ttl_controller_errors_total")
+	}
+	return ttlMetrics{
+		deletedObjectsTotal: deletedObjectsTotal,
+		errorsTotal:         errorsTotal,
+	}
+}
+
+func (m ttlMetrics) recordDeletedObject(ctx context.Context, gvr schema.GroupVersionResource, namespace string) {
+	if m.deletedObjectsTotal != nil {
+		m.deletedObjectsTotal.Add(ctx, 1, attribute.String("resource", gvr.String()), attribute.String("resource_namespace", namespace))
+	}
+}
+
+func (m ttlMetrics) recordError(ctx context.Context, gvr schema.GroupVersionResource, namespace string) {
+	if m.errorsTotal != nil {
+		m.errorsTotal.Add(ctx, 1, attribute.String("resource", gvr.String()), attribute.String("resource_namespace", namespace))
+	}
+}
@@ -0,0 +1,137 @@
+package exceptions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/event"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "exceptions-controller"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	polexLister kyvernov2alpha1listers.PolicyExceptionLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// events and metrics
+	eventGen event.Interface
+	metrics  exceptionMetrics
+
+	// config
+	expiringWindow time.Duration
+}
+
+// NewController creates the policy exceptions controller, it maintains the Active condition of policy
+// exceptions and emits events when an exception is about to expire (within the expiring window) and when it expires
+func NewController(
+	kyvernoClient versioned.Interface,
+	polexInformer kyvernov2alpha1informers.PolicyExceptionInformer,
+	eventGen event.Interface,
+	expiringWindow time.Duration,
+) controllers.Controller {
+	c := controller{
+		kyvernoClient:  kyvernoClient,
+		polexLister:    polexInformer.Lister(),
+		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		eventGen:       eventGen,
+		metrics:        newExceptionMetrics(logger),
+		expiringWindow: expiringWindow,
+	}
+	controllerutils.AddDefaultEventHandlers(logger, polexInformer.Informer(), c.queue)
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	polex, err := c.polexLister.PolicyExceptions(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	now := time.Now()
+	reason, message, next := activeCondition(polex.Spec, now, c.expiringWindow)
+	var previous string
+	if condition := meta.FindStatusCondition(polex.Status.Conditions, kyvernov2alpha1.PolicyExceptionConditionActive); condition != nil {
+		previous = condition.Reason
+	}
+	_, err = controllerutils.UpdateStatus(ctx, polex, c.kyvernoClient.KyvernoV2alpha1().PolicyExceptions(namespace), func(polex *kyvernov2alpha1.PolicyException) error {
+		polex.Status.SetActive(polex.GetGeneration(), reason, message)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if previous != reason {
+		logger.V(2).Info("policy exception condition changed", "from", previous, "to", reason)
+		c.metrics.recordTransition(ctx, namespace, reason)
+		switch reason {
+		case kyvernov2alpha1.PolicyExceptionReasonExpiring:
+			c.emit(polex, event.PolicyExceptionExpiring, message)
+		case kyvernov2alpha1.PolicyExceptionReasonExpired:
+			c.emit(polex, event.PolicyExceptionExpired, message)
+		}
+	}
+	// requeue at the next transition
+	if !next.IsZero() {
+		c.queue.AddAfter(key, next.Sub(now))
+	}
+	return nil
+}
+
+func (c *controller) emit(polex *kyvernov2alpha1.PolicyException, reason event.Reason, message string) {
+	c.eventGen.Add(event.Info{
+		Kind:      "PolicyException",
+		Name:      polex.GetName(),
+		Namespace: polex.GetNamespace(),
+		Reason:    reason,
+		Message:   message,
+		Source:    event.AdmissionController,
+	})
+}
+
+// activeCondition returns the reason and message of the Active condition of an exception at the given time,
+// and the time of the next transition (zero when there is none)
+func activeCondition(spec kyvernov2alpha1.PolicyExceptionSpec, now time.Time, expiringWindow time.Duration) (string, string, time.Time) {
+	if spec.NotBefore != nil && now.Before(spec.NotBefore.Time) {
+		return kyvernov2alpha1.PolicyExceptionReasonNotYetValid, fmt.Sprintf("exception is not valid before %s", spec.NotBefore.UTC().Format(time.RFC3339)), spec.NotBefore.Time
+	}
+	if spec.ExpiresAt == nil {
+		return kyvernov2alpha1.PolicyExceptionReasonActive, "exception is active", time.Time{}
+	}
+	expiresAt := spec.ExpiresAt.Time
+	if !now.Before(expiresAt) {
+		return kyvernov2alpha1.PolicyExceptionReasonExpired, fmt.Sprintf("exception expired at %s", expiresAt.UTC().Format(time.RFC3339)), time.Time{}
+	}
+	message := fmt.Sprintf("exception expires at %s", expiresAt.UTC().Format(time.RFC3339))
+	if expiring := expiresAt.Add(-expiringWindow); now.Before(expiring) {
+		return kyvernov2alpha1.PolicyExceptionReasonActive, message, expiring
+	}
+	return kyvernov2alpha1.PolicyExceptionReasonExpiring, message, expiresAt
+}
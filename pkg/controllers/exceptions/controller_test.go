@@ -0,0 +1,55 @@
+package exceptions
+
+import (
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_activeCondition(t *testing.T) {
+	now := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(d))
+		return &t
+	}
+	window := 72 * time.Hour
+	tests := []struct {
+		name       string
+		spec       kyvernov2alpha1.PolicyExceptionSpec
+		wantReason string
+		wantNext   time.Time
+	}{{
+		name:       "no bounds",
+		spec:       kyvernov2alpha1.PolicyExceptionSpec{},
+		wantReason: kyvernov2alpha1.PolicyExceptionReasonActive,
+	}, {
+		name:       "not yet valid",
+		spec:       kyvernov2alpha1.PolicyExceptionSpec{NotBefore: at(time.Hour)},
+		wantReason: kyvernov2alpha1.PolicyExceptionReasonNotYetValid,
+		wantNext:   now.Add(time.Hour),
+	}, {
+		name:       "active until expiring window",
+		spec:       kyvernov2alpha1.PolicyExceptionSpec{NotBefore: at(-time.Hour), ExpiresAt: at(96 * time.Hour)},
+		wantReason: kyvernov2alpha1.PolicyExceptionReasonActive,
+		wantNext:   now.Add(24 * time.Hour),
+	}, {
+		name:       "expiring",
+		spec:       kyvernov2alpha1.PolicyExceptionSpec{ExpiresAt: at(time.Hour)},
+		wantReason: kyvernov2alpha1.PolicyExceptionReasonExpiring,
+		wantNext:   now.Add(time.Hour),
+	}, {
+		name:       "expired",
+		spec:       kyvernov2alpha1.PolicyExceptionSpec{ExpiresAt: at(0)},
+		wantReason: kyvernov2alpha1.PolicyExceptionReasonExpired,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, _, next := activeCondition(tt.spec, now, window)
+			assert.Equal(t, reason, tt.wantReason)
+			assert.Equal(t, next, tt.wantNext)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package exceptions
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+type exceptionMetrics struct {
+	transitionsTotal syncint64.Counter
+}
+
+func newExceptionMetrics(logger logr.Logger) exceptionMetrics {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	transitionsTotal, err := meter.SyncInt64().Counter(
+		"kyverno_policy_exception_transitions",
+		instrument.WithDescription("can be used to track policy exceptions becoming active, expiring soon or expired"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_exception_transitions")
+	}
+	return exceptionMetrics{
+		transitionsTotal: transitionsTotal,
+	}
+}
+
+// recordTransition counts a transition per namespace and reason, exception names are left out to bound the cardinality
+func (m exceptionMetrics) recordTransition(ctx context.Context, namespace, reason string) {
+	if m.transitionsTotal != nil {
+		m.transitionsTotal.Add(
+			ctx,
+			1,
+			attribute.String("exception_namespace", namespace),
+			attribute.String("reason", reason),
+		)
+	}
+}
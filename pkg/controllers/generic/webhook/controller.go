@@ -29,10 +29,12 @@ const (
 )
 
 var (
-	none = admissionregistrationv1.SideEffectClassNone
-	fail = admissionregistrationv1.Fail
-	None = &none
-	Fail = &fail
+	none   = admissionregistrationv1.SideEffectClassNone
+	fail   = admissionregistrationv1.Fail
+	ignore = admissionregistrationv1.Ignore
+	None   = &none
+	Fail   = &fail
+	Ignore = &ignore
 )
 
 type controller struct {
@@ -54,6 +56,7 @@ type controller struct {
 	server         string
 	servicePort    int32
 	rules          []admissionregistrationv1.RuleWithOperations
+	objectSelector *metav1.LabelSelector
 	failurePolicy  *admissionregistrationv1.FailurePolicyType
 	sideEffects    *admissionregistrationv1.SideEffectClass
 }
@@ -68,6 +71,7 @@ func NewController(
 	server string,
 	servicePort int32,
 	rules []admissionregistrationv1.RuleWithOperations,
+	objectSelector *metav1.LabelSelector,
 	failurePolicy *admissionregistrationv1.FailurePolicyType,
 	sideEffects *admissionregistrationv1.SideEffectClass,
 ) controllers.Controller {
@@ -84,6 +88,7 @@ func NewController(
 		server:         server,
 		servicePort:    servicePort,
 		rules:          rules,
+		objectSelector: objectSelector,
 		failurePolicy:  failurePolicy,
 		sideEffects:    sideEffects,
 	}
@@ -164,6 +169,7 @@ func (c *controller) build(caBundle []byte) (*admissionregistrationv1.Validating
 				Name:                    fmt.Sprintf("%s.%s.svc", config.KyvernoServiceName(), config.KyvernoNamespace()),
 				ClientConfig:            c.clientConfig(caBundle),
 				Rules:                   c.rules,
+				ObjectSelector:          c.objectSelector,
 				FailurePolicy:           c.failurePolicy,
 				SideEffects:             c.sideEffects,
 				AdmissionReviewVersions: []string{"v1"},
@@ -7,10 +7,11 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
-	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
+	"github.com/kyverno/kyverno/pkg/exporter"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"go.uber.org/multierr"
@@ -19,7 +20,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -33,34 +33,30 @@ const (
 )
 
 type controller struct {
-	// clients
-	client versioned.Interface
-
-	// listers
-	admrLister  cache.GenericLister
-	cadmrLister cache.GenericLister
+	// store
+	store admissionreports.Store
 
 	// queue
 	queue workqueue.RateLimitingInterface
 
 	// cache
 	metadataCache resource.MetadataCache
+
+	// exporter
+	exporter exporter.Interface
 }
 
 func NewController(
-	client versioned.Interface,
-	metadataFactory metadatainformers.SharedInformerFactory,
+	store admissionreports.Store,
 	metadataCache resource.MetadataCache,
+	exporter exporter.Interface,
 ) controllers.Controller {
-	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
-	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
 	c := controller{
-		client:        client,
-		admrLister:    admrInformer.Lister(),
-		cadmrLister:   cadmrInformer.Lister(),
+		store:         store,
 		queue:         queue,
 		metadataCache: metadataCache,
+		exporter:      exporter,
 	}
 	c.metadataCache.AddEventHandler(func(eventType resource.EventType, uid types.UID, _ schema.GroupVersionKind, _ resource.Resource) {
 		// if it's a deletion, give some time to native garbage collection
@@ -70,18 +66,7 @@ func NewController(
 			queue.Add(cache.ExplicitKey(uid))
 		}
 	})
-	controllerutils.AddEventHandlersT(
-		admrInformer.Informer(),
-		func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) },
-		func(old, obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(old))) },
-		func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) },
-	)
-	controllerutils.AddEventHandlersT(
-		cadmrInformer.Informer(),
-		func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) },
-		func(old, obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(old))) },
-		func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) },
-	)
+	store.AddEventHandler(func(obj metav1.Object) { queue.Add(cache.ExplicitKey(reportutils.GetResourceUid(obj))) })
 	return &c
 }
 
@@ -89,43 +74,12 @@ func (c *controller) Run(ctx context.Context, workers int) {
 	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
-func (c *controller) deleteReport(ctx context.Context, namespace, name string) error {
-	if namespace == "" {
-		return c.client.KyvernoV1alpha2().ClusterAdmissionReports().Delete(ctx, name, metav1.DeleteOptions{})
-	} else {
-		return c.client.KyvernoV1alpha2().AdmissionReports(namespace).Delete(ctx, name, metav1.DeleteOptions{})
-	}
-}
-
-func (c *controller) fetchReport(ctx context.Context, namespace, name string) (kyvernov1alpha2.ReportInterface, error) {
-	if namespace == "" {
-		return c.client.KyvernoV1alpha2().ClusterAdmissionReports().Get(ctx, name, metav1.GetOptions{})
-	} else {
-		return c.client.KyvernoV1alpha2().AdmissionReports(namespace).Get(ctx, name, metav1.GetOptions{})
-	}
-}
-
 func (c *controller) getReports(uid types.UID) ([]metav1.Object, error) {
 	selector, err := reportutils.SelectorResourceUidEquals(uid)
 	if err != nil {
 		return nil, err
 	}
-	var results []metav1.Object
-	admrs, err := c.admrLister.List(selector)
-	if err != nil {
-		return nil, err
-	}
-	for _, admr := range admrs {
-		results = append(results, admr.(metav1.Object))
-	}
-	cadmrs, err := c.cadmrLister.List(selector)
-	if err != nil {
-		return nil, err
-	}
-	for _, cadmr := range cadmrs {
-		results = append(results, cadmr.(metav1.Object))
-	}
-	return results, nil
+	return c.store.ListMetadata(selector)
 }
 
 func mergeReports(accumulator map[string]policyreportv1alpha2.PolicyReportResult, reports ...kyvernov1alpha2.ReportInterface) {
@@ -153,7 +107,7 @@ func mergeReports(accumulator map[string]policyreportv1alpha2.PolicyReportResult
 }
 
 func (c *controller) aggregateReports(ctx context.Context, uid types.UID, gvk schema.GroupVersionKind, res resource.Resource, reports ...metav1.Object) error {
-	before, err := c.fetchReport(ctx, res.Namespace, string(uid))
+	before, err := c.store.Get(ctx, res.Namespace, string(uid))
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
@@ -161,17 +115,20 @@ func (c *controller) aggregateReports(ctx context.Context, uid types.UID, gvk sc
 		before = reportutils.NewAdmissionReport(res.Namespace, string(uid), res.Name, uid, metav1.GroupVersionKind(gvk))
 	}
 	merged := map[string]policyreportv1alpha2.PolicyReportResult{}
+	// results of the admission requests not aggregated yet
+	var admitted []policyreportv1alpha2.PolicyReportResult
 	for _, report := range reports {
 		if reportutils.GetResourceHash(report) == res.Hash {
 			if report.GetName() == string(uid) {
 				mergeReports(merged, before)
 			} else {
 				// TODO: see if we can use List instead of fetching reports one by one
-				report, err := c.fetchReport(ctx, report.GetNamespace(), report.GetName())
+				report, err := c.store.Get(ctx, report.GetNamespace(), report.GetName())
 				if err != nil {
 					return err
 				}
 				mergeReports(merged, report)
+				admitted = append(admitted, report.GetResults()...)
 			}
 		}
 	}
@@ -189,23 +146,32 @@ func (c *controller) aggregateReports(ctx context.Context, uid types.UID, gvk sc
 	reportutils.SetResults(after, results...)
 	if after.GetResourceVersion() == "" {
 		if len(results) > 0 {
-			if _, err := reportutils.CreateReport(ctx, after, c.client); err != nil {
+			if _, err := c.store.Create(ctx, after); err != nil {
 				return err
 			}
 		}
 	} else {
 		if len(results) == 0 {
-			if err := c.deleteReport(ctx, after.GetNamespace(), after.GetName()); err != nil {
+			if err := c.store.Delete(ctx, after.GetNamespace(), after.GetName()); err != nil {
 				return err
 			}
 		} else {
 			if !utils.ReportsAreIdentical(before, after) {
-				if _, err = reportutils.UpdateReport(ctx, after, c.client); err != nil {
+				if _, err = c.store.Update(ctx, after); err != nil {
 					return err
 				}
 			}
 		}
 	}
+	if c.exporter != nil && len(admitted) != 0 {
+		c.exporter.Export(exporter.SourceAdmission, corev1.ObjectReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  res.Namespace,
+			Name:       res.Name,
+			UID:        uid,
+		}, admitted...)
+	}
 	return c.cleanupReports(ctx, uid, res.Hash, reports...)
 }
 
@@ -222,7 +188,7 @@ func (c *controller) cleanupReports(ctx context.Context, uid types.UID, hash str
 	}
 	var errs []error
 	for _, report := range toDelete {
-		if err := c.deleteReport(ctx, report.GetNamespace(), report.GetName()); err != nil {
+		if err := c.store.Delete(ctx, report.GetNamespace(), report.GetName()); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -244,12 +210,12 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 	// set orphan reports an owner
 	for _, report := range reports {
 		if len(report.GetOwnerReferences()) == 0 {
-			report, err := c.fetchReport(ctx, report.GetNamespace(), report.GetName())
+			report, err := c.store.Get(ctx, report.GetNamespace(), report.GetName())
 			if err != nil {
 				return err
 			}
 			controllerutils.SetOwner(report, gvk.GroupVersion().String(), gvk.Kind, resource.Name, uid)
-			_, err = reportutils.UpdateReport(ctx, report, c.client)
+			_, err = c.store.Update(ctx, report)
 			return err
 		}
 	}
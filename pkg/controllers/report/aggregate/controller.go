@@ -10,6 +10,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
@@ -21,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
@@ -37,17 +39,20 @@ const (
 	maxRetries     = 10
 	mergeLimit     = 1000
 	enqueueDelay   = 30 * time.Second
+	// summaryReportName is the name of the report rolling up the results of a namespace in per resource mode
+	summaryReportName = "summary"
 )
 
 type controller struct {
 	// clients
 	client versioned.Interface
 
+	// stores
+	admissionReports admissionreports.Store
+
 	// listers
 	polLister      kyvernov1listers.PolicyLister
 	cpolLister     kyvernov1listers.ClusterPolicyLister
-	admrLister     cache.GenericLister
-	cadmrLister    cache.GenericLister
 	bgscanrLister  cache.GenericLister
 	cbgscanrLister cache.GenericLister
 
@@ -57,7 +62,12 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 
-	chunkSize int
+	// metrics
+	metrics aggregateMetrics
+
+	chunkSize   int
+	perResource bool
+	historySize int
 }
 
 type policyMapEntry struct {
@@ -71,52 +81,43 @@ func keyFunc(obj metav1.Object) cache.ExplicitKey {
 
 func NewController(
 	client versioned.Interface,
+	admissionReports admissionreports.Store,
 	metadataFactory metadatainformers.SharedInformerFactory,
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	metadataCache resource.MetadataCache,
 	chunkSize int,
+	perResource bool,
+	historySize int,
 ) controllers.Controller {
-	admrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("admissionreports"))
-	cadmrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusteradmissionreports"))
 	bgscanrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"))
 	cbgscanrInformer := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"))
 	polrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("policyreports"))
 	cpolrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports"))
 	c := controller{
-		client:         client,
-		polLister:      polInformer.Lister(),
-		cpolLister:     cpolInformer.Lister(),
-		admrLister:     admrInformer.Lister(),
-		cadmrLister:    cadmrInformer.Lister(),
-		bgscanrLister:  bgscanrInformer.Lister(),
-		cbgscanrLister: cbgscanrInformer.Lister(),
-		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
-		metadataCache:  metadataCache,
-		chunkSize:      chunkSize,
+		client:           client,
+		admissionReports: admissionReports,
+		polLister:        polInformer.Lister(),
+		cpolLister:       cpolInformer.Lister(),
+		bgscanrLister:    bgscanrInformer.Lister(),
+		cbgscanrLister:   cbgscanrInformer.Lister(),
+		queue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		metadataCache:    metadataCache,
+		metrics:          newAggregateMetrics(logger),
+		chunkSize:        chunkSize,
+		perResource:      perResource,
+		historySize:      historySize,
 	}
 	controllerutils.AddDelayedExplicitEventHandlers(logger, polrInformer.Informer(), c.queue, enqueueDelay, keyFunc)
 	controllerutils.AddDelayedExplicitEventHandlers(logger, cpolrInformer.Informer(), c.queue, enqueueDelay, keyFunc)
 	controllerutils.AddDelayedExplicitEventHandlers(logger, bgscanrInformer.Informer(), c.queue, enqueueDelay, keyFunc)
 	controllerutils.AddDelayedExplicitEventHandlers(logger, cbgscanrInformer.Informer(), c.queue, enqueueDelay, keyFunc)
-	enqueueFromAdmr := func(obj metav1.Object) {
+	admissionReports.AddEventHandler(func(obj metav1.Object) {
 		// no need to consider non aggregated reports
 		if controllerutils.HasLabel(obj, reportutils.LabelAggregatedReport) {
 			c.queue.AddAfter(keyFunc(obj), enqueueDelay)
 		}
-	}
-	controllerutils.AddEventHandlersT(
-		admrInformer.Informer(),
-		func(obj metav1.Object) { enqueueFromAdmr(obj) },
-		func(_, obj metav1.Object) { enqueueFromAdmr(obj) },
-		func(obj metav1.Object) { enqueueFromAdmr(obj) },
-	)
-	controllerutils.AddEventHandlersT(
-		cadmrInformer.Informer(),
-		func(obj metav1.Object) { enqueueFromAdmr(obj) },
-		func(_, obj metav1.Object) { enqueueFromAdmr(obj) },
-		func(obj metav1.Object) { enqueueFromAdmr(obj) },
-	)
+	})
 	return &c
 }
 
@@ -125,47 +126,17 @@ func (c *controller) Run(ctx context.Context, workers int) {
 }
 
 func (c *controller) mergeAdmissionReports(ctx context.Context, namespace string, policyMap map[string]policyMapEntry, accumulator map[string]policyreportv1alpha2.PolicyReportResult) error {
-	if namespace == "" {
-		next := ""
-		for {
-			cadms, err := c.client.KyvernoV1alpha2().ClusterAdmissionReports().List(ctx, metav1.ListOptions{
-				// no need to consider non aggregated reports
-				LabelSelector: reportutils.LabelAggregatedReport,
-				Limit:         mergeLimit,
-				Continue:      next,
-			})
-			if err != nil {
-				return err
-			}
-			next = cadms.Continue
-			for i := range cadms.Items {
-				mergeReports(policyMap, accumulator, &cadms.Items[i])
-			}
-			if next == "" {
-				return nil
-			}
-		}
-	} else {
-		next := ""
-		for {
-			adms, err := c.client.KyvernoV1alpha2().AdmissionReports(namespace).List(ctx, metav1.ListOptions{
-				// no need to consider non aggregated reports
-				LabelSelector: reportutils.LabelAggregatedReport,
-				Limit:         mergeLimit,
-				Continue:      next,
-			})
-			if err != nil {
-				return err
-			}
-			next = adms.Continue
-			for i := range adms.Items {
-				mergeReports(policyMap, accumulator, &adms.Items[i])
-			}
-			if next == "" {
-				return nil
-			}
-		}
+	// no need to consider non aggregated reports
+	selector, err := labels.Parse(reportutils.LabelAggregatedReport)
+	if err != nil {
+		return err
 	}
+	reports, err := c.admissionReports.List(ctx, namespace, selector)
+	if err != nil {
+		return err
+	}
+	mergeReports(policyMap, accumulator, reports...)
+	return nil
 }
 
 func (c *controller) mergeBackgroundScanReports(ctx context.Context, namespace string, policyMap map[string]policyMapEntry, accumulator map[string]policyreportv1alpha2.PolicyReportResult) error {
@@ -208,9 +179,13 @@ func (c *controller) mergeBackgroundScanReports(ctx context.Context, namespace s
 	}
 }
 
-func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]policyMapEntry, report kyvernov1alpha2.ReportInterface, namespace, name string, results ...policyreportv1alpha2.PolicyReportResult) (kyvernov1alpha2.ReportInterface, error) {
+func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]policyMapEntry, report kyvernov1alpha2.ReportInterface, namespace, name string, scope *corev1.ObjectReference, results ...policyreportv1alpha2.PolicyReportResult) (kyvernov1alpha2.ReportInterface, error) {
 	if report == nil {
-		report = reportutils.NewPolicyReport(namespace, name, results...)
+		if scope != nil {
+			report = reportutils.NewResourcePolicyReport(namespace, *scope, results...)
+		} else {
+			report = reportutils.NewPolicyReport(namespace, name, results...)
+		}
 		for _, result := range results {
 			policy := policyMap[result.Policy]
 			if policy.policy != nil {
@@ -228,6 +203,9 @@ func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]p
 			reportutils.SetPolicyLabel(after, policy.policy)
 		}
 	}
+	if scope != nil {
+		reportutils.SetScope(after, *scope)
+	}
 	reportutils.SetResults(after, results...)
 	if reflect.DeepEqual(report, after) {
 		return after, nil
@@ -235,6 +213,58 @@ func (c *controller) reconcileReport(ctx context.Context, policyMap map[string]p
 	return reportutils.UpdateReport(ctx, after, c.client)
 }
 
+// reconcileSummaryReport reconciles a report without results, holding the summary of the given results
+func (c *controller) reconcileSummaryReport(ctx context.Context, report kyvernov1alpha2.ReportInterface, namespace string, results ...policyreportv1alpha2.PolicyReportResult) (kyvernov1alpha2.ReportInterface, error) {
+	summary := reportutils.CalculateSummary(results)
+	if report == nil {
+		report = reportutils.NewPolicyReport(namespace, summaryReportName)
+		report.SetSummary(summary)
+		return reportutils.CreateReport(ctx, report, c.client)
+	}
+	after := reportutils.DeepCopy(report)
+	after.SetLabels(nil)
+	reportutils.SetManagedByKyvernoLabel(after)
+	reportutils.SetResults(after)
+	after.SetSummary(summary)
+	if reflect.DeepEqual(report, after) {
+		return after, nil
+	}
+	return reportutils.UpdateReport(ctx, after, c.client)
+}
+
+// reconcileResourceReports reconciles one report per resource and a summary report rolling up the results
+func (c *controller) reconcileResourceReports(ctx context.Context, policyMap map[string]policyMapEntry, actual map[string]kyvernov1alpha2.ReportInterface, namespace string, results ...policyreportv1alpha2.PolicyReportResult) ([]kyvernov1alpha2.ReportInterface, error) {
+	var expected []kyvernov1alpha2.ReportInterface
+	for _, resourceResults := range splitResultsByResource(results) {
+		scope := resourceResults[0].Resources[0]
+		report, err := c.reconcileReport(ctx, policyMap, actual[string(scope.UID)], namespace, string(scope.UID), &scope, resourceResults...)
+		if err != nil {
+			return nil, err
+		}
+		expected = append(expected, report)
+	}
+	if len(results) != 0 {
+		report, err := c.reconcileSummaryReport(ctx, actual[summaryReportName], namespace, results...)
+		if err != nil {
+			return nil, err
+		}
+		expected = append(expected, report)
+	}
+	return expected, nil
+}
+
+// splitResultsByResource groups the results by the uid of the resource they apply to
+func splitResultsByResource(results []policyreportv1alpha2.PolicyReportResult) map[types.UID][]policyreportv1alpha2.PolicyReportResult {
+	resultsMap := map[types.UID][]policyreportv1alpha2.PolicyReportResult{}
+	for _, result := range results {
+		if len(result.Resources) == 1 {
+			uid := result.Resources[0].UID
+			resultsMap[uid] = append(resultsMap[uid], result)
+		}
+	}
+	return resultsMap
+}
+
 func (c *controller) cleanReports(ctx context.Context, actual map[string]kyvernov1alpha2.ReportInterface, expected []kyvernov1alpha2.ReportInterface) error {
 	keep := sets.New[string]()
 	for _, obj := range expected {
@@ -372,9 +402,46 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 		return err
 	}
 	actual := map[string]kyvernov1alpha2.ReportInterface{}
+	previous := map[string]policyreportv1alpha2.PolicyReportResult{}
 	for _, report := range policyReports {
 		actual[report.GetName()] = report
+		for _, result := range report.GetResults() {
+			previous[reportutils.ResultKey(result)] = result
+		}
 	}
+	for _, remediation := range reportutils.TrackHistory(previous, c.historySize, results) {
+		logger.V(4).Info("policy violation remediated", "policy", remediation.Policy, "rule", remediation.Rule, "resource", remediation.Resource, "duration", remediation.Duration)
+		c.metrics.recordRemediation(ctx, remediation)
+	}
+	if c.perResource {
+		// shadow policies results are kept in dedicated per policy reports
+		results, shadowResults := reportutils.SplitShadowResults(results)
+		expected, err := c.reconcileResourceReports(ctx, policyMap, actual, key, results...)
+		if err != nil {
+			return err
+		}
+		shadowReports, err := c.reconcilePolicyReports(ctx, logger, policyMap, actual, key, shadowResults)
+		if err != nil {
+			return err
+		}
+		return c.cleanReports(ctx, actual, append(expected, shadowReports...))
+	}
+	expected, err := c.reconcilePolicyReports(ctx, logger, policyMap, actual, key, results)
+	if err != nil {
+		return err
+	}
+	return c.cleanReports(ctx, actual, expected)
+}
+
+// reconcilePolicyReports reconciles one report per policy, split in chunks of the configured size
+func (c *controller) reconcilePolicyReports(
+	ctx context.Context,
+	logger logr.Logger,
+	policyMap map[string]policyMapEntry,
+	actual map[string]kyvernov1alpha2.ReportInterface,
+	key string,
+	results []policyreportv1alpha2.PolicyReportResult,
+) ([]kyvernov1alpha2.ReportInterface, error) {
 	splitReports := reportutils.SplitResultsByPolicy(logger, results)
 	var expected []kyvernov1alpha2.ReportInterface
 	chunkSize := c.chunkSize
@@ -391,12 +458,12 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 			if i > 0 {
 				name = fmt.Sprintf("%s-%d", name, i/chunkSize)
 			}
-			report, err := c.reconcileReport(ctx, policyMap, actual[name], key, name, results[i:end]...)
+			report, err := c.reconcileReport(ctx, policyMap, actual[name], key, name, nil, results[i:end]...)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			expected = append(expected, report)
 		}
 	}
-	return c.cleanReports(ctx, actual, expected)
+	return expected, nil
 }
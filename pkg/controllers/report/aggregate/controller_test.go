@@ -0,0 +1,50 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_reconcileResourceReports(t *testing.T) {
+	deployment := corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "nginx", UID: "uid-deployment"}
+	pod := corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx-1", UID: "uid-pod"}
+	result := func(resource corev1.ObjectReference, rule string, status policyreportv1alpha2.PolicyResult) policyreportv1alpha2.PolicyReportResult {
+		return policyreportv1alpha2.PolicyReportResult{
+			Policy:    "require-labels",
+			Rule:      rule,
+			Result:    status,
+			Resources: []corev1.ObjectReference{resource},
+		}
+	}
+	client := fake.NewSimpleClientset()
+	c := controller{client: client, perResource: true}
+	expected, err := c.reconcileResourceReports(
+		context.TODO(),
+		nil,
+		map[string]kyvernov1alpha2.ReportInterface{},
+		"default",
+		result(deployment, "check-team", policyreportv1alpha2.StatusFail),
+		result(deployment, "autogen-check-team", policyreportv1alpha2.StatusPass),
+		result(pod, "check-team", policyreportv1alpha2.StatusFail),
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, len(expected), 3)
+	report, err := client.Wgpolicyk8sV1alpha2().PolicyReports("default").Get(context.TODO(), "uid-deployment", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, report.Scope, &deployment)
+	assert.Equal(t, len(report.OwnerReferences), 1)
+	assert.Equal(t, report.OwnerReferences[0].UID, deployment.UID)
+	assert.Equal(t, len(report.Results), 2)
+	assert.DeepEqual(t, report.Summary, policyreportv1alpha2.PolicyReportSummary{Pass: 1, Fail: 1})
+	summary, err := client.Wgpolicyk8sV1alpha2().PolicyReports("default").Get(context.TODO(), summaryReportName, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(summary.Results), 0)
+	assert.DeepEqual(t, summary.Summary, policyreportv1alpha2.PolicyReportSummary{Pass: 1, Fail: 2})
+}
@@ -0,0 +1,43 @@
+package aggregate
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+)
+
+type aggregateMetrics struct {
+	remediationDuration syncfloat64.Histogram
+}
+
+func newAggregateMetrics(logger logr.Logger) aggregateMetrics {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	remediationDuration, err := meter.SyncFloat64().Histogram(
+		"kyverno_policy_violation_remediation_duration_seconds",
+		instrument.WithDescription("can be used to track the time policy violations stayed open before being remediated"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_violation_remediation_duration_seconds")
+	}
+	return aggregateMetrics{
+		remediationDuration: remediationDuration,
+	}
+}
+
+func (m aggregateMetrics) recordRemediation(ctx context.Context, remediation reportutils.Remediation) {
+	if m.remediationDuration != nil {
+		m.remediationDuration.Record(
+			ctx,
+			remediation.Duration.Seconds(),
+			attribute.String("policy_name", remediation.Policy),
+			attribute.String("rule_name", remediation.Rule),
+			attribute.String("resource_kind", remediation.Resource.Kind),
+			attribute.String("resource_namespace", remediation.Resource.Namespace),
+		)
+	}
+}
@@ -19,13 +19,16 @@ import (
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/exporter"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
@@ -40,6 +43,8 @@ const (
 	maxRetries             = 10
 	annotationLastScanTime = "audit.kyverno.io/last-scan-time"
 	enqueueDelay           = 30 * time.Second
+	// rescanJitter spreads periodic rescans over a fraction of the background scan interval
+	rescanJitter = 0.1
 )
 
 type controller struct {
@@ -56,7 +61,8 @@ type controller struct {
 	nsLister       corev1listers.NamespaceLister
 
 	// queue
-	queue workqueue.RateLimitingInterface
+	queue      workqueue.RateLimitingInterface
+	priorities *priorityQueue
 
 	// cache
 	metadataCache          resource.MetadataCache
@@ -66,6 +72,11 @@ type controller struct {
 	// config
 	config   config.Configuration
 	eventGen event.Interface
+	exporter exporter.Interface
+
+	// scheduling
+	shard     Shard
+	nsLimiter *namespaceLimiter
 }
 
 func NewController(
@@ -81,10 +92,14 @@ func NewController(
 	forceDelay time.Duration,
 	config config.Configuration,
 	eventGen event.Interface,
+	exporter exporter.Interface,
+	shard Shard,
+	namespaceQPS float64,
+	namespaceBurst int,
 ) controllers.Controller {
 	bgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("backgroundscanreports"))
 	cbgscanr := metadataFactory.ForResource(kyvernov1alpha2.SchemeGroupVersion.WithResource("clusterbackgroundscanreports"))
-	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
+	queue, priorities := newPriorityRateLimitingQueue(ControllerName)
 	c := controller{
 		client:                 client,
 		kyvernoClient:          kyvernoClient,
@@ -95,11 +110,15 @@ func NewController(
 		cbgscanrLister:         cbgscanr.Lister(),
 		nsLister:               nsInformer.Lister(),
 		queue:                  queue,
+		priorities:             priorities,
 		metadataCache:          metadataCache,
 		informerCacheResolvers: informerCacheResolvers,
 		forceDelay:             forceDelay,
 		config:                 config,
 		eventGen:               eventGen,
+		exporter:               exporter,
+		shard:                  shard,
+		nsLimiter:              newNamespaceLimiter(namespaceQPS, namespaceBurst),
 	}
 	controllerutils.AddDefaultEventHandlers(logger, bgscanr.Informer(), queue)
 	controllerutils.AddDefaultEventHandlers(logger, cbgscanr.Informer(), queue)
@@ -110,36 +129,51 @@ func NewController(
 		if eventType == resource.Deleted {
 			return
 		}
-		if res.Namespace == "" {
-			c.queue.AddAfter(string(uid), enqueueDelay)
-		} else {
-			c.queue.AddAfter(res.Namespace+"/"+string(uid), enqueueDelay)
+		if !c.shard.Owns(res.Namespace) {
+			return
 		}
+		key := string(uid)
+		if res.Namespace != "" {
+			key = res.Namespace + "/" + key
+		}
+		c.priorities.Prioritize(key, priorityResourceChanged)
+		c.queue.AddAfter(key, enqueueDelay)
 	})
 	return &c
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	logger.Info("background scan", "interval", c.forceDelay.Abs().String())
+	logger.Info("background scan", "interval", c.forceDelay.Abs().String(), "shard", c.shard.Index, "shards", c.shard.Count)
 	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
 func (c *controller) addPolicy(obj kyvernov1.PolicyInterface) {
-	c.enqueueResources()
+	c.enqueueResources(obj)
 }
 
 func (c *controller) updatePolicy(old, obj kyvernov1.PolicyInterface) {
 	if old.GetResourceVersion() != obj.GetResourceVersion() {
-		c.enqueueResources()
+		c.enqueueResources(obj)
 	}
 }
 
 func (c *controller) deletePolicy(obj kyvernov1.PolicyInterface) {
-	c.enqueueResources()
+	c.enqueueResources(obj)
 }
 
-func (c *controller) enqueueResources() {
+// enqueueResources enqueues the resources owned by the shard a policy can apply to,
+// namespaced policies only apply to the resources in their namespace
+func (c *controller) enqueueResources(policy kyvernov1.PolicyInterface) {
+	namespace := policy.GetNamespace()
 	for _, key := range c.metadataCache.GetAllResourceKeys() {
+		ns, _, _ := cache.SplitMetaNamespaceKey(key)
+		if namespace != "" && ns != namespace {
+			continue
+		}
+		if !c.shard.Owns(ns) {
+			continue
+		}
+		c.priorities.Prioritize(key, priorityPolicyChanged)
 		c.queue.Add(key)
 	}
 }
@@ -283,6 +317,8 @@ func (c *controller) reconcileReport(
 		}
 	}
 	var ruleResults []policyreportv1alpha2.PolicyReportResult
+	// results computed by this scan
+	var scanned []policyreportv1alpha2.PolicyReportResult
 	if !full {
 		policyNameToLabel := map[string]string{}
 		for _, policy := range backgroundPolicies {
@@ -309,12 +345,23 @@ func (c *controller) reconcileReport(
 				if result.Error != nil {
 					return result.Error
 				} else {
-					ruleResults = append(ruleResults, reportutils.EngineResponseToReportResults(result.EngineResponse)...)
+					results := reportutils.EngineResponseToReportResults(result.EngineResponse)
+					ruleResults = append(ruleResults, results...)
+					scanned = append(scanned, results...)
 					utils.GenerateEvents(logger, c.eventGen, c.config, result.EngineResponse)
 				}
 			}
 		}
 	}
+	if c.exporter != nil && len(scanned) != 0 {
+		c.exporter.Export(exporter.SourceBackgroundScan, corev1.ObjectReference{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  resource.Namespace,
+			Name:       resource.Name,
+			UID:        uid,
+		}, scanned...)
+	}
 	desired := reportutils.DeepCopy(observed)
 	for key := range desired.GetLabels() {
 		if reportutils.IsPolicyLabel(key) {
@@ -353,6 +400,10 @@ func (c *controller) reconcileReport(
 }
 
 func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namespace, name string) error {
+	// resources in namespaces owned by other shards are scanned by other replicas
+	if !c.shard.Owns(namespace) {
+		return nil
+	}
 	// try to find resource from the cache
 	uid := types.UID(name)
 	resource, gvk, exists := c.metadataCache.GetResourceHash(uid)
@@ -393,13 +444,16 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 	// we have the resource, check if we need to reconcile
 	if needsReconcile, full, err := c.needsReconcile(namespace, name, resource.Hash, backgroundPolicies...); err != nil {
 		return err
-	} else {
-		defer func() {
-			c.queue.AddAfter(key, c.forceDelay)
-		}()
-		if needsReconcile {
-			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, resource, backgroundPolicies...)
+	} else if needsReconcile {
+		// enforce the namespace rate limit, keeping the priority of the resource
+		if delay := c.nsLimiter.Delay(namespace, time.Now()); delay > 0 {
+			c.priorities.Prioritize(key, c.priorities.Priority(key))
+			c.queue.AddAfter(key, delay)
+			return nil
 		}
+		defer c.queue.AddAfter(key, wait.Jitter(c.forceDelay, rescanJitter))
+		return c.reconcileReport(ctx, namespace, name, full, uid, gvk, resource, backgroundPolicies...)
 	}
+	c.queue.AddAfter(key, wait.Jitter(c.forceDelay, rescanJitter))
 	return nil
 }
@@ -0,0 +1,173 @@
+package background
+
+import (
+	"sync"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// priorityRescan is the priority of periodic rescans
+	priorityRescan = iota
+	// priorityPolicyChanged is the priority of resources impacted by a policy change
+	priorityPolicyChanged
+	// priorityResourceChanged is the priority of resources that changed
+	priorityResourceChanged
+	priorityLevels
+)
+
+// priorityQueue is a workqueue.Interface handing out items with the highest priority first,
+// in FIFO order within a priority. Like the default workqueue, an item is never processed
+// concurrently and an item added while being processed is queued again once done.
+type priorityQueue struct {
+	cond *sync.Cond
+	// levels holds the queued items per priority, an item can be present in a lower level
+	// after being promoted, queued tells the level it really belongs to
+	levels [priorityLevels][]interface{}
+	// queued holds the priority of the items waiting to be processed
+	queued map[interface{}]int
+	// pending holds the priority given to items the next time they are added
+	pending map[interface{}]int
+	// processing holds the priority of the items being processed
+	processing map[interface{}]int
+	// dirty holds the priority of the items added while being processed
+	dirty        map[interface{}]int
+	shuttingDown bool
+	drain        bool
+}
+
+func newPriorityQueue() *priorityQueue {
+	return &priorityQueue{
+		cond:       sync.NewCond(&sync.Mutex{}),
+		queued:     map[interface{}]int{},
+		pending:    map[interface{}]int{},
+		processing: map[interface{}]int{},
+		dirty:      map[interface{}]int{},
+	}
+}
+
+// Prioritize sets the priority used the next time the item is added, the highest priority wins
+// when called several times
+func (q *priorityQueue) Prioritize(item interface{}, priority int) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if current, ok := q.pending[item]; !ok || current < priority {
+		q.pending[item] = priority
+	}
+}
+
+// Priority returns the priority of an item being processed or waiting to be processed
+func (q *priorityQueue) Priority(item interface{}) int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if priority, ok := q.processing[item]; ok {
+		return priority
+	}
+	return q.queued[item]
+}
+
+func (q *priorityQueue) Add(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	priority := q.pending[item]
+	delete(q.pending, item)
+	if _, ok := q.processing[item]; ok {
+		if current, ok := q.dirty[item]; !ok || current < priority {
+			q.dirty[item] = priority
+		}
+		return
+	}
+	q.push(item, priority)
+}
+
+func (q *priorityQueue) push(item interface{}, priority int) {
+	if current, ok := q.queued[item]; ok && current >= priority {
+		return
+	}
+	q.queued[item] = priority
+	q.levels[priority] = append(q.levels[priority], item)
+	q.cond.Signal()
+}
+
+func (q *priorityQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.queued)
+}
+
+func (q *priorityQueue) Get() (interface{}, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.queued) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.queued) == 0 {
+		return nil, true
+	}
+	for priority := priorityLevels - 1; priority >= 0; priority-- {
+		for len(q.levels[priority]) != 0 {
+			item := q.levels[priority][0]
+			q.levels[priority][0] = nil
+			q.levels[priority] = q.levels[priority][1:]
+			// skip items promoted to a higher level
+			if current, ok := q.queued[item]; ok && current == priority {
+				delete(q.queued, item)
+				q.processing[item] = priority
+				return item, false
+			}
+		}
+	}
+	// unreachable, queued items are always present in their level
+	return nil, true
+}
+
+func (q *priorityQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	delete(q.processing, item)
+	if priority, ok := q.dirty[item]; ok {
+		delete(q.dirty, item)
+		q.push(item, priority)
+	} else if len(q.processing) == 0 {
+		// wake up ShutDownWithDrain
+		q.cond.Broadcast()
+	}
+}
+
+func (q *priorityQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = false
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+func (q *priorityQueue) ShutDownWithDrain() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = true
+	q.shuttingDown = true
+	q.cond.Broadcast()
+	for len(q.processing) != 0 && q.drain {
+		q.cond.Wait()
+	}
+}
+
+func (q *priorityQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
+
+// newPriorityRateLimitingQueue creates a rate limiting queue backed by a priority queue
+func newPriorityRateLimitingQueue(name string) (workqueue.RateLimitingInterface, *priorityQueue) {
+	priorities := newPriorityQueue()
+	queue := workqueue.NewRateLimitingQueueWithDelayingInterface(
+		workqueue.NewDelayingQueueWithCustomQueue(priorities, name),
+		workqueue.DefaultControllerRateLimiter(),
+	)
+	return queue, priorities
+}
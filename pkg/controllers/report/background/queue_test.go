@@ -0,0 +1,49 @@
+package background
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_priorityQueue(t *testing.T) {
+	q := newPriorityQueue()
+	q.Add("rescan-1")
+	q.Add("rescan-2")
+	q.Prioritize("policy", priorityPolicyChanged)
+	q.Add("policy")
+	q.Prioritize("resource", priorityResourceChanged)
+	q.Add("resource")
+	// promoting a queued item
+	q.Prioritize("rescan-2", priorityResourceChanged)
+	q.Add("rescan-2")
+	assert.Equal(t, q.Len(), 4)
+	var order []interface{}
+	for q.Len() != 0 {
+		item, shutdown := q.Get()
+		assert.Assert(t, !shutdown)
+		order = append(order, item)
+		q.Done(item)
+	}
+	assert.DeepEqual(t, order, []interface{}{"resource", "rescan-2", "policy", "rescan-1"})
+}
+
+func Test_priorityQueue_Processing(t *testing.T) {
+	q := newPriorityQueue()
+	q.Add("item")
+	item, _ := q.Get()
+	assert.Equal(t, q.Priority(item), priorityRescan)
+	// adding an item being processed queues it again once done, with the highest priority
+	q.Prioritize("item", priorityPolicyChanged)
+	q.Add("item")
+	q.Add("item")
+	assert.Equal(t, q.Len(), 0)
+	q.Done(item)
+	assert.Equal(t, q.Len(), 1)
+	item, _ = q.Get()
+	assert.Equal(t, q.Priority(item), priorityPolicyChanged)
+	q.Done(item)
+	q.ShutDown()
+	_, shutdown := q.Get()
+	assert.Assert(t, shutdown)
+}
@@ -0,0 +1,99 @@
+package background
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Shard identifies the namespaces a replica scans when background scanning is sharded
+type Shard struct {
+	// Count is the total number of shards, zero or one disables sharding
+	Count int
+	// Index is the shard owned by this replica
+	Index int
+}
+
+// NewShard creates a shard, when index is negative it is taken from the ordinal suffix
+// of the pod name, as set by a StatefulSet
+func NewShard(count, index int, podName string) (Shard, error) {
+	if count <= 1 {
+		return Shard{}, nil
+	}
+	if index < 0 {
+		i := strings.LastIndex(podName, "-")
+		if i < 0 {
+			return Shard{}, fmt.Errorf("failed to compute shard index from pod name %s", podName)
+		}
+		ordinal, err := strconv.Atoi(podName[i+1:])
+		if err != nil {
+			return Shard{}, fmt.Errorf("failed to compute shard index from pod name %s: %w", podName, err)
+		}
+		index = ordinal
+	}
+	if index >= count {
+		return Shard{}, fmt.Errorf("shard index %d is out of range, there are %d shards", index, count)
+	}
+	return Shard{Count: count, Index: index}, nil
+}
+
+// Owns returns true if the shard is responsible for the given namespace,
+// cluster wide resources belong to the first shard
+func (s Shard) Owns(namespace string) bool {
+	if s.Count <= 1 {
+		return true
+	}
+	if namespace == "" {
+		return s.Index == 0
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(namespace))
+	return int(hash.Sum32()%uint32(s.Count)) == s.Index
+}
+
+// namespaceLimiter limits the rate of scans per namespace
+type namespaceLimiter struct {
+	lock     sync.Mutex
+	qps      rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+}
+
+func newNamespaceLimiter(qps float64, burst int) *namespaceLimiter {
+	if qps <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &namespaceLimiter{
+		qps:      rate.Limit(qps),
+		burst:    burst,
+		limiters: map[string]*rate.Limiter{},
+	}
+}
+
+// Delay returns how long a scan in the namespace has to wait, a zero delay consumes a token
+func (l *namespaceLimiter) Delay(namespace string, now time.Time) time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.lock.Lock()
+	limiter := l.limiters[namespace]
+	if limiter == nil {
+		limiter = rate.NewLimiter(l.qps, l.burst)
+		l.limiters[namespace] = limiter
+	}
+	l.lock.Unlock()
+	reservation := limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		reservation.CancelAt(now)
+	}
+	return delay
+}
@@ -0,0 +1,53 @@
+package background
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestNewShard(t *testing.T) {
+	shard, err := NewShard(1, -1, "kyverno-reports-controller-abcde")
+	assert.NilError(t, err)
+	assert.Assert(t, shard.Owns("default"))
+	shard, err = NewShard(3, -1, "kyverno-reports-controller-2")
+	assert.NilError(t, err)
+	assert.Equal(t, shard, Shard{Count: 3, Index: 2})
+	_, err = NewShard(3, -1, "kyverno-reports-controller-abcde")
+	assert.Assert(t, err != nil)
+	_, err = NewShard(3, 3, "")
+	assert.Assert(t, err != nil)
+}
+
+func TestShard_Owns(t *testing.T) {
+	shards := []Shard{{Count: 3, Index: 0}, {Count: 3, Index: 1}, {Count: 3, Index: 2}}
+	assert.Assert(t, shards[0].Owns(""))
+	assert.Assert(t, !shards[1].Owns(""))
+	for i := 0; i < 100; i++ {
+		namespace := fmt.Sprintf("namespace-%d", i)
+		owners := 0
+		for _, shard := range shards {
+			if shard.Owns(namespace) {
+				owners++
+			}
+		}
+		assert.Equal(t, owners, 1, namespace)
+	}
+}
+
+func Test_namespaceLimiter(t *testing.T) {
+	var disabled *namespaceLimiter = newNamespaceLimiter(0, 1)
+	assert.Equal(t, disabled.Delay("default", time.Now()), time.Duration(0))
+	limiter := newNamespaceLimiter(1, 2)
+	now := time.Now()
+	assert.Equal(t, limiter.Delay("default", now), time.Duration(0))
+	assert.Equal(t, limiter.Delay("default", now), time.Duration(0))
+	assert.Assert(t, limiter.Delay("default", now) > 0)
+	// a delayed scan does not consume a token
+	assert.Assert(t, limiter.Delay("default", now) <= time.Second)
+	// namespaces are limited independently
+	assert.Equal(t, limiter.Delay("other", now), time.Duration(0))
+	assert.Equal(t, limiter.Delay("default", now.Add(time.Second)), time.Duration(0))
+}
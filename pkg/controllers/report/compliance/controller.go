@@ -0,0 +1,134 @@
+package compliance
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	policyreportv1alpha2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policyreport/v1alpha2"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	policyreportv1alpha2listers "github.com/kyverno/kyverno/pkg/client/listers/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "compliance-report-controller"
+	maxRetries     = 10
+	enqueueDelay   = 30 * time.Second
+)
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	cfwLister   kyvernov2alpha1listers.ComplianceFrameworkLister
+	crLister    kyvernov2alpha1listers.ComplianceReportLister
+	polrLister  policyreportv1alpha2listers.PolicyReportLister
+	cpolrLister policyreportv1alpha2listers.ClusterPolicyReportLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+// NewController creates the compliance report controller, it computes the compliance scores of each
+// compliance framework from the policy reports and publishes them in a compliance report
+func NewController(
+	client versioned.Interface,
+	cfwInformer kyvernov2alpha1informers.ComplianceFrameworkInformer,
+	crInformer kyvernov2alpha1informers.ComplianceReportInformer,
+	polrInformer policyreportv1alpha2informers.PolicyReportInformer,
+	cpolrInformer policyreportv1alpha2informers.ClusterPolicyReportInformer,
+) controllers.Controller {
+	c := controller{
+		client:      client,
+		cfwLister:   cfwInformer.Lister(),
+		crLister:    crInformer.Lister(),
+		polrLister:  polrInformer.Lister(),
+		cpolrLister: cpolrInformer.Lister(),
+		queue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+	}
+	controllerutils.AddDefaultEventHandlers(logger, cfwInformer.Informer(), c.queue)
+	// compliance reports are named after their framework
+	controllerutils.AddDefaultEventHandlers(logger, crInformer.Informer(), c.queue)
+	// reports change often, frameworks are recomputed after a delay to batch the changes
+	enqueueAll := func(interface{}) {
+		frameworks, err := c.cfwLister.List(labels.Everything())
+		if err != nil {
+			logger.Error(err, "failed to list compliance frameworks")
+			return
+		}
+		for _, framework := range frameworks {
+			c.queue.AddAfter(framework.Name, enqueueDelay)
+		}
+	}
+	updateAll := func(_, obj interface{}) { enqueueAll(obj) }
+	controllerutils.AddEventHandlers(polrInformer.Informer(), enqueueAll, updateAll, enqueueAll)
+	controllerutils.AddEventHandlers(cpolrInformer.Informer(), enqueueAll, updateAll, enqueueAll)
+	registerMetrics(c.crLister)
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, name string) error {
+	framework, err := c.cfwLister.Get(name)
+	if err != nil {
+		// the compliance report is garbage collected with its framework
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	results, err := c.listResults()
+	if err != nil {
+		return err
+	}
+	_, err = controllerutils.CreateOrUpdate(
+		ctx,
+		name,
+		c.crLister,
+		c.client.KyvernoV2alpha1().ComplianceReports(),
+		func(report *kyvernov2alpha1.ComplianceReport) error {
+			controllerutils.SetManagedByKyvernoLabel(report)
+			controllerutils.SetOwner(report, kyvernov2alpha1.SchemeGroupVersion.String(), "ComplianceFramework", framework.Name, framework.UID)
+			buildReport(framework, results, report)
+			return nil
+		},
+	)
+	return err
+}
+
+// listResults returns the results of the policy reports keyed by namespace,
+// the results of the cluster policy reports are keyed with an empty namespace
+func (c *controller) listResults() (map[string][]policyreportv1alpha2.PolicyReportResult, error) {
+	results := map[string][]policyreportv1alpha2.PolicyReportResult{}
+	polrs, err := c.polrLister.PolicyReports(metav1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, polr := range polrs {
+		results[polr.Namespace] = append(results[polr.Namespace], polr.Results...)
+	}
+	cpolrs, err := c.cpolrLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, cpolr := range cpolrs {
+		results[""] = append(results[""], cpolr.Results...)
+	}
+	return results, nil
+}
@@ -0,0 +1,75 @@
+package compliance
+
+import (
+	"context"
+
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type complianceMetrics struct {
+	score          asyncint64.Gauge
+	namespaceScore asyncint64.Gauge
+	crLister       kyvernov2alpha1listers.ComplianceReportLister
+}
+
+// registerMetrics registers the gauges reporting the scores of the compliance reports
+func registerMetrics(crLister kyvernov2alpha1listers.ComplianceReportLister) {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	score, err := meter.AsyncInt64().Gauge(
+		"kyverno_compliance_score",
+		instrument.WithDescription("can be used to track the percentage of passing controls of a compliance framework in the whole cluster"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_compliance_score")
+		return
+	}
+	namespaceScore, err := meter.AsyncInt64().Gauge(
+		"kyverno_compliance_namespace_score",
+		instrument.WithDescription("can be used to track the percentage of passing controls of a compliance framework in a namespace, cluster wide resources have an empty namespace"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_compliance_namespace_score")
+		return
+	}
+	m := complianceMetrics{
+		score:          score,
+		namespaceScore: namespaceScore,
+		crLister:       crLister,
+	}
+	if err := meter.RegisterCallback([]instrument.Asynchronous{score, namespaceScore}, m.report); err != nil {
+		logger.Error(err, "Failed to register callback")
+	}
+}
+
+func (m complianceMetrics) report(ctx context.Context) {
+	reports, err := m.crLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list compliance reports")
+		return
+	}
+	for _, report := range reports {
+		m.score.Observe(
+			ctx,
+			int64(report.Summary.Score),
+			attribute.String("framework", report.Framework),
+			attribute.String("framework_version", report.Version),
+			attribute.String("report_name", report.Name),
+		)
+		for _, namespace := range report.Namespaces {
+			m.namespaceScore.Observe(
+				ctx,
+				int64(namespace.Score.Score),
+				attribute.String("framework", report.Framework),
+				attribute.String("framework_version", report.Version),
+				attribute.String("report_name", report.Name),
+				attribute.String("resource_namespace", namespace.Namespace),
+			)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package compliance
+
+import (
+	"sort"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+)
+
+// controlCounts counts the passing and failing results of a control
+type controlCounts struct {
+	pass int
+	fail int
+}
+
+func (c controlCounts) add(other controlCounts) controlCounts {
+	return controlCounts{pass: c.pass + other.pass, fail: c.fail + other.fail}
+}
+
+func (c controlCounts) status() kyvernov2alpha1.ControlStatus {
+	if c.fail > 0 {
+		return kyvernov2alpha1.ControlFail
+	}
+	if c.pass > 0 {
+		return kyvernov2alpha1.ControlPass
+	}
+	return kyvernov2alpha1.ControlNotEvaluated
+}
+
+// countResults counts the results checking a control, skipped, warning, not scored and shadow policies results are ignored
+func countResults(control kyvernov2alpha1.ComplianceControl, results []policyreportv1alpha2.PolicyReportResult) controlCounts {
+	var counts controlCounts
+	for _, result := range results {
+		if !result.Scored || reportutils.IsShadowResult(result) || !control.Matches(result.Policy, result.Rule) {
+			continue
+		}
+		switch result.Result {
+		case policyreportv1alpha2.StatusPass:
+			counts.pass++
+		case policyreportv1alpha2.StatusFail, policyreportv1alpha2.StatusError:
+			counts.fail++
+		}
+	}
+	return counts
+}
+
+// newScore computes the score of a list of control statuses, the score is 0 when no control was evaluated
+func newScore(statuses ...kyvernov2alpha1.ControlStatus) kyvernov2alpha1.ComplianceScore {
+	var score kyvernov2alpha1.ComplianceScore
+	for _, status := range statuses {
+		switch status {
+		case kyvernov2alpha1.ControlPass:
+			score.Pass++
+		case kyvernov2alpha1.ControlFail:
+			score.Fail++
+		default:
+			score.NotEvaluated++
+		}
+	}
+	if evaluated := score.Pass + score.Fail; evaluated > 0 {
+		score.Score = score.Pass * 100 / evaluated
+	}
+	return score
+}
+
+// buildReport fills a compliance report from the policy report results of each namespace, the results of
+// cluster policy reports are keyed with an empty namespace. Namespaces without results for the framework
+// controls are left out.
+func buildReport(framework *kyvernov2alpha1.ComplianceFramework, results map[string][]policyreportv1alpha2.PolicyReportResult, report *kyvernov2alpha1.ComplianceReport) {
+	namespaces := make([]string, 0, len(results))
+	for namespace := range results {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	controls := framework.Spec.Controls
+	totals := make([]controlCounts, len(controls))
+	report.Framework = framework.Spec.Framework
+	report.Version = framework.Spec.Version
+	report.Namespaces = nil
+	for _, namespace := range namespaces {
+		var statuses []kyvernov2alpha1.ControlStatus
+		var failed []string
+		evaluated := false
+		for i, control := range controls {
+			counts := countResults(control, results[namespace])
+			totals[i] = totals[i].add(counts)
+			status := counts.status()
+			if status != kyvernov2alpha1.ControlNotEvaluated {
+				evaluated = true
+			}
+			if status == kyvernov2alpha1.ControlFail {
+				failed = append(failed, control.ID)
+			}
+			statuses = append(statuses, status)
+		}
+		if evaluated {
+			report.Namespaces = append(report.Namespaces, kyvernov2alpha1.NamespaceCompliance{
+				Namespace:      namespace,
+				Score:          newScore(statuses...),
+				FailedControls: failed,
+			})
+		}
+	}
+	report.Controls = nil
+	var statuses []kyvernov2alpha1.ControlStatus
+	for i, control := range controls {
+		status := totals[i].status()
+		statuses = append(statuses, status)
+		report.Controls = append(report.Controls, kyvernov2alpha1.ControlCompliance{
+			ID:     control.ID,
+			Title:  control.Title,
+			Status: status,
+			Pass:   totals[i].pass,
+			Fail:   totals[i].fail,
+		})
+	}
+	report.Summary = newScore(statuses...)
+}
@@ -0,0 +1,114 @@
+package compliance
+
+import (
+	"testing"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+)
+
+func Test_buildReport(t *testing.T) {
+	result := func(policy, rule string, status policyreportv1alpha2.PolicyResult) policyreportv1alpha2.PolicyReportResult {
+		return policyreportv1alpha2.PolicyReportResult{
+			Policy: policy,
+			Rule:   rule,
+			Result: status,
+			Scored: true,
+		}
+	}
+	framework := &kyvernov2alpha1.ComplianceFramework{
+		Spec: kyvernov2alpha1.ComplianceFrameworkSpec{
+			Framework: "PSS",
+			Version:   "v1.25",
+			Controls: []kyvernov2alpha1.ComplianceControl{{
+				ID:       "host-path",
+				Policies: []kyvernov2alpha1.ComplianceControlPolicy{{Name: "disallow-host-path"}},
+			}, {
+				ID:       "privileged",
+				Title:    "Privileged containers",
+				Policies: []kyvernov2alpha1.ComplianceControlPolicy{{Name: "disallow-privileged", Rules: []string{"check"}}},
+			}, {
+				ID:       "capabilities",
+				Policies: []kyvernov2alpha1.ComplianceControlPolicy{{Name: "disallow-capabilities"}},
+			}},
+		},
+	}
+	results := map[string][]policyreportv1alpha2.PolicyReportResult{
+		"default": {
+			result("disallow-host-path", "check", policyreportv1alpha2.StatusPass),
+			result("disallow-privileged", "autogen-check", policyreportv1alpha2.StatusFail),
+			result("disallow-privileged", "other", policyreportv1alpha2.StatusFail),
+		},
+		"kube-system": {
+			result("disallow-host-path", "check", policyreportv1alpha2.StatusPass),
+			result("disallow-privileged", "check", policyreportv1alpha2.StatusPass),
+			result("disallow-capabilities", "check", policyreportv1alpha2.StatusSkip),
+		},
+		"unrelated": {
+			result("require-labels", "check", policyreportv1alpha2.StatusFail),
+		},
+		"": {
+			{Policy: "disallow-host-path", Rule: "check", Result: policyreportv1alpha2.StatusError},
+		},
+	}
+	var report kyvernov2alpha1.ComplianceReport
+	buildReport(framework, results, &report)
+	assert.DeepEqual(t, report, kyvernov2alpha1.ComplianceReport{
+		Framework: "PSS",
+		Version:   "v1.25",
+		Summary:   kyvernov2alpha1.ComplianceScore{Score: 50, Pass: 1, Fail: 1, NotEvaluated: 1},
+		Controls: []kyvernov2alpha1.ControlCompliance{
+			{ID: "host-path", Status: kyvernov2alpha1.ControlPass, Pass: 2},
+			{ID: "privileged", Title: "Privileged containers", Status: kyvernov2alpha1.ControlFail, Pass: 1, Fail: 1},
+			{ID: "capabilities", Status: kyvernov2alpha1.ControlNotEvaluated},
+		},
+		Namespaces: []kyvernov2alpha1.NamespaceCompliance{{
+			Namespace:      "default",
+			Score:          kyvernov2alpha1.ComplianceScore{Score: 50, Pass: 1, Fail: 1, NotEvaluated: 1},
+			FailedControls: []string{"privileged"},
+		}, {
+			Namespace: "kube-system",
+			Score:     kyvernov2alpha1.ComplianceScore{Score: 100, Pass: 2, NotEvaluated: 1},
+		}},
+	})
+	assert.Equal(t, newScore().Score, 0)
+}
+
+func Test_buildReport_NotEvaluated(t *testing.T) {
+	framework := &kyvernov2alpha1.ComplianceFramework{
+		Spec: kyvernov2alpha1.ComplianceFrameworkSpec{
+			Framework: "PSS",
+			Controls: []kyvernov2alpha1.ComplianceControl{
+				{ID: "host-path", Policies: []kyvernov2alpha1.ComplianceControlPolicy{{Name: "disallow-host-path"}}},
+			},
+		},
+	}
+	results := map[string][]policyreportv1alpha2.PolicyReportResult{
+		"default": {{Policy: "require-labels", Rule: "check-labels", Result: policyreportv1alpha2.StatusPass, Scored: true}},
+	}
+	var report kyvernov2alpha1.ComplianceReport
+	buildReport(framework, results, &report)
+	// a framework without matching results is not reported as compliant
+	assert.DeepEqual(t, report.Summary, kyvernov2alpha1.ComplianceScore{Score: 0, NotEvaluated: 1})
+	assert.Equal(t, len(report.Namespaces), 0)
+}
+
+func Test_countResults_Shadow(t *testing.T) {
+	control := kyvernov2alpha1.ComplianceControl{
+		ID:       "host-path",
+		Policies: []kyvernov2alpha1.ComplianceControlPolicy{{Name: "disallow-host-path"}},
+	}
+	results := []policyreportv1alpha2.PolicyReportResult{
+		{Policy: "disallow-host-path", Rule: "check", Result: policyreportv1alpha2.StatusPass, Scored: true},
+		{
+			Policy:     "disallow-host-path",
+			Rule:       "check",
+			Result:     policyreportv1alpha2.StatusFail,
+			Scored:     true,
+			Properties: map[string]string{reportutils.ShadowProperty: "true"},
+		},
+	}
+	assert.Equal(t, countResults(control, results), controlCounts{pass: 1})
+}
@@ -0,0 +1,191 @@
+package exception
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	policyreportv1alpha2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policyreport/v1alpha2"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "exception-usage-controller"
+	maxRetries     = 10
+	enqueueDelay   = 30 * time.Second
+	// exceptionsIndex is the name of the reports informers index holding the exceptions used by each report
+	exceptionsIndex = "exceptions"
+)
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	polexLister kyvernov2alpha1listers.PolicyExceptionLister
+
+	// indexers
+	polrIndexer  cache.Indexer
+	cpolrIndexer cache.Indexer
+
+	// queue
+	queue workqueue.RateLimitingInterface
+}
+
+// NewController creates the exception usage controller, it aggregates the policy report results relying
+// on each policy exception in the exception status
+func NewController(
+	client versioned.Interface,
+	polexInformer kyvernov2alpha1informers.PolicyExceptionInformer,
+	polrInformer policyreportv1alpha2informers.PolicyReportInformer,
+	cpolrInformer policyreportv1alpha2informers.ClusterPolicyReportInformer,
+) controllers.Controller {
+	indexers := cache.Indexers{exceptionsIndex: exceptionsIndexFunc}
+	if err := polrInformer.Informer().AddIndexers(indexers); err != nil {
+		logger.Error(err, "failed to add policy reports indexer")
+	}
+	if err := cpolrInformer.Informer().AddIndexers(indexers); err != nil {
+		logger.Error(err, "failed to add cluster policy reports indexer")
+	}
+	c := controller{
+		client:       client,
+		polexLister:  polexInformer.Lister(),
+		polrIndexer:  polrInformer.Informer().GetIndexer(),
+		cpolrIndexer: cpolrInformer.Informer().GetIndexer(),
+		queue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+	}
+	controllerutils.AddDefaultEventHandlers(logger, polexInformer.Informer(), c.queue)
+	enqueueFromReport := func(obj interface{}) {
+		keys, err := exceptionsIndexFunc(obj)
+		if err != nil {
+			logger.Error(err, "failed to get report exceptions")
+			return
+		}
+		for _, key := range keys {
+			c.queue.AddAfter(key, enqueueDelay)
+		}
+	}
+	for _, informer := range []cache.SharedIndexInformer{polrInformer.Informer(), cpolrInformer.Informer()} {
+		controllerutils.AddEventHandlers(
+			informer,
+			enqueueFromReport,
+			func(old, obj interface{}) {
+				enqueueFromReport(old)
+				enqueueFromReport(obj)
+			},
+			enqueueFromReport,
+		)
+	}
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	polex, err := c.polexLister.PolicyExceptions(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, indexer := range []cache.Indexer{c.polrIndexer, c.cpolrIndexer} {
+		objs, err := indexer.ByIndex(exceptionsIndex, key)
+		if err != nil {
+			return err
+		}
+		for _, obj := range objs {
+			results = append(results, reportResults(obj)...)
+		}
+	}
+	usage := exceptionUsage(key, results)
+	_, err = controllerutils.UpdateStatus(ctx, polex, c.client.KyvernoV2alpha1().PolicyExceptions(namespace), func(polex *kyvernov2alpha1.PolicyException) error {
+		polex.Status.Usage = usage
+		return nil
+	})
+	return err
+}
+
+// reportResults returns the results of a policy report or cluster policy report
+func reportResults(obj interface{}) []policyreportv1alpha2.PolicyReportResult {
+	switch report := obj.(type) {
+	case *policyreportv1alpha2.PolicyReport:
+		return report.Results
+	case *policyreportv1alpha2.ClusterPolicyReport:
+		return report.Results
+	}
+	return nil
+}
+
+// exceptionsIndexFunc indexes reports by the keys of the exceptions their results rely on
+func exceptionsIndexFunc(obj interface{}) ([]string, error) {
+	keys := sets.New[string]()
+	for _, result := range reportResults(obj) {
+		keys.Insert(reportutils.ResultExceptions(result)...)
+	}
+	return sets.List(keys), nil
+}
+
+// exceptionUsage aggregates the results relying on the exception with the given key,
+// it returns nil when no result relies on the exception
+func exceptionUsage(key string, results []policyreportv1alpha2.PolicyReportResult) *kyvernov2alpha1.ExceptionUsage {
+	type ruleKey struct {
+		policy string
+		rule   string
+	}
+	var usage kyvernov2alpha1.ExceptionUsage
+	counts := map[ruleKey]int{}
+	var lastUsed int64
+	for _, result := range results {
+		for _, exception := range reportutils.ResultExceptions(result) {
+			if exception != key {
+				continue
+			}
+			usage.MatchCount++
+			counts[ruleKey{policy: result.Policy, rule: result.Rule}]++
+			if result.Timestamp.Seconds > lastUsed {
+				lastUsed = result.Timestamp.Seconds
+			}
+			break
+		}
+	}
+	if usage.MatchCount == 0 {
+		return nil
+	}
+	for rule, count := range counts {
+		usage.Rules = append(usage.Rules, kyvernov2alpha1.ExceptionRuleUsage{
+			PolicyName: rule.policy,
+			RuleName:   rule.rule,
+			MatchCount: count,
+		})
+	}
+	sort.Slice(usage.Rules, func(i, j int) bool {
+		if usage.Rules[i].PolicyName != usage.Rules[j].PolicyName {
+			return usage.Rules[i].PolicyName < usage.Rules[j].PolicyName
+		}
+		return usage.Rules[i].RuleName < usage.Rules[j].RuleName
+	})
+	if lastUsed != 0 {
+		lastUsedTime := metav1.NewTime(time.Unix(lastUsed, 0).UTC())
+		usage.LastUsedTime = &lastUsedTime
+	}
+	return &usage
+}
@@ -0,0 +1,43 @@
+package exception
+
+import (
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_exceptionUsage(t *testing.T) {
+	result := func(policy, rule, exceptions string, seconds int64) policyreportv1alpha2.PolicyReportResult {
+		return policyreportv1alpha2.PolicyReportResult{
+			Policy:     policy,
+			Rule:       rule,
+			Timestamp:  metav1.Timestamp{Seconds: seconds},
+			Properties: map[string]string{reportutils.ExceptionsProperty: exceptions},
+		}
+	}
+	results := []policyreportv1alpha2.PolicyReportResult{
+		result("require-labels", "check-team", "default/legacy", 100),
+		result("require-labels", "check-team", "default/legacy,default/other", 300),
+		result("disallow-host-path", "check", "default/legacy", 200),
+		result("disallow-host-path", "check", "default/other", 400),
+		{Policy: "require-labels", Rule: "check-owner"},
+	}
+	assert.Assert(t, exceptionUsage("default/missing", results) == nil)
+	lastUsedTime := metav1.NewTime(time.Unix(300, 0).UTC())
+	assert.DeepEqual(t, exceptionUsage("default/legacy", results), &kyvernov2alpha1.ExceptionUsage{
+		MatchCount: 3,
+		Rules: []kyvernov2alpha1.ExceptionRuleUsage{
+			{PolicyName: "disallow-host-path", RuleName: "check", MatchCount: 1},
+			{PolicyName: "require-labels", RuleName: "check-team", MatchCount: 2},
+		},
+		LastUsedTime: &lastUsedTime,
+	})
+	keys, err := exceptionsIndexFunc(&policyreportv1alpha2.PolicyReport{Results: results})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, keys, []string{"default/legacy", "default/other"})
+}
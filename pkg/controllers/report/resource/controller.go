@@ -82,6 +82,9 @@ type controller struct {
 	// queue
 	queue workqueue.RateLimitingInterface
 
+	// config
+	reportedRules utils.ReportedRules
+
 	lock            sync.RWMutex
 	dynamicWatchers map[schema.GroupVersionResource]*watcher
 	eventHandlers   []EventHandler
@@ -91,11 +94,13 @@ func NewController(
 	client dclient.Interface,
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	reportedRules utils.ReportedRules,
 ) Controller {
 	c := controller{
 		client:          client,
 		polLister:       polInformer.Lister(),
 		cpolLister:      cpolInformer.Lister(),
+		reportedRules:   reportedRules,
 		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		dynamicWatchers: map[schema.GroupVersionResource]*watcher{},
 	}
@@ -220,7 +225,7 @@ func (c *controller) updateDynamicWatchers(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	kinds := utils.BuildKindSet(logger, utils.RemoveNonValidationPolicies(append(clusterPolicies, policies...)...)...)
+	kinds := utils.BuildKindSet(logger, c.reportedRules, utils.RemoveNonReportedPolicies(c.reportedRules, append(clusterPolicies, policies...)...)...)
 	gvrs := map[schema.GroupVersionKind]schema.GroupVersionResource{}
 	for _, kind := range sets.List(kinds) {
 		apiVersion, kind := kubeutils.GetKindFromGVK(kind)
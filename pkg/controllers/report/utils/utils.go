@@ -16,17 +16,40 @@ func CanBackgroundProcess(p kyvernov1.PolicyInterface) bool {
 	if !p.BackgroundProcessingEnabled() {
 		return false
 	}
+	// shadow policies are only evaluated on sampled admission requests
+	if kyvernov1.IsShadowPolicy(p) {
+		return false
+	}
 	if err := policy.ValidateVariables(p, true); err != nil {
 		return false
 	}
 	return true
 }
 
-func BuildKindSet(logger logr.Logger, policies ...kyvernov1.PolicyInterface) sets.Set[string] {
+// ReportedRules configures the results reported in addition to the validate and verifyImages rules results
+type ReportedRules struct {
+	// Mutate enables reporting of mutate rules, applied at admission time or to existing resources
+	Mutate bool
+	// Generate enables reporting of generate rules
+	Generate bool
+}
+
+// Has returns true if the results of the rule are reported
+func (r ReportedRules) Has(rule kyvernov1.Rule) bool {
+	if rule.HasValidate() || rule.HasVerifyImages() {
+		return true
+	}
+	if r.Mutate && rule.HasMutate() {
+		return true
+	}
+	return r.Generate && rule.HasGenerate()
+}
+
+func BuildKindSet(logger logr.Logger, reported ReportedRules, policies ...kyvernov1.PolicyInterface) sets.Set[string] {
 	kinds := sets.New[string]()
 	for _, policy := range policies {
 		for _, rule := range autogen.ComputeRules(policy) {
-			if rule.HasValidate() || rule.HasVerifyImages() {
+			if reported.Has(rule) {
 				kinds.Insert(rule.MatchResources.GetKinds()...)
 			}
 		}
@@ -44,15 +67,20 @@ func RemoveNonBackgroundPolicies(policies ...kyvernov1.PolicyInterface) []kyvern
 	return backgroundPolicies
 }
 
-func RemoveNonValidationPolicies(policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
-	var validationPolicies []kyvernov1.PolicyInterface
+// RemoveNonReportedPolicies keeps the policies having at least one rule whose results are reported
+func RemoveNonReportedPolicies(reported ReportedRules, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
+	var reportedPolicies []kyvernov1.PolicyInterface
 	for _, pol := range policies {
 		spec := pol.GetSpec()
 		if spec.HasVerifyImages() || spec.HasValidate() || spec.HasYAMLSignatureVerify() {
-			validationPolicies = append(validationPolicies, pol)
+			reportedPolicies = append(reportedPolicies, pol)
+		} else if reported.Mutate && spec.HasMutate() {
+			reportedPolicies = append(reportedPolicies, pol)
+		} else if reported.Generate && spec.HasGenerate() {
+			reportedPolicies = append(reportedPolicies, pol)
 		}
 	}
-	return validationPolicies
+	return reportedPolicies
 }
 
 func ReportsAreIdentical(before, after kyvernov1alpha2.ReportInterface) bool {
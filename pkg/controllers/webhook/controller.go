@@ -98,7 +98,9 @@ type controller struct {
 	servicePort        int32
 	autoUpdateWebhooks bool
 	admissionReports   bool
-	runtime            runtimeutils.Runtime
+	// mutateReports registers the kinds of mutate rules in the validating webhook, their results are reported from there
+	mutateReports bool
+	runtime       runtimeutils.Runtime
 
 	// state
 	lock        sync.Mutex
@@ -124,6 +126,7 @@ func NewController(
 	servicePort int32,
 	autoUpdateWebhooks bool,
 	admissionReports bool,
+	mutateReports bool,
 	runtime runtimeutils.Runtime,
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName)
@@ -147,6 +150,7 @@ func NewController(
 		servicePort:        servicePort,
 		autoUpdateWebhooks: autoUpdateWebhooks,
 		admissionReports:   admissionReports,
+		mutateReports:      mutateReports,
 		runtime:            runtime,
 		policyState: map[string]sets.Set[string]{
 			config.MutatingWebhookConfigurationName:   sets.New[string](),
@@ -634,6 +638,10 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(caBundle []byte)
 		} else {
 			for _, p := range policies {
 				spec := p.GetSpec()
+				// shadow policies only run validate rules
+				if kyvernov1.IsShadowPolicy(p) {
+					continue
+				}
 				if spec.HasMutate() || spec.HasVerifyImages() {
 					if spec.GetFailurePolicy() == kyvernov1.Ignore {
 						c.mergeWebhook(ignore, p, false)
@@ -742,7 +750,8 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(caBundle []byte
 			for _, p := range policies {
 				spec := p.GetSpec()
 				if spec.HasValidate() || spec.HasGenerate() || spec.HasMutate() || spec.HasImagesValidationChecks() || spec.HasYAMLSignatureVerify() {
-					if spec.GetFailurePolicy() == kyvernov1.Ignore {
+					// shadow policies must never reject requests, even when the webhook fails
+					if spec.GetFailurePolicy() == kyvernov1.Ignore || kyvernov1.IsShadowPolicy(p) {
 						c.mergeWebhook(ignore, p, true)
 					} else {
 						c.mergeWebhook(fail, p, true)
@@ -834,6 +843,7 @@ func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface
 		}
 		if (updateValidate && rule.HasValidate() || rule.HasImagesValidationChecks()) ||
 			(updateValidate && rule.HasMutate() && rule.IsMutateExisting()) ||
+			(updateValidate && c.mutateReports && rule.HasMutate()) ||
 			(!updateValidate && rule.HasMutate()) && !rule.IsMutateExisting() ||
 			(!updateValidate && rule.HasVerifyImages()) || (!updateValidate && rule.HasYAMLSignatureVerify()) {
 			matchedGVK = append(matchedGVK, rule.MatchResources.GetKinds()...)
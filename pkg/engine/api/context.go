@@ -74,8 +74,18 @@ func LoadVariable(logger logr.Logger, entry kyvernov1.ContextEntry, ctx engineco
 	}
 }
 
+// ImageDataFetcher fetches the image data for the given image reference
+type ImageDataFetcher = func(ctx context.Context, ref string) (interface{}, error)
+
 func LoadImageData(ctx context.Context, rclient registryclient.Client, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface) error {
-	imageData, err := fetchImageData(ctx, rclient, logger, entry, enginectx)
+	return LoadImageDataWithFetcher(ctx, func(ctx context.Context, ref string) (interface{}, error) {
+		return fetchImageDataMap(ctx, rclient, ref)
+	}, logger, entry, enginectx)
+}
+
+// LoadImageDataWithFetcher loads an imageRegistry context entry using the given fetcher to retrieve image data
+func LoadImageDataWithFetcher(ctx context.Context, fetcher ImageDataFetcher, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface) error {
+	imageData, err := fetchImageData(ctx, fetcher, logger, entry, enginectx)
 	if err != nil {
 		return err
 	}
@@ -100,6 +110,18 @@ func LoadAPIData(ctx context.Context, logger logr.Logger, entry kyvernov1.Contex
 	return nil
 }
 
+// LoadAPIDataWithExecutor loads an apiCall context entry using the given executor to perform the call
+func LoadAPIDataWithExecutor(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, executor apicall.Executor) error {
+	call, err := apicall.New(ctx, entry, enginectx, nil, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize APICall: %w", err)
+	}
+	if _, err := call.WithExecutor(executor).Execute(); err != nil {
+		return fmt.Errorf("failed to execute APICall: %w", err)
+	}
+	return nil
+}
+
 func LoadConfigMap(ctx context.Context, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface, resolver ConfigmapResolver) error {
 	data, err := fetchConfigMap(ctx, logger, entry, enginectx, resolver)
 	if err != nil {
@@ -112,7 +134,7 @@ func LoadConfigMap(ctx context.Context, logger logr.Logger, entry kyvernov1.Cont
 	return nil
 }
 
-func fetchImageData(ctx context.Context, rclient registryclient.Client, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface) (interface{}, error) {
+func fetchImageData(ctx context.Context, fetcher ImageDataFetcher, logger logr.Logger, entry kyvernov1.ContextEntry, enginectx enginecontext.Interface) (interface{}, error) {
 	ref, err := variables.SubstituteAll(logger, enginectx, entry.ImageRegistry.Reference)
 	if err != nil {
 		return nil, fmt.Errorf("ailed to substitute variables in context entry %s %s: %v", entry.Name, entry.ImageRegistry.Reference, err)
@@ -125,7 +147,7 @@ func fetchImageData(ctx context.Context, rclient registryclient.Client, logger l
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.ImageRegistry.JMESPath, err)
 	}
-	imageData, err := fetchImageDataMap(ctx, rclient, refString)
+	imageData, err := fetcher(ctx, refString)
 	if err != nil {
 		return nil, err
 	}
@@ -3,10 +3,12 @@ package api
 import (
 	"fmt"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	pssutils "github.com/kyverno/kyverno/pkg/pss/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/pod-security-admission/api"
 )
 
@@ -46,6 +48,26 @@ type RuleResponse struct {
 	PodSecurityChecks *PodSecurityChecks
 	// Exception is the exception applied (if any)
 	Exception *kyvernov2alpha1.PolicyException
+	// Exemptions are the exceptions exempting specific controls or containers from the rule (if any)
+	Exemptions []*kyvernov2alpha1.PolicyException
+	// ReportMetadata is the report metadata of the rule (if any)
+	ReportMetadata *kyvernov1.ReportMetadata
+}
+
+// ExceptionKeys returns the keys of the exceptions applied to the rule, either as a whole or partially
+func (r RuleResponse) ExceptionKeys() []string {
+	var keys []string
+	if r.Exception != nil {
+		if key, err := cache.MetaNamespaceKeyFunc(r.Exception); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	for _, exception := range r.Exemptions {
+		if key, err := cache.MetaNamespaceKeyFunc(exception); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 // HasStatus checks if rule status is in a given list
@@ -19,12 +19,16 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 )
 
+// Executor performs an APICall, after variables have been substituted, and returns the raw response
+type Executor = func(call *kyvernov1.APICall) ([]byte, error)
+
 type apiCall struct {
-	log     logr.Logger
-	entry   kyvernov1.ContextEntry
-	ctx     goctx.Context
-	jsonCtx context.Interface
-	client  dclient.Interface
+	log      logr.Logger
+	entry    kyvernov1.ContextEntry
+	ctx      goctx.Context
+	jsonCtx  context.Interface
+	client   dclient.Interface
+	executor Executor
 }
 
 func New(ctx goctx.Context, entry kyvernov1.ContextEntry, jsonCtx context.Interface, client dclient.Interface, log logr.Logger) (*apiCall, error) {
@@ -41,6 +45,12 @@ func New(ctx goctx.Context, entry kyvernov1.ContextEntry, jsonCtx context.Interf
 	}, nil
 }
 
+// WithExecutor replaces the default executor, calling the Kubernetes API server or the configured service
+func (a *apiCall) WithExecutor(executor Executor) *apiCall {
+	a.executor = executor
+	return a
+}
+
 func (a *apiCall) Execute() ([]byte, error) {
 	call, err := variables.SubstituteAllInType(a.log, a.jsonCtx, a.entry.APICall)
 	if err != nil {
@@ -61,6 +71,10 @@ func (a *apiCall) Execute() ([]byte, error) {
 }
 
 func (a *apiCall) execute(call *kyvernov1.APICall) ([]byte, error) {
+	if a.executor != nil {
+		return a.executor(call)
+	}
+
 	if call.URLPath != "" {
 		return a.executeK8sAPICall(call.URLPath)
 	}
@@ -169,10 +183,7 @@ func (a *apiCall) buildHTTPClient(service *kyvernov1.ServiceCall) (*http.Client,
 }
 
 func (a *apiCall) buildPostData(data []kyvernov1.RequestData) (io.Reader, error) {
-	dataMap := make(map[string]interface{})
-	for _, d := range data {
-		dataMap[d.Key] = d.Value
-	}
+	dataMap := BuildPostData(data)
 
 	buffer := new(bytes.Buffer)
 	if err := json.NewEncoder(buffer).Encode(dataMap); err != nil {
@@ -182,6 +193,16 @@ func (a *apiCall) buildPostData(data []kyvernov1.RequestData) (io.Reader, error)
 	return buffer, nil
 }
 
+// BuildPostData returns the body sent for a POST service call
+func BuildPostData(data []kyvernov1.RequestData) map[string]interface{} {
+	dataMap := make(map[string]interface{})
+	for _, d := range data {
+		dataMap[d.Key] = d.Value
+	}
+
+	return dataMap
+}
+
 func (a *apiCall) transformAndStore(jsonData []byte) ([]byte, error) {
 	if a.entry.APICall.JMESPath == "" {
 		err := a.jsonCtx.AddContextEntry(a.entry.Name, jsonData)
@@ -144,3 +144,27 @@ func Test_servicePostRequest(t *testing.T) {
 	expectedResults := `{"images":["https://ghcr.io/tomcat/tomcat:9","https://ghcr.io/vault/vault:v3","https://ghcr.io/busybox/busybox:latest"]}`
 	assert.Equal(t, string(expectedResults)+"\n", string(data))
 }
+
+func Test_executor(t *testing.T) {
+	entry := kyvernov1.ContextEntry{
+		Name: "test",
+		APICall: &kyvernov1.APICall{
+			URLPath:  "/api/v1/namespaces/{{ namespace }}",
+			JMESPath: "metadata.name",
+		},
+	}
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, ctx.AddVariable("namespace", "prod"))
+
+	call, err := New(context.TODO(), entry, ctx, nil, logr.Discard())
+	assert.NilError(t, err)
+
+	var urlPath string
+	data, err := call.WithExecutor(func(call *kyvernov1.APICall) ([]byte, error) {
+		urlPath = call.URLPath
+		return []byte(`{ "metadata": { "name": "prod" } }`), nil
+	}).Execute()
+	assert.NilError(t, err)
+	assert.Equal(t, urlPath, "/api/v1/namespaces/prod")
+	assert.Equal(t, string(data), `"prod"`)
+}
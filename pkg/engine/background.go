@@ -101,9 +101,10 @@ func (e *engine) filterRule(
 			// if the oldResource matched, return "false" to delete GR for it
 			if err = MatchesResourceDescription(subresourceGVKToAPIResource, oldResource, rule, admissionInfo, excludeGroupRole, namespaceLabels, "", policyContext.SubResource()); err == nil {
 				return &engineapi.RuleResponse{
-					Name:   rule.Name,
-					Type:   ruleType,
-					Status: engineapi.RuleStatusFail,
+					Name:           rule.Name,
+					Type:           ruleType,
+					Status:         engineapi.RuleStatusFail,
+					ReportMetadata: rule.ReportMetadata,
 					Stats: engineapi.ExecutionStats{
 						ProcessingTime: time.Since(startTime),
 						Timestamp:      startTime.Unix(),
@@ -146,9 +147,10 @@ func (e *engine) filterRule(
 
 	// build rule Response
 	return &engineapi.RuleResponse{
-		Name:   ruleCopy.Name,
-		Type:   ruleType,
-		Status: engineapi.RuleStatusPass,
+		Name:           ruleCopy.Name,
+		Type:           ruleType,
+		Status:         engineapi.RuleStatusPass,
+		ReportMetadata: ruleCopy.ReportMetadata,
 		Stats: engineapi.ExecutionStats{
 			ProcessingTime: time.Since(startTime),
 			Timestamp:      startTime.Unix(),
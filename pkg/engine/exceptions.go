@@ -2,6 +2,7 @@ package engine
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -9,6 +10,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
 	matched "github.com/kyverno/kyverno/pkg/utils/match"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -32,7 +34,12 @@ func findExceptions(
 	if err != nil {
 		return nil, fmt.Errorf("failed to compute policy key: %w", err)
 	}
+	now := time.Now()
 	for _, polex := range polexs {
+		// exceptions outside of their validity window are ignored
+		if !polex.IsActive(now) {
+			continue
+		}
 		if polex.Contains(policyName, rule) {
 			result = append(result, polex)
 		}
@@ -40,18 +47,21 @@ func findExceptions(
 	return result, nil
 }
 
-// matchesException checks if an exception applies to the resource being admitted
-func matchesException(
+// matchingExceptions returns the exceptions applying to the resource being admitted,
+// exceptions with conditions apply only when their conditions pass
+func matchingExceptions(
+	log logr.Logger,
 	selector engineapi.PolicyExceptionSelector,
 	policyContext engineapi.PolicyContext,
 	rule *kyvernov1.Rule,
 	subresourceGVKToAPIResource map[string]*metav1.APIResource,
 	cfg config.Configuration,
-) (*kyvernov2alpha1.PolicyException, error) {
+) ([]*kyvernov2alpha1.PolicyException, error) {
 	candidates, err := findExceptions(selector, policyContext.Policy(), rule.Name)
 	if err != nil {
 		return nil, err
 	}
+	var result []*kyvernov2alpha1.PolicyException
 	for _, candidate := range candidates {
 		err := matched.CheckMatchesResources(
 			policyContext.NewResource(),
@@ -62,12 +72,47 @@ func matchesException(
 			policyContext.AdmissionInfo(),
 			cfg.GetExcludeGroupRole(),
 		)
-		// if there's no error it means a match
-		if err == nil {
-			return candidate, nil
+		// an error means the resource doesn't match
+		if err != nil {
+			continue
+		}
+		if candidate.Spec.Conditions != nil {
+			passed, err := variables.CheckAnyAllConditions(log, policyContext.JSONContext(), *candidate.Spec.Conditions)
+			if err != nil {
+				log.Error(err, "failed to check policy exception conditions", "namespace", candidate.GetNamespace(), "name", candidate.GetName())
+				continue
+			}
+			if !passed {
+				continue
+			}
 		}
+		result = append(result, candidate)
 	}
-	return nil, nil
+	return result, nil
+}
+
+// policyExceptions returns the exception exempting the whole rule, if any, and the exceptions exempting
+// specific controls or containers that apply to the resource being admitted
+func policyExceptions(
+	log logr.Logger,
+	selector engineapi.PolicyExceptionSelector,
+	policyContext engineapi.PolicyContext,
+	rule *kyvernov1.Rule,
+	subresourceGVKToAPIResource map[string]*metav1.APIResource,
+	cfg config.Configuration,
+) (*kyvernov2alpha1.PolicyException, []*kyvernov2alpha1.PolicyException, error) {
+	exceptions, err := matchingExceptions(log, selector, policyContext, rule, subresourceGVKToAPIResource, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	var partial []*kyvernov2alpha1.PolicyException
+	for _, exception := range exceptions {
+		if !exception.Spec.IsPartial() {
+			return exception, nil, nil
+		}
+		partial = append(partial, exception)
+	}
+	return nil, partial, nil
 }
 
 // hasPolicyExceptions returns nil when there are no matching exceptions.
@@ -82,10 +127,18 @@ func hasPolicyExceptions(
 	cfg config.Configuration,
 ) *engineapi.RuleResponse {
 	// if matches, check if there is a corresponding policy exception
-	exception, err := matchesException(selector, ctx, rule, subresourceGVKToAPIResource, cfg)
+	exception, _, err := policyExceptions(log, selector, ctx, rule, subresourceGVKToAPIResource, cfg)
+	if err != nil {
+		return nil
+	}
+	return exceptionResponse(log, ruleType, rule, exception)
+}
+
+// exceptionResponse returns the response of a rule skipped by an exception, or nil when there is no exception
+func exceptionResponse(log logr.Logger, ruleType engineapi.RuleType, rule *kyvernov1.Rule, exception *kyvernov2alpha1.PolicyException) *engineapi.RuleResponse {
 	var response *engineapi.RuleResponse
 	// if we found an exception
-	if err == nil && exception != nil {
+	if exception != nil {
 		key, err := cache.MetaNamespaceKeyFunc(exception)
 		if err != nil {
 			log.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
@@ -0,0 +1,147 @@
+package engine
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// podSpecPaths are the paths of the pod spec in pods and pod controllers
+var podSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+var containerFields = []string{"initContainers", "containers", "ephemeralContainers"}
+
+// containerSelectors returns the container selectors of the exceptions exempting whole containers,
+// exceptions exempting pod security controls restrict the controls to the selected containers instead
+func containerSelectors(exceptions []*kyvernov2alpha1.PolicyException) []*kyvernov2alpha1.ContainerSelector {
+	var selectors []*kyvernov2alpha1.ContainerSelector
+	for _, exception := range exceptions {
+		if exception.Spec.Containers != nil && len(exception.Spec.PodSecurity) == 0 {
+			selectors = append(selectors, exception.Spec.Containers)
+		}
+	}
+	return selectors
+}
+
+// isExemptedContainer returns true if the given container object is selected by one of the selectors
+func isExemptedContainer(obj interface{}, selectors []*kyvernov2alpha1.ContainerSelector) bool {
+	container, ok := obj.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	name, _ := container["name"].(string)
+	image, _ := container["image"].(string)
+	if name == "" && image == "" {
+		return false
+	}
+	for _, selector := range selectors {
+		if selector.Matches(name, image) {
+			return true
+		}
+	}
+	return false
+}
+
+// exemptContainers returns a copy of the resource without the containers exempted by the exceptions,
+// the returned bool is true if at least one container was removed
+func exemptContainers(resource unstructured.Unstructured, exceptions []*kyvernov2alpha1.PolicyException) (unstructured.Unstructured, bool) {
+	selectors := containerSelectors(exceptions)
+	path, ok := podSpecPaths[resource.GetKind()]
+	if len(selectors) == 0 || !ok {
+		return resource, false
+	}
+	exempted := resource.DeepCopy()
+	removed := false
+	for _, field := range containerFields {
+		fieldPath := append(append([]string{}, path...), field)
+		containers, found, err := unstructured.NestedSlice(exempted.Object, fieldPath...)
+		if err != nil || !found {
+			continue
+		}
+		kept := make([]interface{}, 0, len(containers))
+		for _, container := range containers {
+			if isExemptedContainer(container, selectors) {
+				removed = true
+			} else {
+				kept = append(kept, container)
+			}
+		}
+		if err := unstructured.SetNestedSlice(exempted.Object, kept, fieldPath...); err != nil {
+			return resource, false
+		}
+	}
+	if !removed {
+		return resource, false
+	}
+	return *exempted, true
+}
+
+// isExemptedElement returns true if a foreach element is a container exempted by the exceptions
+func isExemptedElement(element interface{}, exceptions []*kyvernov2alpha1.PolicyException) bool {
+	selectors := containerSelectors(exceptions)
+	if len(selectors) == 0 {
+		return false
+	}
+	return isExemptedContainer(element, selectors)
+}
+
+// exemptedControls returns the pod security controls exempted by the exceptions, the controls of exceptions
+// selecting containers are exempted for the images of the selected containers only (restricted to the
+// images of the control when it has some)
+func exemptedControls(pod *corev1.Pod, exceptions []*kyvernov2alpha1.PolicyException) []kyvernov1.PodSecurityStandard {
+	var controls []kyvernov1.PodSecurityStandard
+	for _, exception := range exceptions {
+		if len(exception.Spec.PodSecurity) == 0 {
+			continue
+		}
+		selector := exception.Spec.Containers
+		if selector == nil {
+			controls = append(controls, exception.Spec.PodSecurity...)
+			continue
+		}
+		var images []string
+		add := func(name, image string) {
+			if selector.Matches(name, image) {
+				images = append(images, image)
+			}
+		}
+		for _, container := range pod.Spec.InitContainers {
+			add(container.Name, container.Image)
+		}
+		for _, container := range pod.Spec.Containers {
+			add(container.Name, container.Image)
+		}
+		for _, container := range pod.Spec.EphemeralContainers {
+			add(container.Name, container.Image)
+		}
+		if len(images) == 0 {
+			continue
+		}
+		for _, control := range exception.Spec.PodSecurity {
+			var controlImages []string
+			for _, image := range images {
+				if len(control.Images) == 0 || wildcard.CheckPatterns(control.Images, image) {
+					controlImages = append(controlImages, image)
+				}
+			}
+			if len(controlImages) != 0 {
+				controls = append(controls, kyvernov1.PodSecurityStandard{
+					ControlName: control.ControlName,
+					Images:      controlImages,
+				})
+			}
+		}
+	}
+	return controls
+}
@@ -0,0 +1,303 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type exceptionSelector []*kyvernov2alpha1.PolicyException
+
+func (s exceptionSelector) List(labels.Selector) ([]*kyvernov2alpha1.PolicyException, error) {
+	return s, nil
+}
+
+var exemptionsPod = []byte(`{
+	"apiVersion": "v1",
+	"kind": "Pod",
+	"metadata": {
+		"name": "app",
+		"namespace": "default"
+	},
+	"spec": {
+		"containers": [
+			{
+				"name": "app",
+				"image": "ghcr.io/example/app:v1",
+				"securityContext": {
+					"runAsNonRoot": true,
+					"allowPrivilegeEscalation": false,
+					"capabilities": {
+						"drop": ["ALL"]
+					},
+					"seccompProfile": {
+						"type": "RuntimeDefault"
+					}
+				}
+			},
+			{
+				"name": "istio-proxy",
+				"image": "docker.io/istio/proxyv2:1.16.1",
+				"securityContext": {
+					"runAsNonRoot": false,
+					"allowPrivilegeEscalation": false,
+					"capabilities": {
+						"drop": ["ALL"]
+					},
+					"seccompProfile": {
+						"type": "RuntimeDefault"
+					}
+				}
+			}
+		]
+	}
+}`)
+
+func newExemption(spec kyvernov2alpha1.PolicyExceptionSpec) *kyvernov2alpha1.PolicyException {
+	spec.Match = kyvernov2beta1.MatchResources{
+		Any: resourceFilters("Pod"),
+	}
+	spec.Exceptions = []kyvernov2alpha1.Exception{{
+		PolicyName: "require-non-root",
+		RuleNames:  []string{"check"},
+	}}
+	polex := &kyvernov2alpha1.PolicyException{Spec: spec}
+	polex.SetName("sidecar")
+	polex.SetNamespace("default")
+	return polex
+}
+
+func resourceFilters(kinds ...string) kyverno.ResourceFilters {
+	return kyverno.ResourceFilters{{
+		ResourceDescription: kyverno.ResourceDescription{
+			Kinds: kinds,
+		},
+	}}
+}
+
+func testValidateWithExceptions(t *testing.T, rawPolicy []byte, exceptions ...*kyvernov2alpha1.PolicyException) *engineapi.EngineResponse {
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resource, err := kubeutils.BytesToUnstructured(exemptionsPod)
+	assert.NilError(t, err)
+	jsonContext := enginecontext.NewContext()
+	assert.NilError(t, jsonContext.AddResource(resource.Object))
+	e := NewEngine(
+		cfg,
+		nil,
+		registryclient.NewOrDie(),
+		engineapi.DefaultContextLoaderFactory(nil),
+		exceptionSelector(exceptions),
+	)
+	return e.Validate(
+		context.TODO(),
+		&PolicyContext{policy: &policy, newResource: *resource, jsonContext: jsonContext},
+	)
+}
+
+func Test_PartialException_Pattern(t *testing.T) {
+	policy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "require-non-root"
+		},
+		"spec": {
+			"rules": [{
+				"name": "check",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {
+					"message": "containers must run as non root",
+					"pattern": {
+						"spec": {
+							"containers": [{
+								"securityContext": {"runAsNonRoot": true}
+							}]
+						}
+					}
+				}
+			}]
+		}
+	}`)
+	er := testValidateWithExceptions(t, policy)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusFail)
+	er = testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+		Containers: &kyvernov2alpha1.ContainerSelector{Names: []string{"istio-*"}},
+	}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+	assert.Assert(t, er.PolicyResponse.Rules[0].Exception == nil)
+	assert.DeepEqual(t, er.PolicyResponse.Rules[0].ExceptionKeys(), []string{"default/sidecar"})
+	er = testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+		Containers: &kyvernov2alpha1.ContainerSelector{Images: []string{"ghcr.io/example/*"}},
+	}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusFail)
+	er = testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusSkip)
+	assert.DeepEqual(t, er.PolicyResponse.Rules[0].ExceptionKeys(), []string{"default/sidecar"})
+}
+
+func Test_PartialException_ForEach(t *testing.T) {
+	policy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "require-non-root"
+		},
+		"spec": {
+			"rules": [{
+				"name": "check",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {
+					"message": "containers must run as non root",
+					"foreach": [{
+						"list": "request.object.spec.containers",
+						"deny": {
+							"conditions": {
+								"any": [{
+									"key": "{{ element.securityContext.runAsNonRoot }}",
+									"operator": "NotEquals",
+									"value": true
+								}]
+							}
+						}
+					}]
+				}
+			}]
+		}
+	}`)
+	er := testValidateWithExceptions(t, policy)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusFail)
+	er = testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+		Containers: &kyvernov2alpha1.ContainerSelector{Images: []string{"docker.io/istio/proxyv2:*"}},
+	}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+}
+
+func Test_PartialException_PodSecurity(t *testing.T) {
+	policy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "require-non-root"
+		},
+		"spec": {
+			"rules": [{
+				"name": "check",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {
+					"podSecurity": {
+						"level": "restricted",
+						"version": "latest"
+					}
+				}
+			}]
+		}
+	}`)
+	er := testValidateWithExceptions(t, policy)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusFail)
+	// the control is exempted for the sidecar only
+	er = testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+		PodSecurity: []kyverno.PodSecurityStandard{{ControlName: "Running as Non-root"}},
+		Containers:  &kyvernov2alpha1.ContainerSelector{Names: []string{"istio-proxy"}},
+	}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+	// other controls are still enforced
+	er = testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+		PodSecurity: []kyverno.PodSecurityStandard{{ControlName: "Capabilities"}},
+		Containers:  &kyvernov2alpha1.ContainerSelector{Names: []string{"istio-proxy"}},
+	}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusFail)
+}
+
+func Test_exemptedControls(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.36"}},
+			Containers: []corev1.Container{
+				{Name: "app", Image: "ghcr.io/example/app:v1"},
+				{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.16.1"},
+			},
+		},
+	}
+	controls := exemptedControls(pod, []*kyvernov2alpha1.PolicyException{
+		newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+			PodSecurity: []kyverno.PodSecurityStandard{{ControlName: "Seccomp"}},
+		}),
+		newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+			PodSecurity: []kyverno.PodSecurityStandard{
+				{ControlName: "Capabilities"},
+				{ControlName: "Running as Non-root", Images: []string{"busybox:*"}},
+				{ControlName: "Privilege Escalation", Images: []string{"nginx:*"}},
+			},
+			Containers: &kyvernov2alpha1.ContainerSelector{Names: []string{"init", "istio-proxy"}},
+		}),
+		newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+			PodSecurity: []kyverno.PodSecurityStandard{{ControlName: "Host Ports"}},
+			Containers:  &kyvernov2alpha1.ContainerSelector{Names: []string{"missing"}},
+		}),
+	})
+	assert.DeepEqual(t, controls, []kyverno.PodSecurityStandard{
+		{ControlName: "Seccomp"},
+		{ControlName: "Capabilities", Images: []string{"busybox:1.36", "docker.io/istio/proxyv2:1.16.1"}},
+		{ControlName: "Running as Non-root", Images: []string{"busybox:1.36"}},
+	})
+}
+
+func Test_ExceptionConditions(t *testing.T) {
+	policy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {
+			"name": "require-non-root"
+		},
+		"spec": {
+			"rules": [{
+				"name": "check",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {
+					"message": "containers must run as non root",
+					"pattern": {
+						"spec": {
+							"containers": [{
+								"securityContext": {"runAsNonRoot": true}
+							}]
+						}
+					}
+				}
+			}]
+		}
+	}`)
+	conditions := func(name string) *kyvernov2beta1.AnyAllConditions {
+		return &kyvernov2beta1.AnyAllConditions{
+			AnyConditions: []kyvernov2beta1.Condition{{
+				RawKey:   kyverno.ToJSON("{{ request.object.metadata.name }}"),
+				Operator: kyvernov2beta1.ConditionOperators["Equals"],
+				RawValue: kyverno.ToJSON(name),
+			}},
+		}
+	}
+	er := testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+		Conditions: conditions("app"),
+	}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusSkip)
+	er = testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+		Conditions: conditions("other"),
+	}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusFail)
+	er = testValidateWithExceptions(t, policy, newExemption(kyvernov2alpha1.PolicyExceptionSpec{
+		Conditions: conditions("app"),
+		Containers: &kyvernov2alpha1.ContainerSelector{Names: []string{"istio-*"}},
+	}))
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+}
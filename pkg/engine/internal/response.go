@@ -24,10 +24,11 @@ func RulePass(rule *kyvernov1.Rule, ruleType engineapi.RuleType, msg string) *en
 
 func RuleResponse(rule kyvernov1.Rule, ruleType engineapi.RuleType, msg string, status engineapi.RuleStatus) *engineapi.RuleResponse {
 	resp := &engineapi.RuleResponse{
-		Name:    rule.Name,
-		Type:    ruleType,
-		Message: msg,
-		Status:  status,
+		Name:           rule.Name,
+		Type:           ruleType,
+		Message:        msg,
+		Status:         status,
+		ReportMetadata: rule.ReportMetadata,
 	}
 	return resp
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/go-logr/logr"
 	gojmespath "github.com/jmespath/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
@@ -93,14 +94,17 @@ func (e *engine) validateResource(
 				if !matches(logger, rule, enginectx, subresourceGVKToAPIResource, e.configuration) {
 					return nil
 				}
-				// check if there is a corresponding policy exception
-				ruleResp := hasPolicyExceptions(logger, engineapi.Validation, e.exceptionSelector, enginectx, rule, subresourceGVKToAPIResource, e.configuration)
-				if ruleResp != nil {
+				// check if there is a policy exception exempting the whole rule, or specific controls or containers
+				exception, exceptions, err := policyExceptions(logger, e.exceptionSelector, enginectx, rule, subresourceGVKToAPIResource, e.configuration)
+				if err != nil {
+					logger.Error(err, "failed to lookup policy exceptions")
+				}
+				if ruleResp := exceptionResponse(logger, engineapi.Validation, rule, exception); ruleResp != nil {
 					return ruleResp
 				}
 				enginectx.JSONContext().Reset()
 				if hasValidate && !hasYAMLSignatureVerify {
-					return e.processValidationRule(ctx, logger, enginectx, rule)
+					return e.processValidationRule(ctx, logger, enginectx, rule, exceptions)
 				} else if hasValidateImage {
 					return e.processImageValidationRule(ctx, logger, enginectx, rule)
 				} else if hasYAMLSignatureVerify {
@@ -126,9 +130,14 @@ func (e *engine) processValidationRule(
 	logger logr.Logger,
 	policyContext engineapi.PolicyContext,
 	rule *kyvernov1.Rule,
+	exceptions []*kyvernov2alpha1.PolicyException,
 ) *engineapi.RuleResponse {
-	v := newValidator(logger, e.ContextLoader(policyContext.Policy(), *rule), policyContext, rule)
-	return v.validate(ctx)
+	v := newValidator(logger, e.ContextLoader(policyContext.Policy(), *rule), policyContext, rule, exceptions)
+	ruleResp := v.validate(ctx)
+	if ruleResp != nil {
+		ruleResp.Exemptions = exceptions
+	}
+	return ruleResp
 }
 
 type validator struct {
@@ -144,10 +153,16 @@ type validator struct {
 	forEach          []kyvernov1.ForEachValidation
 	contextLoader    engineapi.EngineContextLoader
 	nesting          int
+	// exceptions exempting specific controls or containers from the rule
+	exceptions []*kyvernov2alpha1.PolicyException
+	// resource is the new resource without the exempted containers
+	resource unstructured.Unstructured
+	exempted bool
 }
 
-func newValidator(log logr.Logger, contextLoader engineapi.EngineContextLoader, ctx engineapi.PolicyContext, rule *kyvernov1.Rule) *validator {
+func newValidator(log logr.Logger, contextLoader engineapi.EngineContextLoader, ctx engineapi.PolicyContext, rule *kyvernov1.Rule, exceptions []*kyvernov2alpha1.PolicyException) *validator {
 	ruleCopy := rule.DeepCopy()
+	resource, exempted := exemptContainers(ctx.NewResource(), exceptions)
 	return &validator{
 		log:              log,
 		rule:             ruleCopy,
@@ -160,6 +175,9 @@ func newValidator(log logr.Logger, contextLoader engineapi.EngineContextLoader,
 		deny:             ruleCopy.Validation.Deny,
 		podSecurity:      ruleCopy.Validation.PodSecurity,
 		forEach:          ruleCopy.Validation.ForEachValidation,
+		exceptions:       exceptions,
+		resource:         resource,
+		exempted:         exempted,
 	}
 }
 
@@ -170,6 +188,8 @@ func newForEachValidator(
 	rule *kyvernov1.Rule,
 	ctx engineapi.PolicyContext,
 	log logr.Logger,
+	exceptions []*kyvernov2alpha1.PolicyException,
+	resource unstructured.Unstructured,
 ) (*validator, error) {
 	ruleCopy := rule.DeepCopy()
 	anyAllConditions, err := datautils.ToMap(foreach.AnyAllConditions)
@@ -194,10 +214,21 @@ func newForEachValidator(
 		deny:             foreach.Deny,
 		forEach:          nestedForEach,
 		nesting:          nesting,
+		exceptions:       exceptions,
+		resource:         resource,
 	}, nil
 }
 
 func (v *validator) validate(ctx context.Context) *engineapi.RuleResponse {
+	if v.exempted {
+		// replace the new resource in the context so that variables don't see the exempted containers
+		if err := v.policyContext.JSONContext().AddResource(nil); err != nil {
+			return internal.RuleError(v.rule, engineapi.Validation, "failed to exempt containers", err)
+		}
+		if err := v.policyContext.JSONContext().AddResource(v.resource.Object); err != nil {
+			return internal.RuleError(v.rule, engineapi.Validation, "failed to exempt containers", err)
+		}
+	}
 	if err := v.loadContext(ctx); err != nil {
 		return internal.RuleError(v.rule, engineapi.Validation, "failed to load context", err)
 	}
@@ -272,6 +303,10 @@ func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForE
 		if element == nil {
 			continue
 		}
+		if isExemptedElement(element, v.exceptions) {
+			v.log.V(3).Info("skip element exempted by policy exception", "index", index)
+			continue
+		}
 
 		v.policyContext.JSONContext().Reset()
 		policyContext := v.policyContext.Copy()
@@ -280,7 +315,7 @@ func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForE
 			return internal.RuleError(v.rule, engineapi.Validation, "failed to process foreach", err), applyCount
 		}
 
-		foreachValidator, err := newForEachValidator(foreach, v.contextLoader, v.nesting+1, v.rule, policyContext, v.log)
+		foreachValidator, err := newForEachValidator(foreach, v.contextLoader, v.nesting+1, v.rule, policyContext, v.log, v.exceptions, v.resource)
 		if err != nil {
 			v.log.Error(err, "failed to create foreach validator")
 			return internal.RuleError(v.rule, engineapi.Validation, "failed to create foreach validator", err), applyCount
@@ -389,7 +424,7 @@ func (v *validator) getDenyMessage(deny bool) string {
 }
 
 func getSpec(v *validator) (podSpec *corev1.PodSpec, metadata *metav1.ObjectMeta, err error) {
-	newResource := v.policyContext.NewResource()
+	newResource := v.resource
 	kind := newResource.GetKind()
 
 	if kind == "DaemonSet" || kind == "Deployment" || kind == "Job" || kind == "StatefulSet" || kind == "ReplicaSet" || kind == "ReplicationController" {
@@ -453,7 +488,12 @@ func (v *validator) validatePodSecurity() *engineapi.RuleResponse {
 		Spec:       *podSpec,
 		ObjectMeta: *metadata,
 	}
-	allowed, pssChecks, err := pss.EvaluatePod(v.podSecurity, pod)
+	podSecurity := v.podSecurity
+	if controls := exemptedControls(pod, v.exceptions); len(controls) != 0 {
+		podSecurity = podSecurity.DeepCopy()
+		podSecurity.Exclude = append(podSecurity.Exclude, controls...)
+	}
+	allowed, pssChecks, err := pss.EvaluatePod(podSecurity, pod)
 	if err != nil {
 		return internal.RuleError(v.rule, engineapi.Validation, "failed to parse pod security api version", err)
 	}
@@ -484,7 +524,7 @@ func (v *validator) validateResourceWithRule() *engineapi.RuleResponse {
 		v.log.V(3).Info("skipping validation on deleted resource")
 		return nil
 	}
-	resp := v.validatePatterns(v.policyContext.NewResource())
+	resp := v.validatePatterns(v.resource)
 	return resp
 }
 
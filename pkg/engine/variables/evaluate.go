@@ -1,8 +1,11 @@
 package variables
 
 import (
+	"fmt"
+
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/variables/operator"
 )
@@ -81,3 +84,40 @@ func evaluateOldConditions(log logr.Logger, ctx context.EvalInterface, condition
 
 	return true
 }
+
+// CheckAnyAllConditions substitutes variables and evaluates the conditions, all conditions must pass
+// and at least one of the any conditions (if any) must pass
+func CheckAnyAllConditions(logger logr.Logger, ctx context.EvalInterface, conditions kyvernov2beta1.AnyAllConditions) (bool, error) {
+	for _, condition := range conditions.AllConditions {
+		if passed, err := CheckCondition(logger, ctx, condition); err != nil {
+			return false, err
+		} else if !passed {
+			return false, nil
+		}
+	}
+	for _, condition := range conditions.AnyConditions {
+		if passed, err := CheckCondition(logger, ctx, condition); err != nil {
+			return false, err
+		} else if passed {
+			return true, nil
+		}
+	}
+	return len(conditions.AnyConditions) == 0, nil
+}
+
+// CheckCondition substitutes variables in the condition key and value and evaluates the condition
+func CheckCondition(logger logr.Logger, ctx context.EvalInterface, condition kyvernov2beta1.Condition) (bool, error) {
+	key, err := SubstituteAllInPreconditions(logger, ctx, condition.GetKey())
+	if err != nil {
+		return false, fmt.Errorf("failed to substitute variables in condition key: %w", err)
+	}
+	value, err := SubstituteAllInPreconditions(logger, ctx, condition.GetValue())
+	if err != nil {
+		return false, fmt.Errorf("failed to substitute variables in condition value: %w", err)
+	}
+	handler := operator.CreateOperatorHandler(logger, ctx, kyvernov1.ConditionOperator(condition.Operator))
+	if handler == nil {
+		return false, fmt.Errorf("failed to create handler for condition operator: %s", condition.Operator)
+	}
+	return handler.Evaluate(key, value), nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/stretchr/testify/assert"
 )
@@ -458,3 +459,57 @@ func Test_Eval_Equal_Var_Fail(t *testing.T) {
 		t.Error("expected to fail")
 	}
 }
+
+func TestCheckAnyAllConditions(t *testing.T) {
+	ctx := context.NewContext()
+	assert.NoError(t, ctx.AddResource(map[string]interface{}{
+		"name": "dummy",
+	}))
+	condition := func(key, operator string, value interface{}) kyvernov2beta1.Condition {
+		return kyvernov2beta1.Condition{
+			RawKey:   kyverno.ToJSON(key),
+			Operator: kyvernov2beta1.ConditionOperators[operator],
+			RawValue: kyverno.ToJSON(value),
+		}
+	}
+	matches := condition("{{ request.object.name }}", "Equals", "dummy")
+	differs := condition("{{ request.object.name }}", "Equals", "other")
+	testCases := []struct {
+		name       string
+		conditions kyvernov2beta1.AnyAllConditions
+		want       bool
+		wantErr    bool
+	}{{
+		name: "empty",
+		want: true,
+	}, {
+		name:       "all pass",
+		conditions: kyvernov2beta1.AnyAllConditions{AllConditions: []kyvernov2beta1.Condition{matches}},
+		want:       true,
+	}, {
+		name:       "all fail",
+		conditions: kyvernov2beta1.AnyAllConditions{AllConditions: []kyvernov2beta1.Condition{matches, differs}},
+	}, {
+		name:       "any pass",
+		conditions: kyvernov2beta1.AnyAllConditions{AnyConditions: []kyvernov2beta1.Condition{differs, matches}},
+		want:       true,
+	}, {
+		name:       "any fail",
+		conditions: kyvernov2beta1.AnyAllConditions{AnyConditions: []kyvernov2beta1.Condition{differs}},
+	}, {
+		name:       "unknown operator",
+		conditions: kyvernov2beta1.AnyAllConditions{AllConditions: []kyvernov2beta1.Condition{condition("a", "Unknown", "a")}},
+		wantErr:    true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CheckAnyAllConditions(logr.Discard(), ctx, tc.conditions)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
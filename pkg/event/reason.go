@@ -4,8 +4,11 @@ package event
 type Reason string
 
 const (
-	PolicyViolation Reason = "PolicyViolation"
-	PolicyApplied   Reason = "PolicyApplied"
-	PolicyError     Reason = "PolicyError"
-	PolicySkipped   Reason = "PolicySkipped"
+	PolicyViolation         Reason = "PolicyViolation"
+	PolicyApplied           Reason = "PolicyApplied"
+	PolicyError             Reason = "PolicyError"
+	PolicySkipped           Reason = "PolicySkipped"
+	ResourceExpired         Reason = "ResourceExpired"
+	PolicyExceptionExpiring Reason = "PolicyExceptionExpiring"
+	PolicyExceptionExpired  Reason = "PolicyExceptionExpired"
 )
@@ -0,0 +1,68 @@
+package exporter
+
+import (
+	"strings"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// SourceAdmission is the source of the results produced at admission time
+	SourceAdmission = "kyverno/admission"
+	// SourceBackgroundScan is the source of the results produced by background scans
+	SourceBackgroundScan = "kyverno/background-scan"
+	// EventType is the CloudEvents type of exported policy report results
+	EventType = "io.kyverno.policyreport.result.v1alpha2"
+
+	cloudEventsSpecVersion = "1.0"
+)
+
+// CloudEvent is a policy report result in the CloudEvents structured JSON format
+type CloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject,omitempty"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            EventData `json:"data"`
+}
+
+// EventData is the payload of an exported policy report result
+type EventData struct {
+	// Resource is the resource the result applies to
+	Resource corev1.ObjectReference `json:"resource"`
+	// Result is the policy report result
+	Result policyreportv1alpha2.PolicyReportResult `json:"result"`
+}
+
+func newCloudEvent(source string, resource corev1.ObjectReference, result policyreportv1alpha2.PolicyReportResult, now time.Time) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          source,
+		Type:            EventType,
+		Subject:         subject(resource),
+		Time:            now.UTC(),
+		DataContentType: "application/json",
+		Data: EventData{
+			Resource: resource,
+			Result:   result,
+		},
+	}
+}
+
+// subject returns the kind/namespace/name path of the resource, the namespace is omitted for cluster wide resources
+func subject(resource corev1.ObjectReference) string {
+	var parts []string
+	for _, part := range []string{resource.Kind, resource.Namespace, resource.Name} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
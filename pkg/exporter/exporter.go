@@ -0,0 +1,161 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Interface to export policy report results
+type Interface interface {
+	// Export queues the results of a resource for delivery to all sinks, it never blocks
+	// and the results are dropped for the sinks whose buffer is full
+	Export(source string, resource corev1.ObjectReference, results ...policyreportv1alpha2.PolicyReportResult)
+}
+
+// Exporter streams policy report results to external sinks
+type Exporter interface {
+	Interface
+	// Run delivers the queued results until the context is cancelled
+	Run(context.Context)
+}
+
+// Options configures the delivery of results
+type Options struct {
+	// BufferSize is the number of events queued per sink before events get dropped
+	BufferSize int
+	// BatchSize is the maximum number of events sent at once to a sink
+	BatchSize int
+	// FlushInterval is the maximum time an event waits for a batch to fill up
+	FlushInterval time.Duration
+	// MaxRetries is the number of times a failed batch is retried
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, it doubles after every retry
+	RetryBackoff time.Duration
+}
+
+type sinkQueue struct {
+	sink  Sink
+	queue chan CloudEvent
+}
+
+type exporter struct {
+	options Options
+	sinks   []sinkQueue
+	metrics exporterMetrics
+	logger  logr.Logger
+}
+
+// NewExporter creates an exporter delivering results to the given sinks, it returns nil when there is no sink
+func NewExporter(logger logr.Logger, options Options, sinks ...Sink) Exporter {
+	if len(sinks) == 0 {
+		return nil
+	}
+	if options.BufferSize <= 0 {
+		options.BufferSize = 1
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = 1
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = time.Second
+	}
+	e := exporter{
+		options: options,
+		metrics: newExporterMetrics(logger),
+		logger:  logger,
+	}
+	for _, sink := range sinks {
+		e.sinks = append(e.sinks, sinkQueue{
+			sink:  sink,
+			queue: make(chan CloudEvent, options.BufferSize),
+		})
+	}
+	return &e
+}
+
+func (e *exporter) Export(source string, resource corev1.ObjectReference, results ...policyreportv1alpha2.PolicyReportResult) {
+	now := time.Now()
+	for _, result := range results {
+		event := newCloudEvent(source, resource, result, now)
+		for _, sink := range e.sinks {
+			select {
+			case sink.queue <- event:
+			default:
+				e.metrics.recordEvents(context.Background(), sink.sink.Name(), statusDropped, 1)
+			}
+		}
+	}
+}
+
+func (e *exporter) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, sink := range e.sinks {
+		wg.Add(1)
+		go func(sink sinkQueue) {
+			defer wg.Done()
+			e.run(ctx, sink)
+		}(sink)
+	}
+	wg.Wait()
+}
+
+func (e *exporter) run(ctx context.Context, sink sinkQueue) {
+	logger := e.logger.WithValues("sink", sink.sink.Name())
+	logger.Info("start")
+	defer logger.Info("stop")
+	ticker := time.NewTicker(e.options.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]CloudEvent, 0, e.options.BatchSize)
+	flush := func() {
+		if len(batch) != 0 {
+			e.deliver(ctx, logger, sink.sink, batch)
+			batch = make([]CloudEvent, 0, e.options.BatchSize)
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-sink.queue:
+			batch = append(batch, event)
+			if len(batch) >= e.options.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (e *exporter) deliver(ctx context.Context, logger logr.Logger, sink Sink, batch []CloudEvent) {
+	backoff := e.options.RetryBackoff
+	for retry := 0; ; retry++ {
+		start := time.Now()
+		err := sink.Send(ctx, batch)
+		e.metrics.recordDuration(ctx, sink.Name(), time.Since(start))
+		if err == nil {
+			e.metrics.recordEvents(ctx, sink.Name(), statusDelivered, len(batch))
+			return
+		}
+		var permanent permanentError
+		if errors.As(err, &permanent) || retry >= e.options.MaxRetries {
+			logger.Error(err, "failed to deliver events", "count", len(batch), "retries", retry)
+			e.metrics.recordEvents(ctx, sink.Name(), statusFailed, len(batch))
+			return
+		}
+		logger.V(3).Info("failed to deliver events, retrying", "error", err.Error(), "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			e.metrics.recordEvents(ctx, sink.Name(), statusFailed, len(batch))
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,166 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	testResource = corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx"}
+	testResult   = policyreportv1alpha2.PolicyReportResult{Policy: "require-labels", Rule: "check-team", Result: policyreportv1alpha2.StatusFail}
+)
+
+type webhookStub struct {
+	lock     sync.Mutex
+	failures int
+	status   int
+	batches  [][]CloudEvent
+}
+
+func (s *webhookStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if r.Header.Get("Content-Type") != "application/cloudevents-batch+json; charset=UTF-8" {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(s.status)
+		return
+	}
+	var batch []CloudEvent
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.batches = append(s.batches, batch)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *webhookStub) received() [][]CloudEvent {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.batches
+}
+
+func runExporter(t *testing.T, options Options, sink Sink, results int) func() {
+	t.Helper()
+	exporter := NewExporter(logr.Discard(), options, sink)
+	assert.Assert(t, exporter != nil)
+	for i := 0; i < results; i++ {
+		exporter.Export(SourceAdmission, testResource, testResult)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		exporter.Run(ctx)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("condition not met")
+}
+
+func Test_NewExporter(t *testing.T) {
+	assert.Assert(t, NewExporter(logr.Discard(), Options{}) == nil)
+}
+
+func Test_WebhookSink_Batching(t *testing.T) {
+	stub := &webhookStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+	stop := runExporter(t, Options{BufferSize: 10, BatchSize: 2, FlushInterval: 50 * time.Millisecond}, NewWebhookSink(server.URL, time.Second), 3)
+	defer stop()
+	waitFor(t, func() bool { return len(stub.received()) == 2 })
+	batches := stub.received()
+	assert.Equal(t, len(batches[0]), 2)
+	assert.Equal(t, len(batches[1]), 1)
+	event := batches[0][0]
+	assert.Equal(t, event.SpecVersion, "1.0")
+	assert.Equal(t, event.Type, EventType)
+	assert.Equal(t, event.Source, SourceAdmission)
+	assert.Equal(t, event.Subject, "Pod/default/nginx")
+	assert.Assert(t, event.ID != "")
+	assert.DeepEqual(t, event.Data.Resource, testResource)
+	assert.Equal(t, event.Data.Result.Policy, testResult.Policy)
+}
+
+func Test_WebhookSink_Retries(t *testing.T) {
+	stub := &webhookStub{failures: 2, status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+	stop := runExporter(t, Options{BufferSize: 10, BatchSize: 1, FlushInterval: time.Second, MaxRetries: 3, RetryBackoff: time.Millisecond}, NewWebhookSink(server.URL, time.Second), 1)
+	defer stop()
+	waitFor(t, func() bool { return len(stub.received()) == 1 })
+}
+
+func Test_WebhookSink_PermanentError(t *testing.T) {
+	stub := &webhookStub{status: http.StatusBadRequest, failures: 1}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+	sink := NewWebhookSink(server.URL, time.Second)
+	err := sink.Send(context.Background(), []CloudEvent{newCloudEvent(SourceAdmission, testResource, testResult, time.Now())})
+	var permanent permanentError
+	assert.Assert(t, err != nil)
+	assert.Assert(t, errors.As(err, &permanent))
+}
+
+func Test_Exporter_DropsWhenFull(t *testing.T) {
+	var buffer safeBuffer
+	stop := runExporter(t, Options{BufferSize: 2, BatchSize: 10, FlushInterval: 20 * time.Millisecond}, NewWriterSink("buffer", &buffer), 5)
+	defer stop()
+	waitFor(t, func() bool { return buffer.lines() == 2 })
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, buffer.lines(), 2)
+}
+
+type safeBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *safeBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *safeBuffer) lines() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	count := 0
+	scanner := bufio.NewScanner(bytes.NewReader(b.buffer.Bytes()))
+	for scanner.Scan() {
+		var event CloudEvent
+		if json.Unmarshal(scanner.Bytes(), &event) == nil {
+			count++
+		}
+	}
+	return count
+}
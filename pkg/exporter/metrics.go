@@ -0,0 +1,57 @@
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+const (
+	statusDelivered = "delivered"
+	statusFailed    = "failed"
+	statusDropped   = "dropped"
+)
+
+type exporterMetrics struct {
+	eventsTotal      syncint64.Counter
+	deliveryDuration syncfloat64.Histogram
+}
+
+func newExporterMetrics(logger logr.Logger) exporterMetrics {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	eventsTotal, err := meter.SyncInt64().Counter(
+		"kyverno_policy_results_export",
+		instrument.WithDescription("can be used to track the policy report results delivered, failed or dropped by the results exporter"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_results_export_total")
+	}
+	deliveryDuration, err := meter.SyncFloat64().Histogram(
+		"kyverno_policy_results_export_duration_seconds",
+		instrument.WithDescription("can be used to track the latency of the results exporter sinks"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_results_export_duration_seconds")
+	}
+	return exporterMetrics{
+		eventsTotal:      eventsTotal,
+		deliveryDuration: deliveryDuration,
+	}
+}
+
+func (m exporterMetrics) recordEvents(ctx context.Context, sink, status string, count int) {
+	if m.eventsTotal != nil {
+		m.eventsTotal.Add(ctx, int64(count), attribute.String("sink", sink), attribute.String("status", status))
+	}
+}
+
+func (m exporterMetrics) recordDuration(ctx context.Context, sink string, duration time.Duration) {
+	if m.deliveryDuration != nil {
+		m.deliveryDuration.Record(ctx, duration.Seconds(), attribute.String("sink", sink))
+	}
+}
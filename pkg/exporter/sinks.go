@@ -0,0 +1,122 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sink delivers batches of events to an external system
+type Sink interface {
+	// Name returns the name of the sink, used in logs and metrics
+	Name() string
+	// Send delivers a batch of events, errors wrapped with Permanent are not retried
+	Send(context.Context, []CloudEvent) error
+}
+
+type permanentError struct {
+	error
+}
+
+func (e permanentError) Unwrap() error {
+	return e.error
+}
+
+// Permanent marks an error as not retryable
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink creates a sink posting batches of events to an http endpoint,
+// using the CloudEvents batched content mode
+func NewWebhookSink(url string, timeout time.Duration) Sink {
+	return &webhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *webhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *webhookSink) Send(ctx context.Context, events []CloudEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return Permanent(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/cloudevents-batch+json; charset=UTF-8")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	// only throttling, timeouts and server errors are worth a retry
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode >= 500 {
+		return err
+	}
+	return Permanent(err)
+}
+
+type writerSink struct {
+	name    string
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewWriterSink creates a sink writing events as JSON lines
+func NewWriterSink(name string, writer io.Writer) Sink {
+	return &writerSink{
+		name:    name,
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+// NewStdoutSink creates a sink writing events as JSON lines to the standard output
+func NewStdoutSink() Sink {
+	return NewWriterSink("stdout", os.Stdout)
+}
+
+// NewFileSink creates a sink appending events as JSON lines to a file
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterSink("file", file), nil
+}
+
+func (s *writerSink) Name() string {
+	return s.name
+}
+
+func (s *writerSink) Send(_ context.Context, events []CloudEvent) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, event := range events {
+		if err := s.encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
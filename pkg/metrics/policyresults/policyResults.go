@@ -6,6 +6,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 )
 
 func registerPolicyResultsMetric(
@@ -21,12 +22,13 @@ func registerPolicyResultsMetric(
 	ruleResult metrics.RuleResult,
 	ruleType metrics.RuleType,
 	ruleExecutionCause metrics.RuleExecutionCause,
+	ruleSeverity, ruleCategory string,
 ) {
 	if policyType == metrics.Cluster {
 		policyNamespace = "-"
 	}
 	if m.Config().CheckNamespace(policyNamespace) {
-		m.RecordPolicyResults(ctx, policyValidationMode, policyType, policyBackgroundMode, policyNamespace, policyName, resourceKind, resourceNamespace, resourceRequestOperation, ruleName, ruleResult, ruleType, ruleExecutionCause)
+		m.RecordPolicyResults(ctx, policyValidationMode, policyType, policyBackgroundMode, policyNamespace, policyName, resourceKind, resourceNamespace, resourceRequestOperation, ruleName, ruleResult, ruleType, ruleExecutionCause, ruleSeverity, ruleCategory)
 	}
 }
 
@@ -72,6 +74,7 @@ func ProcessEngineResponse(ctx context.Context, m metrics.MetricsConfigManager,
 			ruleResult,
 			ruleType,
 			executionCause,
+			string(reportutils.RuleSeverity(policy, rule)), reportutils.RuleCategory(policy, rule),
 		)
 	}
 	return nil
@@ -143,6 +143,10 @@ func NewPolicyController(
 
 func (pc *PolicyController) canBackgroundProcess(p kyvernov1.PolicyInterface) bool {
 	logger := pc.log.WithValues("policy", p.GetName())
+	if kyvernov1.IsShadowPolicy(p) {
+		logger.V(4).Info("shadow policies are not processed in the background")
+		return false
+	}
 	if !p.BackgroundProcessingEnabled() {
 		if !p.GetSpec().HasGenerate() && !p.GetSpec().IsMutateExisting() {
 			logger.V(4).Info("background processing is disabled")
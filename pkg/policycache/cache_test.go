@@ -102,6 +102,24 @@ func Test_Add_Validate_Audit(t *testing.T) {
 	}
 }
 
+func Test_Add_Validate_Shadow(t *testing.T) {
+	pCache := newPolicyCache()
+	policy := newPolicy(t)
+	policy.SetAnnotations(map[string]string{kyvernov1.AnnotationPolicyShadow: "true"})
+	setPolicy(pCache, policy)
+	for _, rule := range autogen.ComputeRules(policy) {
+		for _, kind := range rule.MatchResources.Kinds {
+			assert.Equal(t, len(pCache.get(ValidateShadow, kind, "")), 1)
+			assert.Equal(t, len(pCache.get(ValidateEnforce, kind, "")), 0)
+			assert.Equal(t, len(pCache.get(ValidateAudit, kind, "")), 0)
+			assert.Equal(t, len(pCache.get(Mutate, kind, "")), 0)
+			assert.Equal(t, len(pCache.get(Generate, kind, "")), 0)
+		}
+	}
+	unsetPolicy(pCache, policy)
+	assert.Equal(t, len(pCache.get(ValidateShadow, "Pod", "")), 0)
+}
+
 func Test_Add_Remove(t *testing.T) {
 	pCache := newPolicyCache()
 	policy := newPolicy(t)
@@ -12,4 +12,6 @@ const (
 	VerifyImagesMutate
 	VerifyImagesValidate
 	VerifyYAML
+	// ValidateShadow are the shadow policies, they are not part of any other type
+	ValidateShadow
 )
@@ -0,0 +1,140 @@
+package reportsapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// authCacheTTL is how long users and decisions are cached
+	authCacheTTL = 10 * time.Second
+	// authCacheSize is the maximum number of cached users and decisions
+	authCacheSize = 4096
+)
+
+// ErrUnauthenticated is returned when the caller of the reports API could not be authenticated
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Auth authenticates the callers of the reports API and checks their permissions against the API server
+type Auth interface {
+	// Authenticate returns the user owning the bearer token of the request
+	Authenticate(ctx context.Context, r *http.Request) (authenticationv1.UserInfo, error)
+	// Authorize returns true if the user is allowed to perform the action described by the attributes
+	Authorize(ctx context.Context, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error)
+}
+
+type auth struct {
+	tokenReviews         authenticationv1client.TokenReviewInterface
+	subjectAccessReviews authorizationv1client.SubjectAccessReviewInterface
+}
+
+// NewAuth creates an Auth using token reviews to authenticate callers and subject access reviews to authorize them
+func NewAuth(tokenReviews authenticationv1client.TokenReviewInterface, subjectAccessReviews authorizationv1client.SubjectAccessReviewInterface) Auth {
+	return &auth{
+		tokenReviews:         tokenReviews,
+		subjectAccessReviews: subjectAccessReviews,
+	}
+}
+
+func (a *auth) Authenticate(ctx context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return authenticationv1.UserInfo{}, ErrUnauthenticated
+	}
+	review, err := a.tokenReviews.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, ErrUnauthenticated
+	}
+	return review.Status.User, nil
+}
+
+func (a *auth) Authorize(ctx context.Context, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review, err := a.subjectAccessReviews.Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+type cachedAuth struct {
+	inner     Auth
+	users     *cache.LRUExpireCache
+	decisions *cache.LRUExpireCache
+}
+
+// NewCachedAuth creates an Auth caching the users and decisions of the given Auth for a few seconds,
+// users are keyed by a hash of the request bearer token and decisions by user and attributes.
+// Errors are not cached.
+func NewCachedAuth(inner Auth) Auth {
+	return &cachedAuth{
+		inner:     inner,
+		users:     cache.NewLRUExpireCache(authCacheSize),
+		decisions: cache.NewLRUExpireCache(authCacheSize),
+	}
+}
+
+func (a *cachedAuth) Authenticate(ctx context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	hash := sha256.Sum256([]byte(r.Header.Get("Authorization")))
+	key := hex.EncodeToString(hash[:])
+	if user, ok := a.users.Get(key); ok {
+		return user.(authenticationv1.UserInfo), nil
+	}
+	user, err := a.inner.Authenticate(ctx, r)
+	if err != nil {
+		return user, err
+	}
+	a.users.Add(key, user, authCacheTTL)
+	return user, nil
+}
+
+func (a *cachedAuth) Authorize(ctx context.Context, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	data, err := json.Marshal(struct {
+		User       authenticationv1.UserInfo
+		Attributes authorizationv1.ResourceAttributes
+	}{user, attributes})
+	if err != nil {
+		return false, err
+	}
+	key := string(data)
+	if allowed, ok := a.decisions.Get(key); ok {
+		return allowed.(bool), nil
+	}
+	allowed, err := a.inner.Authorize(ctx, user, attributes)
+	if err != nil {
+		return false, err
+	}
+	a.decisions.Add(key, allowed, authCacheTTL)
+	return allowed, nil
+}
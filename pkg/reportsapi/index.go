@@ -0,0 +1,155 @@
+package reportsapi
+
+import (
+	"sort"
+	"sync"
+
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	policyreportv1alpha2informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/policyreport/v1alpha2"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Result is a policy report result flattened with the namespace of its report
+type Result struct {
+	Namespace string                              `json:"namespace,omitempty"`
+	Report    string                              `json:"report"`
+	Policy    string                              `json:"policy"`
+	Rule      string                              `json:"rule,omitempty"`
+	Result    policyreportv1alpha2.PolicyResult   `json:"result"`
+	Severity  policyreportv1alpha2.PolicySeverity `json:"severity,omitempty"`
+	Category  string                              `json:"category,omitempty"`
+	Message   string                              `json:"message,omitempty"`
+	Resource  *corev1.ObjectReference             `json:"resource,omitempty"`
+	Timestamp int64                               `json:"timestamp,omitempty"`
+}
+
+// Index is an in-memory index of the results of the policy reports in the cluster
+type Index interface {
+	// Results returns a snapshot of the indexed results, sorted by namespace, policy, rule and resource
+	Results() []Result
+}
+
+type index struct {
+	lock    sync.RWMutex
+	reports map[string][]Result
+	// sorted is the cached snapshot of the results, reset on every change
+	sorted []Result
+}
+
+// NewIndex creates an index maintained from the policy report informers
+func NewIndex(polrInformer policyreportv1alpha2informers.PolicyReportInformer, cpolrInformer policyreportv1alpha2informers.ClusterPolicyReportInformer) Index {
+	idx := &index{
+		reports: map[string][]Result{},
+	}
+	controllerutils.AddEventHandlersT(
+		polrInformer.Informer(),
+		func(obj *policyreportv1alpha2.PolicyReport) { idx.set(obj) },
+		func(_, obj *policyreportv1alpha2.PolicyReport) { idx.set(obj) },
+		func(obj *policyreportv1alpha2.PolicyReport) { idx.remove(obj) },
+	)
+	controllerutils.AddEventHandlersT(
+		cpolrInformer.Informer(),
+		func(obj *policyreportv1alpha2.ClusterPolicyReport) { idx.set(obj) },
+		func(_, obj *policyreportv1alpha2.ClusterPolicyReport) { idx.set(obj) },
+		func(obj *policyreportv1alpha2.ClusterPolicyReport) { idx.remove(obj) },
+	)
+	return idx
+}
+
+func (i *index) set(report kyvernov1alpha2.ReportInterface) {
+	key, err := cache.MetaNamespaceKeyFunc(report)
+	if err != nil {
+		return
+	}
+	var results []Result
+	for _, result := range report.GetResults() {
+		// shadow policies results are not part of the reported compliance
+		if reportutils.IsShadowResult(result) {
+			continue
+		}
+		entry := Result{
+			Namespace: report.GetNamespace(),
+			Report:    report.GetName(),
+			Policy:    result.Policy,
+			Rule:      result.Rule,
+			Result:    result.Result,
+			Severity:  result.Severity,
+			Category:  result.Category,
+			Message:   result.Message,
+			Timestamp: result.Timestamp.Seconds,
+		}
+		if len(result.Resources) == 0 {
+			results = append(results, entry)
+		}
+		for j := range result.Resources {
+			resource := result.Resources[j]
+			entry.Resource = &resource
+			results = append(results, entry)
+		}
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.reports[key] = results
+	i.sorted = nil
+}
+
+func (i *index) remove(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	delete(i.reports, key)
+	i.sorted = nil
+}
+
+func (i *index) Results() []Result {
+	i.lock.RLock()
+	sorted := i.sorted
+	i.lock.RUnlock()
+	if sorted != nil {
+		return sorted
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.sorted != nil {
+		return i.sorted
+	}
+	sorted = []Result{}
+	for _, results := range i.reports {
+		sorted = append(sorted, results...)
+	}
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return lessResult(sorted[a], sorted[b])
+	})
+	i.sorted = sorted
+	return sorted
+}
+
+func resourceKey(resource *corev1.ObjectReference) string {
+	if resource == nil {
+		return ""
+	}
+	return resource.Kind + "/" + resource.Namespace + "/" + resource.Name + "/" + string(resource.UID)
+}
+
+func lessResult(a, b Result) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	if a.Policy != b.Policy {
+		return a.Policy < b.Policy
+	}
+	if a.Rule != b.Rule {
+		return a.Rule < b.Rule
+	}
+	if ra, rb := resourceKey(a.Resource), resourceKey(b.Resource); ra != rb {
+		return ra < rb
+	}
+	return a.Report < b.Report
+}
@@ -0,0 +1,191 @@
+package reportsapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	GroupByPolicy    = "policy"
+	GroupByRule      = "rule"
+	GroupByNamespace = "namespace"
+	GroupBySeverity  = "severity"
+	GroupByCategory  = "category"
+)
+
+// Filter selects results, empty fields match everything
+type Filter struct {
+	Namespace string
+	Policy    string
+	Rule      string
+	Severity  string
+	Category  string
+	Result    string
+	// Namespaces restricts the results to the given namespaces when not nil, cluster wide results have an empty namespace
+	Namespaces map[string]bool
+}
+
+func (f Filter) matches(result Result) bool {
+	return (f.Namespace == "" || f.Namespace == result.Namespace) &&
+		(f.Namespaces == nil || f.Namespaces[result.Namespace]) &&
+		(f.Policy == "" || f.Policy == result.Policy) &&
+		(f.Rule == "" || f.Rule == result.Rule) &&
+		(f.Severity == "" || f.Severity == string(result.Severity)) &&
+		(f.Category == "" || f.Category == result.Category) &&
+		(f.Result == "" || f.Result == string(result.Result))
+}
+
+// Count holds the number of results per status of a group
+type Count struct {
+	Group map[string]string `json:"group,omitempty"`
+	policyreportv1alpha2.PolicyReportSummary
+}
+
+// ResourceCount holds the number of failing results of a resource
+type ResourceCount struct {
+	Resource corev1.ObjectReference `json:"resource"`
+	Fail     int                    `json:"fail"`
+	Error    int                    `json:"error"`
+}
+
+// ResultsPage is a page of results
+type ResultsPage struct {
+	Results []Result `json:"results"`
+	// Continue is the token to pass to get the next page, empty on the last page
+	Continue string `json:"continue,omitempty"`
+	// Total is the number of results matching the filter
+	Total int `json:"total"`
+}
+
+func groupValue(result Result, groupBy string) (string, error) {
+	switch groupBy {
+	case GroupByPolicy:
+		return result.Policy, nil
+	case GroupByRule:
+		return result.Rule, nil
+	case GroupByNamespace:
+		return result.Namespace, nil
+	case GroupBySeverity:
+		return string(result.Severity), nil
+	case GroupByCategory:
+		return result.Category, nil
+	}
+	return "", fmt.Errorf("unsupported group by %s", groupBy)
+}
+
+func addToSummary(summary *policyreportv1alpha2.PolicyReportSummary, status policyreportv1alpha2.PolicyResult) {
+	switch status {
+	case policyreportv1alpha2.StatusPass:
+		summary.Pass++
+	case policyreportv1alpha2.StatusFail:
+		summary.Fail++
+	case policyreportv1alpha2.StatusWarn:
+		summary.Warn++
+	case policyreportv1alpha2.StatusError:
+		summary.Error++
+	case policyreportv1alpha2.StatusSkip:
+		summary.Skip++
+	}
+}
+
+// Counts counts the results matching the filter per status, grouped by the given fields
+func Counts(index Index, filter Filter, groupBy ...string) ([]Count, error) {
+	counts := map[string]*Count{}
+	var keys []string
+	for _, result := range index.Results() {
+		if !filter.matches(result) {
+			continue
+		}
+		group := map[string]string{}
+		values := make([]string, 0, len(groupBy))
+		for _, field := range groupBy {
+			value, err := groupValue(result, field)
+			if err != nil {
+				return nil, err
+			}
+			group[field] = value
+			values = append(values, value)
+		}
+		key := strings.Join(values, "\x00")
+		count := counts[key]
+		if count == nil {
+			count = &Count{}
+			if len(groupBy) != 0 {
+				count.Group = group
+			}
+			counts[key] = count
+			keys = append(keys, key)
+		}
+		addToSummary(&count.PolicyReportSummary, result.Result)
+	}
+	sort.Strings(keys)
+	out := make([]Count, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, *counts[key])
+	}
+	return out, nil
+}
+
+// TopFailingResources returns the resources with the most failing results matching the filter
+func TopFailingResources(index Index, filter Filter, limit int) []ResourceCount {
+	counts := map[string]*ResourceCount{}
+	for _, result := range index.Results() {
+		if result.Resource == nil || !filter.matches(result) {
+			continue
+		}
+		if result.Result != policyreportv1alpha2.StatusFail && result.Result != policyreportv1alpha2.StatusError {
+			continue
+		}
+		key := resourceKey(result.Resource)
+		count := counts[key]
+		if count == nil {
+			count = &ResourceCount{Resource: *result.Resource}
+			counts[key] = count
+		}
+		if result.Result == policyreportv1alpha2.StatusFail {
+			count.Fail++
+		} else {
+			count.Error++
+		}
+	}
+	out := make([]ResourceCount, 0, len(counts))
+	for _, count := range counts {
+		out = append(out, *count)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if ti, tj := out[i].Fail+out[i].Error, out[j].Fail+out[j].Error; ti != tj {
+			return ti > tj
+		}
+		return resourceKey(&out[i].Resource) < resourceKey(&out[j].Resource)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// Results returns a page of the results matching the filter, starting at the given offset.
+// Offsets are not stable across report changes, a page can skip or repeat results when reports change.
+func Results(index Index, filter Filter, offset, limit int) ResultsPage {
+	var matching []Result
+	for _, result := range index.Results() {
+		if filter.matches(result) {
+			matching = append(matching, result)
+		}
+	}
+	page := ResultsPage{Results: []Result{}, Total: len(matching)}
+	if offset >= len(matching) {
+		return page
+	}
+	end := len(matching)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+		page.Continue = fmt.Sprint(end)
+	}
+	page.Results = matching[offset:end]
+	return page
+}
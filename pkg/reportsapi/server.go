@@ -0,0 +1,250 @@
+package reportsapi
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/julienschmidt/httprouter"
+	"github.com/kyverno/kyverno/pkg/logging"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+const (
+	SummaryPath      = "/api/v1/summary"
+	TopResourcesPath = "/api/v1/top-failing-resources"
+	ResultsPath      = "/api/v1/results"
+	defaultPageSize  = 100
+	maxPageSize      = 1000
+	defaultTopLimit  = 10
+	// policyReportsGroup is the API group of the policy reports the access of the callers is checked against
+	policyReportsGroup = "wgpolicyk8s.io"
+)
+
+// TlsProvider returns the PEM encoded certificate and key the server is serving with
+type TlsProvider func() ([]byte, []byte, error)
+
+// Server serves the read-only reports API
+type Server interface {
+	// Run starts the server in a separate goroutine and returns immediately
+	Run()
+	// Stop shuts down the server
+	Stop(context.Context)
+}
+
+type server struct {
+	server *http.Server
+	logger logr.Logger
+}
+
+// NewServer creates a TLS server listening on the given address and serving requests with the given handler
+func NewServer(logger logr.Logger, address string, handler http.Handler, tlsProvider TlsProvider) Server {
+	return &server{
+		server: &http.Server{
+			Addr: address,
+			TLSConfig: &tls.Config{
+				GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+					certPem, keyPem, err := tlsProvider()
+					if err != nil {
+						return nil, err
+					}
+					pair, err := tls.X509KeyPair(certPem, keyPem)
+					if err != nil {
+						return nil, err
+					}
+					return &pair, nil
+				},
+				MinVersion: tls.VersionTLS12,
+			},
+			Handler:           handler,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			ReadHeaderTimeout: 30 * time.Second,
+			IdleTimeout:       5 * time.Minute,
+			ErrorLog:          logging.StdLogger(logger, ""),
+		},
+		logger: logger,
+	}
+}
+
+func (s *server) Run() {
+	go func() {
+		s.logger.Info("start", "address", s.server.Addr)
+		if err := s.server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			s.logger.Error(err, "failed to start server")
+		}
+	}()
+}
+
+func (s *server) Stop(ctx context.Context) {
+	if err := s.server.Shutdown(ctx); err != nil {
+		if err := s.server.Close(); err != nil {
+			s.logger.Error(err, "failed to stop server")
+		}
+	}
+}
+
+// NewHandler creates the router of the reports API, more endpoints can be registered on the returned router.
+// Callers are authenticated with their bearer token and only get the results of the policy reports they can list.
+func NewHandler(logger logr.Logger, index Index, auth Auth) *httprouter.Router {
+	mux := httprouter.New()
+	mux.HandlerFunc("GET", SummaryPath, withAccess(logger, index, auth, func(w http.ResponseWriter, r *http.Request, filter Filter) {
+		var groupBy []string
+		if value := r.URL.Query().Get("groupBy"); value != "" {
+			groupBy = strings.Split(value, ",")
+		}
+		counts, err := Counts(index, filter, groupBy...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(logger, w, counts)
+	}))
+	mux.HandlerFunc("GET", TopResourcesPath, withAccess(logger, index, auth, func(w http.ResponseWriter, r *http.Request, filter Filter) {
+		limit, err := parseInt(r, "limit", defaultTopLimit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(logger, w, TopFailingResources(index, filter, limit))
+	}))
+	mux.HandlerFunc("GET", ResultsPath, withAccess(logger, index, auth, func(w http.ResponseWriter, r *http.Request, filter Filter) {
+		limit, err := parseInt(r, "limit", defaultPageSize)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if limit <= 0 || limit > maxPageSize {
+			limit = maxPageSize
+		}
+		offset, err := parseInt(r, "continue", 0)
+		if err != nil || offset < 0 {
+			http.Error(w, "invalid continue token", http.StatusBadRequest)
+			return
+		}
+		writeJSON(logger, w, Results(index, filter, offset, limit))
+	}))
+	return mux
+}
+
+// withAccess authenticates the caller and restricts the filter of the request to the namespaces the caller
+// can list policy reports in, cluster wide results require to list cluster policy reports
+func withAccess(logger logr.Logger, index Index, auth Auth, inner func(http.ResponseWriter, *http.Request, Filter)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user, err := auth.Authenticate(ctx, r)
+		if err != nil {
+			if !errors.Is(err, ErrUnauthenticated) {
+				logger.Error(err, "failed to authenticate request")
+			}
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		filter := parseFilter(r)
+		canList := func(resource, namespace string) (bool, error) {
+			return auth.Authorize(ctx, user, authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     policyReportsGroup,
+				Resource:  resource,
+			})
+		}
+		namespaces, err := allowedNamespaces(index, filter, canList)
+		if err != nil {
+			logger.Error(err, "failed to authorize request", "user", user.Username)
+			http.Error(w, "failed to authorize request", http.StatusInternalServerError)
+			return
+		}
+		if filter.Namespace != "" && !namespaces[filter.Namespace] {
+			http.Error(w, forbidden(user, filter.Namespace), http.StatusForbidden)
+			return
+		}
+		filter.Namespaces = namespaces
+		inner(w, r, filter)
+	}
+}
+
+// allowedNamespaces returns the namespaces of the indexed results the caller can list policy reports in,
+// or nil when the caller can list the policy reports of the whole cluster
+func allowedNamespaces(index Index, filter Filter, canList func(resource, namespace string) (bool, error)) (map[string]bool, error) {
+	clusterReports, err := canList("clusterpolicyreports", "")
+	if err != nil {
+		return nil, err
+	}
+	allReports, err := canList("policyreports", "")
+	if err != nil {
+		return nil, err
+	}
+	if clusterReports && allReports {
+		return nil, nil
+	}
+	namespaces := map[string]bool{}
+	candidates := []string{filter.Namespace}
+	if filter.Namespace == "" {
+		candidates = indexedNamespaces(index)
+	}
+	for _, namespace := range candidates {
+		if namespace == "" {
+			namespaces[namespace] = clusterReports
+			continue
+		}
+		allowed := allReports
+		if !allowed {
+			if allowed, err = canList("policyreports", namespace); err != nil {
+				return nil, err
+			}
+		}
+		namespaces[namespace] = allowed
+	}
+	return namespaces, nil
+}
+
+// indexedNamespaces returns the distinct namespaces of the indexed results
+func indexedNamespaces(index Index) []string {
+	var namespaces []string
+	for _, result := range index.Results() {
+		// results are sorted by namespace
+		if len(namespaces) == 0 || namespaces[len(namespaces)-1] != result.Namespace {
+			namespaces = append(namespaces, result.Namespace)
+		}
+	}
+	return namespaces
+}
+
+func forbidden(user authenticationv1.UserInfo, namespace string) string {
+	return "user " + user.Username + " cannot list policyreports in namespace " + namespace
+}
+
+func parseFilter(r *http.Request) Filter {
+	query := r.URL.Query()
+	return Filter{
+		Namespace: query.Get("namespace"),
+		Policy:    query.Get("policy"),
+		Rule:      query.Get("rule"),
+		Severity:  query.Get("severity"),
+		Category:  query.Get("category"),
+		Result:    query.Get("result"),
+	}
+}
+
+func parseInt(r *http.Request, name string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func writeJSON(logger logr.Logger, w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		logger.Error(err, "failed to write response")
+	}
+}
@@ -0,0 +1,244 @@
+package reportsapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newIndex(reports ...kyvernov1alpha2.ReportInterface) *index {
+	idx := &index{
+		reports: map[string][]Result{},
+	}
+	for _, report := range reports {
+		idx.set(report)
+	}
+	return idx
+}
+
+func testIndex() *index {
+	pod := func(name string) []corev1.ObjectReference {
+		return []corev1.ObjectReference{{Kind: "Pod", Namespace: "default", Name: name, UID: types.UID("uid-" + name)}}
+	}
+	result := func(policy, rule string, status policyreportv1alpha2.PolicyResult, severity policyreportv1alpha2.PolicySeverity, resources []corev1.ObjectReference) policyreportv1alpha2.PolicyReportResult {
+		return policyreportv1alpha2.PolicyReportResult{Policy: policy, Rule: rule, Result: status, Severity: severity, Resources: resources}
+	}
+	return newIndex(
+		&policyreportv1alpha2.PolicyReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cpol-require-labels"},
+			Results: []policyreportv1alpha2.PolicyReportResult{
+				result("require-labels", "check-team", policyreportv1alpha2.StatusFail, policyreportv1alpha2.SeverityHigh, pod("a")),
+				result("require-labels", "check-team", policyreportv1alpha2.StatusFail, policyreportv1alpha2.SeverityHigh, pod("b")),
+				result("require-labels", "check-app", policyreportv1alpha2.StatusPass, policyreportv1alpha2.SeverityHigh, pod("a")),
+			},
+		},
+		&policyreportv1alpha2.PolicyReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cpol-disallow-latest"},
+			Results: []policyreportv1alpha2.PolicyReportResult{
+				result("disallow-latest", "check-tag", policyreportv1alpha2.StatusFail, policyreportv1alpha2.SeverityMedium, pod("a")),
+			},
+		},
+		&policyreportv1alpha2.ClusterPolicyReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "cpol-require-labels"},
+			Results: []policyreportv1alpha2.PolicyReportResult{
+				result("require-labels", "check-team", policyreportv1alpha2.StatusPass, policyreportv1alpha2.SeverityHigh, []corev1.ObjectReference{{Kind: "Namespace", Name: "default"}}),
+			},
+		},
+	)
+}
+
+func TestCounts(t *testing.T) {
+	idx := testIndex()
+	counts, err := Counts(idx, Filter{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(counts), 1)
+	assert.DeepEqual(t, counts[0].PolicyReportSummary, policyreportv1alpha2.PolicyReportSummary{Pass: 2, Fail: 3})
+	counts, err = Counts(idx, Filter{Namespace: "default"}, GroupByPolicy, GroupBySeverity)
+	assert.NilError(t, err)
+	assert.Equal(t, len(counts), 2)
+	assert.DeepEqual(t, counts[0].Group, map[string]string{GroupByPolicy: "disallow-latest", GroupBySeverity: "medium"})
+	assert.DeepEqual(t, counts[1].PolicyReportSummary, policyreportv1alpha2.PolicyReportSummary{Pass: 1, Fail: 2})
+	_, err = Counts(idx, Filter{}, "unknown")
+	assert.Assert(t, err != nil)
+	// removing a report updates the index
+	idx.remove(&policyreportv1alpha2.PolicyReport{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cpol-disallow-latest"}})
+	counts, err = Counts(idx, Filter{Result: "fail"})
+	assert.NilError(t, err)
+	assert.Equal(t, counts[0].Fail, 2)
+}
+
+func TestTopFailingResources(t *testing.T) {
+	top := TopFailingResources(testIndex(), Filter{}, 1)
+	assert.Equal(t, len(top), 1)
+	assert.Equal(t, top[0].Resource.Name, "a")
+	assert.Equal(t, top[0].Fail, 2)
+}
+
+func TestResults(t *testing.T) {
+	idx := testIndex()
+	page := Results(idx, Filter{}, 0, 2)
+	assert.Equal(t, page.Total, 5)
+	assert.Equal(t, len(page.Results), 2)
+	assert.Equal(t, page.Continue, "2")
+	// cluster results come first
+	assert.Equal(t, page.Results[0].Namespace, "")
+	page = Results(idx, Filter{}, 4, 2)
+	assert.Equal(t, len(page.Results), 1)
+	assert.Equal(t, page.Continue, "")
+}
+
+// fakeAuth authenticates the tokens it knows and allows the listed resources, keyed by namespace/resource
+type fakeAuth struct {
+	users   map[string]string
+	allowed map[string]bool
+}
+
+func (a fakeAuth) Authenticate(_ context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	user, ok := a.users[r.Header.Get("Authorization")]
+	if !ok {
+		return authenticationv1.UserInfo{}, ErrUnauthenticated
+	}
+	return authenticationv1.UserInfo{Username: user}, nil
+}
+
+func (a fakeAuth) Authorize(_ context.Context, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	if user.Username == "admin" {
+		return true, nil
+	}
+	return a.allowed[attributes.Namespace+"/"+attributes.Resource], nil
+}
+
+func newFakeAuth(allowed ...string) fakeAuth {
+	auth := fakeAuth{
+		users: map[string]string{
+			"Bearer admin": "admin",
+			"Bearer user":  "user",
+		},
+		allowed: map[string]bool{},
+	}
+	for _, key := range allowed {
+		auth.allowed[key] = true
+	}
+	return auth
+}
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler(logr.Discard(), testIndex(), newFakeAuth())
+	get := func(url string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		request.Header.Set("Authorization", "Bearer admin")
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+	recorder := get(SummaryPath + "?groupBy=namespace")
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	var counts []Count
+	assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &counts))
+	assert.Equal(t, len(counts), 2)
+	assert.Equal(t, get(SummaryPath+"?groupBy=unknown").Code, http.StatusBadRequest)
+	recorder = get(ResultsPath + "?limit=3&policy=require-labels")
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	var page ResultsPage
+	assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	assert.Equal(t, page.Total, 4)
+	assert.Equal(t, page.Continue, "3")
+	assert.Equal(t, get(ResultsPath+"?continue=abc").Code, http.StatusBadRequest)
+	recorder = get(TopResourcesPath)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+}
+
+func TestHandler_Access(t *testing.T) {
+	get := func(handler http.Handler, token, url string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, url, nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+	total := func(recorder *httptest.ResponseRecorder) int {
+		var page ResultsPage
+		assert.NilError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+		return page.Total
+	}
+	// unauthenticated
+	handler := NewHandler(logr.Discard(), testIndex(), newFakeAuth())
+	assert.Equal(t, get(handler, "", ResultsPath).Code, http.StatusUnauthorized)
+	assert.Equal(t, get(handler, "unknown", ResultsPath).Code, http.StatusUnauthorized)
+	// no access
+	recorder := get(handler, "user", ResultsPath)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, total(recorder), 0)
+	assert.Equal(t, get(handler, "user", ResultsPath+"?namespace=default").Code, http.StatusForbidden)
+	// namespace access
+	handler = NewHandler(logr.Discard(), testIndex(), newFakeAuth("default/policyreports"))
+	recorder = get(handler, "user", ResultsPath)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, total(recorder), 4)
+	recorder = get(handler, "user", ResultsPath+"?namespace=default")
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, total(recorder), 4)
+	// cluster reports access
+	handler = NewHandler(logr.Discard(), testIndex(), newFakeAuth("/clusterpolicyreports"))
+	recorder = get(handler, "user", ResultsPath)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, total(recorder), 1)
+	// full access
+	recorder = get(handler, "admin", ResultsPath)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, total(recorder), 5)
+}
+
+// countingAuth counts the calls made to the wrapped Auth
+type countingAuth struct {
+	Auth
+	authentications int
+	authorizations  int
+}
+
+func (a *countingAuth) Authenticate(ctx context.Context, r *http.Request) (authenticationv1.UserInfo, error) {
+	a.authentications++
+	return a.Auth.Authenticate(ctx, r)
+}
+
+func (a *countingAuth) Authorize(ctx context.Context, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	a.authorizations++
+	return a.Auth.Authorize(ctx, user, attributes)
+}
+
+func TestHandler_CachedAuth(t *testing.T) {
+	inner := &countingAuth{Auth: newFakeAuth("default/policyreports")}
+	handler := NewHandler(logr.Discard(), testIndex(), NewCachedAuth(inner))
+	get := func(token string) int {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, ResultsPath, nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+	assert.Equal(t, get("user"), http.StatusOK)
+	authorizations := inner.authorizations
+	assert.Assert(t, authorizations > 0)
+	// the user and the decisions are cached
+	assert.Equal(t, get("user"), http.StatusOK)
+	assert.Equal(t, inner.authentications, 1)
+	assert.Equal(t, inner.authorizations, authorizations)
+	// failed authentications are not cached
+	assert.Equal(t, get("unknown"), http.StatusUnauthorized)
+	assert.Equal(t, get("unknown"), http.StatusUnauthorized)
+	assert.Equal(t, inner.authentications, 3)
+}
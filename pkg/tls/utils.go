@@ -106,5 +106,11 @@ func GenerateTLSPairSecretName() string {
 }
 
 func GenerateRootCASecretName() string {
-	return inClusterServiceName() + ".kyverno-tls-ca"
+	return RootCASecretName(inClusterServiceName())
+}
+
+// RootCASecretName returns the name of the secret holding the root CA of an in cluster service,
+// the service is given in the <name>.<namespace>.svc form
+func RootCASecretName(service string) string {
+	return service + ".kyverno-tls-ca"
 }
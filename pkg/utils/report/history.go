@@ -0,0 +1,140 @@
+package report
+
+import (
+	"strings"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// FirstSeenProperty is the result property holding the time the result was first reported
+	FirstSeenProperty = "firstSeen"
+	// LastSeenProperty is the result property holding the time the result was last reported
+	LastSeenProperty = "lastSeen"
+	// HistoryProperty is the result property holding the last status transitions of the result,
+	// formatted as a comma separated list of <status>@<time>, oldest first
+	HistoryProperty = "history"
+)
+
+// Transition is a change of status of a result
+type Transition struct {
+	Status policyreportv1alpha2.PolicyResult
+	Time   time.Time
+}
+
+// Remediation is a failing result that started passing
+type Remediation struct {
+	Policy   string
+	Rule     string
+	Resource corev1.ObjectReference
+	// Duration is the time the result stayed in violation
+	Duration time.Duration
+}
+
+// ResultKey returns the key identifying a result across reports, made of the policy, the rule and the resource uid
+func ResultKey(result policyreportv1alpha2.PolicyReportResult) string {
+	key := result.Policy + "/" + result.Rule
+	for _, resource := range result.Resources {
+		key += "/" + string(resource.UID)
+	}
+	return key
+}
+
+// ResultHistory returns the status transitions recorded in a result
+func ResultHistory(result policyreportv1alpha2.PolicyReportResult) []Transition {
+	value := result.Properties[HistoryProperty]
+	if value == "" {
+		return nil
+	}
+	var history []Transition
+	for _, entry := range strings.Split(value, ",") {
+		status, at, found := strings.Cut(entry, "@")
+		if !found {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			continue
+		}
+		history = append(history, Transition{Status: policyreportv1alpha2.PolicyResult(status), Time: t})
+	}
+	return history
+}
+
+// ResultFirstSeen returns the time a result was first reported, or the zero time when unknown
+func ResultFirstSeen(result policyreportv1alpha2.PolicyReportResult) time.Time {
+	t, _ := time.Parse(time.RFC3339, result.Properties[FirstSeenProperty])
+	return t
+}
+
+// ResultSince returns the time a result entered its current status, or the zero time when unknown
+func ResultSince(result policyreportv1alpha2.PolicyReportResult) time.Time {
+	history := ResultHistory(result)
+	if len(history) != 0 && history[len(history)-1].Status == result.Result {
+		return history[len(history)-1].Time
+	}
+	return ResultFirstSeen(result)
+}
+
+func isViolation(status policyreportv1alpha2.PolicyResult) bool {
+	return status == policyreportv1alpha2.StatusFail || status == policyreportv1alpha2.StatusError
+}
+
+func formatHistory(history []Transition) string {
+	entries := make([]string, 0, len(history))
+	for _, transition := range history {
+		entries = append(entries, string(transition.Status)+"@"+transition.Time.UTC().Format(time.RFC3339))
+	}
+	return strings.Join(entries, ",")
+}
+
+// TrackHistory carries the first seen time and the status transitions of the previous results over to
+// the current ones, keeping at most historySize transitions per result. It returns the results that went
+// from a violation to a passing or skipped status.
+func TrackHistory(previous map[string]policyreportv1alpha2.PolicyReportResult, historySize int, results []policyreportv1alpha2.PolicyReportResult) []Remediation {
+	if historySize < 1 {
+		historySize = 1
+	}
+	var remediations []Remediation
+	for i := range results {
+		result := &results[i]
+		now := time.Unix(result.Timestamp.Seconds, 0).UTC()
+		properties := make(map[string]string, len(result.Properties)+3)
+		for k, v := range result.Properties {
+			properties[k] = v
+		}
+		var history []Transition
+		firstSeen := now
+		if prev, ok := previous[ResultKey(*result)]; ok {
+			history = ResultHistory(prev)
+			if t := ResultFirstSeen(prev); !t.IsZero() {
+				firstSeen = t
+			}
+			// shadow policies don't remediate violations
+			if !IsShadowResult(*result) && prev.Result != result.Result && isViolation(prev.Result) &&
+				(result.Result == policyreportv1alpha2.StatusPass || result.Result == policyreportv1alpha2.StatusSkip) {
+				remediation := Remediation{Policy: result.Policy, Rule: result.Rule}
+				if len(result.Resources) != 0 {
+					remediation.Resource = result.Resources[0]
+				}
+				if since := ResultSince(prev); !since.IsZero() && now.After(since) {
+					remediation.Duration = now.Sub(since)
+				}
+				remediations = append(remediations, remediation)
+			}
+		}
+		if len(history) == 0 || history[len(history)-1].Status != result.Result {
+			history = append(history, Transition{Status: result.Result, Time: now})
+		}
+		if len(history) > historySize {
+			history = history[len(history)-historySize:]
+		}
+		properties[FirstSeenProperty] = firstSeen.Format(time.RFC3339)
+		properties[LastSeenProperty] = now.Format(time.RFC3339)
+		properties[HistoryProperty] = formatHistory(history)
+		result.Properties = properties
+	}
+	return remediations
+}
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTrackHistory(t *testing.T) {
+	resource := corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "nginx", UID: "uid"}
+	result := func(status policyreportv1alpha2.PolicyResult, at int64) policyreportv1alpha2.PolicyReportResult {
+		return policyreportv1alpha2.PolicyReportResult{
+			Policy:    "require-labels",
+			Rule:      "check-team",
+			Result:    status,
+			Resources: []corev1.ObjectReference{resource},
+			Timestamp: metav1.Timestamp{Seconds: at},
+		}
+	}
+	track := func(previous *policyreportv1alpha2.PolicyReportResult, current policyreportv1alpha2.PolicyReportResult, historySize int) (policyreportv1alpha2.PolicyReportResult, []Remediation) {
+		prev := map[string]policyreportv1alpha2.PolicyReportResult{}
+		if previous != nil {
+			prev[ResultKey(*previous)] = *previous
+		}
+		results := []policyreportv1alpha2.PolicyReportResult{current}
+		remediations := TrackHistory(prev, historySize, results)
+		return results[0], remediations
+	}
+	// new violation
+	first, remediations := track(nil, result(policyreportv1alpha2.StatusFail, 100), 10)
+	assert.Equal(t, len(remediations), 0)
+	assert.Equal(t, first.Properties[FirstSeenProperty], time.Unix(100, 0).UTC().Format(time.RFC3339))
+	assert.Equal(t, first.Properties[LastSeenProperty], time.Unix(100, 0).UTC().Format(time.RFC3339))
+	assert.Equal(t, len(ResultHistory(first)), 1)
+	// same status, no new transition
+	second, remediations := track(&first, result(policyreportv1alpha2.StatusFail, 200), 10)
+	assert.Equal(t, len(remediations), 0)
+	assert.Equal(t, second.Properties[FirstSeenProperty], first.Properties[FirstSeenProperty])
+	assert.Equal(t, second.Properties[LastSeenProperty], time.Unix(200, 0).UTC().Format(time.RFC3339))
+	assert.Equal(t, len(ResultHistory(second)), 1)
+	assert.Equal(t, ResultSince(second), time.Unix(100, 0).UTC())
+	// remediated
+	third, remediations := track(&second, result(policyreportv1alpha2.StatusPass, 400), 10)
+	assert.Equal(t, len(remediations), 1)
+	assert.Equal(t, remediations[0].Duration, 300*time.Second)
+	assert.DeepEqual(t, remediations[0].Resource, resource)
+	history := ResultHistory(third)
+	assert.Equal(t, len(history), 2)
+	assert.Equal(t, history[1].Status, policyreportv1alpha2.PolicyResult(policyreportv1alpha2.StatusPass))
+	// history is bounded
+	fourth, _ := track(&third, result(policyreportv1alpha2.StatusFail, 500), 2)
+	history = ResultHistory(fourth)
+	assert.Equal(t, len(history), 2)
+	assert.Equal(t, history[0].Time, time.Unix(400, 0).UTC())
+	assert.Equal(t, ResultSince(fourth), time.Unix(500, 0).UTC())
+}
@@ -6,6 +6,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -39,6 +40,32 @@ func BuildAdmissionReport(resource unstructured.Unstructured, request *admission
 	return report
 }
 
+// BuildResourceAdmissionReport creates an admission report owned by an existing resource, used to report the results
+// of rules not evaluated by the validation handlers (mutations, generate and mutate existing rules).
+// Skipped rules are left out, they did not change anything.
+func BuildResourceAdmissionReport(name string, resource unstructured.Unstructured, responses ...*engineapi.EngineResponse) kyvernov1alpha2.ReportInterface {
+	gvk := resource.GroupVersionKind()
+	report := NewAdmissionReport(resource.GetNamespace(), name, resource.GetName(), resource.GetUID(), metav1.GroupVersionKind(gvk))
+	controllerutils.SetOwner(report, resource.GetAPIVersion(), resource.GetKind(), resource.GetName(), resource.GetUID())
+	SetResourceVersionLabels(report, &resource)
+	var applied []*engineapi.EngineResponse
+	for _, response := range responses {
+		var rules []engineapi.RuleResponse
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status != engineapi.RuleStatusSkip {
+				rules = append(rules, rule)
+			}
+		}
+		if len(rules) > 0 {
+			copy := *response
+			copy.PolicyResponse.Rules = rules
+			applied = append(applied, &copy)
+		}
+	}
+	SetResponses(report, applied...)
+	return report
+}
+
 func NewBackgroundScanReport(namespace, name string, gvk schema.GroupVersionKind, owner string, uid types.UID) kyvernov1alpha2.ReportInterface {
 	var report kyvernov1alpha2.ReportInterface
 	if namespace == "" {
@@ -67,3 +94,25 @@ func NewPolicyReport(namespace, name string, results ...policyreportv1alpha2.Pol
 	SetResults(report, results...)
 	return report
 }
+
+// NewResourcePolicyReport creates a policy report scoped to a single resource, the report is owned
+// by the resource so that it is garbage collected with it
+func NewResourcePolicyReport(namespace string, scope corev1.ObjectReference, results ...policyreportv1alpha2.PolicyReportResult) kyvernov1alpha2.ReportInterface {
+	report := NewPolicyReport(namespace, string(scope.UID), results...)
+	SetScope(report, scope)
+	return report
+}
+
+// SetScope sets the report scope and owner to the given resource, it does nothing for reports other than policy reports
+func SetScope(report kyvernov1alpha2.ReportInterface, scope corev1.ObjectReference) {
+	switch typed := report.(type) {
+	case *policyreportv1alpha2.PolicyReport:
+		typed.Scope = &scope
+	case *policyreportv1alpha2.ClusterPolicyReport:
+		typed.Scope = &scope
+	default:
+		return
+	}
+	controllerutils.SetOwner(report, scope.APIVersion, scope.Kind, scope.Name, scope.UID)
+	SetResourceLabels(report, scope.UID)
+}
@@ -15,6 +15,42 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
+const (
+	// ExceptionsProperty is the result property listing the keys of the policy exceptions applied to the rule
+	ExceptionsProperty = "exceptions"
+	// RuleTypeProperty is the result property holding the type of mutate and generate rules, Mutation or Generation
+	RuleTypeProperty = "ruleType"
+	// ShadowProperty is the result property set to true for the results of shadow policies
+	ShadowProperty = "shadow"
+)
+
+// IsShadowResult returns true if the result was produced by a shadow policy, shadow results are kept in
+// dedicated reports and must not be aggregated with the results of active policies
+func IsShadowResult(result policyreportv1alpha2.PolicyReportResult) bool {
+	return result.Properties[ShadowProperty] == "true"
+}
+
+// SplitShadowResults separates the results of active policies from the results of shadow policies
+func SplitShadowResults(results []policyreportv1alpha2.PolicyReportResult) ([]policyreportv1alpha2.PolicyReportResult, []policyreportv1alpha2.PolicyReportResult) {
+	var active, shadow []policyreportv1alpha2.PolicyReportResult
+	for _, result := range results {
+		if IsShadowResult(result) {
+			shadow = append(shadow, result)
+		} else {
+			active = append(active, result)
+		}
+	}
+	return active, shadow
+}
+
+// ResultExceptions returns the keys of the policy exceptions applied to a result
+func ResultExceptions(result policyreportv1alpha2.PolicyReportResult) []string {
+	if value := result.Properties[ExceptionsProperty]; value != "" {
+		return strings.Split(value, ",")
+	}
+	return nil
+}
+
 func SortReportResults(results []policyreportv1alpha2.PolicyReportResult) {
 	slices.SortFunc(results, func(a policyreportv1alpha2.PolicyReportResult, b policyreportv1alpha2.PolicyReportResult) bool {
 		if a.Policy != b.Policy {
@@ -81,6 +117,22 @@ func severityFromString(severity string) policyreportv1alpha2.PolicySeverity {
 	return ""
 }
 
+// RuleSeverity returns the severity of a rule result, the rule report metadata takes precedence over the policy annotation
+func RuleSeverity(policy kyvernov1.PolicyInterface, ruleResult engineapi.RuleResponse) policyreportv1alpha2.PolicySeverity {
+	if ruleResult.ReportMetadata != nil && ruleResult.ReportMetadata.Severity != "" {
+		return severityFromString(ruleResult.ReportMetadata.Severity)
+	}
+	return severityFromString(policy.GetAnnotations()[kyvernov1.AnnotationPolicySeverity])
+}
+
+// RuleCategory returns the category of a rule result, the rule report metadata takes precedence over the policy annotation
+func RuleCategory(policy kyvernov1.PolicyInterface, ruleResult engineapi.RuleResponse) string {
+	if ruleResult.ReportMetadata != nil && ruleResult.ReportMetadata.Category != "" {
+		return ruleResult.ReportMetadata.Category
+	}
+	return policy.GetAnnotations()[kyvernov1.AnnotationPolicyCategory]
+}
+
 func EngineResponseToReportResults(response *engineapi.EngineResponse) []policyreportv1alpha2.PolicyReportResult {
 	key, _ := cache.MetaNamespaceKeyFunc(response.Policy)
 	var results []policyreportv1alpha2.PolicyReportResult
@@ -96,8 +148,14 @@ func EngineResponseToReportResults(response *engineapi.EngineResponse) []policyr
 			Timestamp: metav1.Timestamp{
 				Seconds: time.Now().Unix(),
 			},
-			Category: annotations[kyvernov1.AnnotationPolicyCategory],
-			Severity: severityFromString(annotations[kyvernov1.AnnotationPolicySeverity]),
+			Category: RuleCategory(response.Policy, ruleResult),
+			Severity: RuleSeverity(response.Policy, ruleResult),
+		}
+		if ruleResult.ReportMetadata != nil && len(ruleResult.ReportMetadata.Properties) > 0 {
+			result.Properties = make(map[string]string, len(ruleResult.ReportMetadata.Properties))
+			for k, v := range ruleResult.ReportMetadata.Properties {
+				result.Properties[k] = v
+			}
 		}
 		if ruleResult.PodSecurityChecks != nil {
 			var controls []string
@@ -108,13 +166,32 @@ func EngineResponseToReportResults(response *engineapi.EngineResponse) []policyr
 			}
 			if len(controls) > 0 {
 				sort.Strings(controls)
-				result.Properties = map[string]string{
-					"standard": string(ruleResult.PodSecurityChecks.Level),
-					"version":  ruleResult.PodSecurityChecks.Version,
-					"controls": strings.Join(controls, ","),
+				if result.Properties == nil {
+					result.Properties = map[string]string{}
 				}
+				result.Properties["standard"] = string(ruleResult.PodSecurityChecks.Level)
+				result.Properties["version"] = ruleResult.PodSecurityChecks.Version
+				result.Properties["controls"] = strings.Join(controls, ",")
 			}
 		}
+		if ruleResult.Type == engineapi.Mutation || ruleResult.Type == engineapi.Generation {
+			if result.Properties == nil {
+				result.Properties = map[string]string{}
+			}
+			result.Properties[RuleTypeProperty] = string(ruleResult.Type)
+		}
+		if exceptions := ruleResult.ExceptionKeys(); len(exceptions) > 0 {
+			if result.Properties == nil {
+				result.Properties = map[string]string{}
+			}
+			result.Properties[ExceptionsProperty] = strings.Join(exceptions, ",")
+		}
+		if kyvernov1.IsShadowPolicy(response.Policy) {
+			if result.Properties == nil {
+				result.Properties = map[string]string{}
+			}
+			result.Properties[ShadowProperty] = "true"
+		}
 		if result.Result == "fail" && !result.Scored {
 			result.Result = "warn"
 		}
@@ -137,6 +214,10 @@ func SplitResultsByPolicy(logger logr.Logger, results []policyreportv1alpha2.Pol
 				} else {
 					keysMap[result.Policy] = "pol-" + n
 				}
+				// shadow policies results are kept in dedicated reports
+				if IsShadowResult(result) {
+					keysMap[result.Policy] = "shadow-" + keysMap[result.Policy]
+				}
 			}
 		}
 	}
@@ -0,0 +1,147 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestEngineResponseToReportResults_ReportMetadata(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "require-labels",
+			Annotations: map[string]string{
+				kyvernov1.AnnotationPolicySeverity: "medium",
+				kyvernov1.AnnotationPolicyCategory: "Best Practices",
+			},
+		},
+	}
+	response := &engineapi.EngineResponse{
+		Policy: policy,
+		PolicyResponse: engineapi.PolicyResponse{
+			Rules: []engineapi.RuleResponse{
+				{
+					Name:   "check-team",
+					Status: engineapi.RuleStatusFail,
+					ReportMetadata: &kyvernov1.ReportMetadata{
+						Severity: "high",
+						Category: "Ownership",
+						Properties: map[string]string{
+							"owner":       "platform",
+							"remediation": "https://example.com/labels",
+						},
+					},
+				},
+				{
+					Name:   "check-app",
+					Status: engineapi.RuleStatusPass,
+				},
+			},
+		},
+	}
+	results := EngineResponseToReportResults(response)
+	assert.Equal(t, len(results), 2)
+	assert.Equal(t, results[0].Severity, policyreportv1alpha2.PolicySeverity(policyreportv1alpha2.SeverityHigh))
+	assert.Equal(t, results[0].Category, "Ownership")
+	assert.DeepEqual(t, results[0].Properties, map[string]string{
+		"owner":       "platform",
+		"remediation": "https://example.com/labels",
+	})
+	// the properties of the rule are copied
+	results[0].Properties["owner"] = "changed"
+	assert.Equal(t, response.PolicyResponse.Rules[0].ReportMetadata.Properties["owner"], "platform")
+	// rules without metadata fall back to the policy annotations
+	assert.Equal(t, results[1].Severity, policyreportv1alpha2.PolicySeverity(policyreportv1alpha2.SeverityMedium))
+	assert.Equal(t, results[1].Category, "Best Practices")
+	assert.Assert(t, results[1].Properties == nil)
+}
+
+func TestBuildResourceAdmissionReport_MutateAndGenerate(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "add-defaults",
+		},
+	}
+	resource := unstructured.Unstructured{}
+	resource.SetAPIVersion("v1")
+	resource.SetKind("ConfigMap")
+	resource.SetNamespace("default")
+	resource.SetName("config")
+	resource.SetUID("uid")
+	response := &engineapi.EngineResponse{
+		Resource: resource,
+		Policy:   policy,
+		PolicyResponse: engineapi.PolicyResponse{
+			Rules: []engineapi.RuleResponse{
+				{
+					Name:   "add-labels",
+					Type:   engineapi.Mutation,
+					Status: engineapi.RuleStatusPass,
+				},
+				{
+					Name:   "add-annotations",
+					Type:   engineapi.Mutation,
+					Status: engineapi.RuleStatusSkip,
+				},
+				{
+					Name:   "generate-quota",
+					Type:   engineapi.Generation,
+					Status: engineapi.RuleStatusError,
+				},
+			},
+		},
+	}
+	report := BuildResourceAdmissionReport("report", resource, response)
+	assert.Equal(t, report.GetNamespace(), "default")
+	assert.Equal(t, len(report.GetOwnerReferences()), 1)
+	assert.Equal(t, report.GetOwnerReferences()[0].UID, resource.GetUID())
+	// skipped rules are not reported
+	results := report.GetResults()
+	assert.Equal(t, len(results), 2)
+	assert.Equal(t, results[0].Rule, "add-labels")
+	assert.Equal(t, results[0].Properties[RuleTypeProperty], string(engineapi.Mutation))
+	assert.Equal(t, results[1].Rule, "generate-quota")
+	assert.Equal(t, results[1].Result, policyreportv1alpha2.PolicyResult(policyreportv1alpha2.StatusError))
+	assert.Equal(t, results[1].Properties[RuleTypeProperty], string(engineapi.Generation))
+	// the engine response is left unchanged
+	assert.Equal(t, len(response.PolicyResponse.Rules), 3)
+}
+
+func TestSplitResultsByPolicy_Shadow(t *testing.T) {
+	newResponse := func(namespace, name string, shadow bool) *engineapi.EngineResponse {
+		var policy kyvernov1.PolicyInterface = &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if namespace != "" {
+			policy = &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		}
+		if shadow {
+			policy.SetAnnotations(map[string]string{kyvernov1.AnnotationPolicyShadow: "true"})
+		}
+		return &engineapi.EngineResponse{
+			Policy: policy,
+			PolicyResponse: engineapi.PolicyResponse{
+				Rules: []engineapi.RuleResponse{{Name: "check", Status: engineapi.RuleStatusFail}},
+			},
+		}
+	}
+	var results []policyreportv1alpha2.PolicyReportResult
+	results = append(results, EngineResponseToReportResults(newResponse("", "require-labels", false))...)
+	results = append(results, EngineResponseToReportResults(newResponse("", "require-labels-v2", true))...)
+	results = append(results, EngineResponseToReportResults(newResponse("default", "require-team", true))...)
+	assert.Assert(t, results[0].Properties == nil)
+	assert.Equal(t, results[1].Properties[ShadowProperty], "true")
+	split := SplitResultsByPolicy(logr.Discard(), results)
+	assert.Equal(t, len(split), 3)
+	assert.Equal(t, len(split["cpol-require-labels"]), 1)
+	assert.Equal(t, len(split["shadow-cpol-require-labels-v2"]), 1)
+	assert.Equal(t, len(split["shadow-pol-require-team"]), 1)
+	active, shadow := SplitShadowResults(results)
+	assert.Equal(t, len(active), 1)
+	assert.Equal(t, active[0].Policy, "require-labels")
+	assert.Equal(t, len(shadow), 2)
+}
@@ -8,9 +8,13 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/auth"
+	"github.com/kyverno/kyverno/pkg/cleanup"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 )
@@ -39,8 +43,8 @@ func FetchClusteredResources(logger logr.Logger, client dclient.Interface) (sets
 	return clusterResources, nil
 }
 
-// Validate checks policy is valid
-func Validate(ctx context.Context, logger logr.Logger, client dclient.Interface, policy kyvernov2alpha1.CleanupPolicyInterface) error {
+// Validate checks policy is valid, author is the user creating or updating the policy
+func Validate(ctx context.Context, logger logr.Logger, client dclient.Interface, policy kyvernov2alpha1.CleanupPolicyInterface, author authenticationv1.UserInfo) error {
 	clusteredResources, err := FetchClusteredResources(logger, client)
 	if err != nil {
 		return err
@@ -48,6 +52,9 @@ func Validate(ctx context.Context, logger logr.Logger, client dclient.Interface,
 	if err := validatePolicy(clusteredResources, policy); err != nil {
 		return err
 	}
+	if err := validateServiceAccount(ctx, client, policy, author); err != nil {
+		return err
+	}
 	if err := validateAuth(ctx, client, policy); err != nil {
 		return err
 	}
@@ -64,35 +71,108 @@ func validatePolicy(clusterResources sets.Set[string], policy kyvernov2alpha1.Cl
 	return errs.ToAggregate()
 }
 
-// validateAuth checks the the delete action is allowed
+// validateAuth checks the the delete action is allowed, for policies declaring a service account
+// the permissions of the service account are checked instead of the cleanup controller ones
 func validateAuth(ctx context.Context, client dclient.Interface, policy kyvernov2alpha1.CleanupPolicyInterface) error {
 	namespace := policy.GetNamespace()
 	spec := policy.GetSpec()
+	userInfo, impersonate := cleanup.UserInfo(policy)
+	subject := "cleanup controller"
+	if impersonate {
+		subject = fmt.Sprintf("service account %s", spec.ServiceAccountName)
+	}
+	canI := func(kind, verb string) auth.CanIOptions {
+		if impersonate {
+			return auth.NewCanIForUser(client.Discovery(), client.GetKubeClient().AuthorizationV1().SubjectAccessReviews(), userInfo, kind, namespace, verb, "")
+		}
+		return auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SelfSubjectAccessReviews(), kind, namespace, verb, "")
+	}
 	kinds := sets.New(spec.MatchResources.GetKinds()...)
 	for kind := range kinds {
-		checker := auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SelfSubjectAccessReviews(), kind, namespace, "delete", "")
-		allowedDeletion, err := checker.RunAccessCheck(ctx)
+		allowedDeletion, err := canI(kind, "delete").RunAccessCheck(ctx)
 		if err != nil {
 			return err
 		}
 		if !allowedDeletion {
-			return fmt.Errorf("cleanup controller has no permission to delete kind %s", kind)
+			return fmt.Errorf("%s has no permission to delete kind %s", subject, kind)
 		}
 
-		checker = auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SelfSubjectAccessReviews(), kind, namespace, "list", "")
-		allowedList, err := checker.RunAccessCheck(ctx)
+		allowedList, err := canI(kind, "list").RunAccessCheck(ctx)
 		if err != nil {
 			return err
 		}
 		if !allowedList {
-			return fmt.Errorf("cleanup controller has no permission to list kind %s", kind)
+			return fmt.Errorf("%s has no permission to list kind %s", subject, kind)
+		}
+	}
+	for _, entry := range spec.Context {
+		if entry.References == nil {
+			continue
+		}
+		kind := entry.References.Kind
+		// referencing resources are looked up in cluster wide informers running with the cleanup controller permissions
+		for _, verb := range []string{"list", "watch"} {
+			allowed, err := auth.NewCanI(client.Discovery(), client.GetKubeClient().AuthorizationV1().SelfSubjectAccessReviews(), kind, "", verb, "").RunAccessCheck(ctx)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return fmt.Errorf("cleanup controller has no permission to %s kind %s in all namespaces, it is required to look up references", verb, kind)
+			}
+		}
+		// and the lookup is authorized for the policy subject in the namespace of the target
+		if impersonate {
+			allowed, err := canI(kind, "list").RunAccessCheck(ctx)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return fmt.Errorf("%s has no permission to list kind %s", subject, kind)
+			}
 		}
 	}
 	return nil
 }
 
+// validateServiceAccount checks the author of a policy declaring a service account is allowed to impersonate it,
+// the policy executes with the service account permissions and must not grant more than the author already has
+func validateServiceAccount(ctx context.Context, client dclient.Interface, policy kyvernov2alpha1.CleanupPolicyInterface, author authenticationv1.UserInfo) error {
+	name := policy.GetSpec().ServiceAccountName
+	if name == "" || policy.GetNamespace() == "" {
+		return nil
+	}
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range author.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review, err := client.GetKubeClient().AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: policy.GetNamespace(),
+				Verb:      "impersonate",
+				Resource:  "serviceaccounts",
+				Name:      name,
+			},
+			User:   author.Username,
+			Groups: author.Groups,
+			UID:    author.UID,
+			Extra:  extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("user %s is not allowed to impersonate service account %s/%s", author.Username, policy.GetNamespace(), name)
+	}
+	return nil
+}
+
 func validateVariables(logger logr.Logger, policy kyvernov2alpha1.CleanupPolicyInterface) error {
 	ctx := enginecontext.NewMockContext(allowedVariables)
+	for _, entry := range policy.GetSpec().Context {
+		ctx.AddVariable(entry.Name + "*")
+	}
 
 	c := policy.GetSpec().Conditions
 	conditionCopy := c.DeepCopy()
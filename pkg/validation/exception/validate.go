@@ -2,19 +2,25 @@ package exception
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 const (
 	namespacesDontMatch = "PolicyException resource namespace must match the defined namespace."
 	disabledPolex       = "PolicyException resources would not be processed until it is enabled."
+	expiredPolex        = "PolicyException has already expired and will not be applied."
 )
 
 type ValidationOptions struct {
 	Enabled   bool
 	Namespace string
+	// Configuration provides the approval fields policy exceptions must set, optional
+	Configuration config.Configuration
 }
 
 // Validate checks policy exception is valid
@@ -25,6 +31,12 @@ func Validate(ctx context.Context, logger logr.Logger, polex *kyvernov2alpha1.Po
 	} else if opts.Namespace != "" && opts.Namespace != polex.Namespace {
 		warnings = append(warnings, namespacesDontMatch)
 	}
+	if polex.Spec.ExpiresAt != nil && !time.Now().Before(polex.Spec.ExpiresAt.Time) {
+		warnings = append(warnings, expiredPolex)
+	}
 	errs := polex.Validate()
+	if opts.Configuration != nil {
+		errs = append(errs, polex.Spec.ValidateApproval(field.NewPath("spec", "approval"), opts.Configuration.GetPolicyExceptionRequiredFields())...)
+	}
 	return warnings, errs.ToAggregate()
 }
@@ -5,9 +5,11 @@ import (
 	"testing"
 
 	"github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/logging"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func Test_Validate(t *testing.T) {
@@ -64,6 +66,17 @@ func Test_Validate(t *testing.T) {
 			},
 			want: 0,
 		},
+		{
+			name: "PolicyExceptions enabled. Exception expired",
+			args: args{
+				opts: ValidationOptions{
+					Enabled:   true,
+					Namespace: "",
+				},
+				resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"enforce-label-exception","namespace":"kyverno"},"spec":{"expiresAt":"2020-01-01T00:00:00Z","exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`),
+			},
+			want: 1,
+		},
 	}
 	for _, c := range tc {
 		t.Run(c.name, func(t *testing.T) {
@@ -76,6 +89,48 @@ func Test_Validate(t *testing.T) {
 	}
 }
 
+func Test_ValidateApproval(t *testing.T) {
+	cfg := config.NewDefaultConfiguration()
+	cfg.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"policyExceptionRequiredFields": "owner, ticket",
+		},
+	})
+	tc := []struct {
+		name     string
+		resource []byte
+		error    bool
+	}{
+		{
+			name:     "Approval missing.",
+			resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"enforce-label-exception","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`),
+			error:    true,
+		},
+		{
+			name:     "Approval ticket missing.",
+			resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"enforce-label-exception","namespace":"kyverno"},"spec":{"approval":{"owner":"team-a"},"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`),
+			error:    true,
+		},
+		{
+			name:     "Approval complete.",
+			resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"enforce-label-exception","namespace":"kyverno"},"spec":{"approval":{"owner":"team-a","ticket":"SEC-42"},"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`),
+			error:    false,
+		},
+	}
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			polex, err := admissionutils.UnmarshalPolicyException(c.resource)
+			assert.NilError(t, err)
+			_, err = Validate(context.Background(), logging.GlobalLogger(), polex, ValidationOptions{Enabled: true, Configuration: cfg})
+			if c.error {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
 func Test_ValidateVariables(t *testing.T) {
 	tc := []struct {
 		name     string
@@ -87,6 +142,11 @@ func Test_ValidateVariables(t *testing.T) {
 			resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"enforce-label-polex"},"spec":{"background":true,"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"],"namespaces":["{{request.object.name}}"],"names":["{{request.userInfo.username}}"]}}]}}}`),
 			error:    true,
 		},
+		{
+			name:     "Variable used in conditions.",
+			resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"enforce-label-polex"},"spec":{"background":false,"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"conditions":{"any":[{"key":"{{request.operation}}","operator":"Equals","value":"DELETE"}]}}}`),
+			error:    false,
+		},
 		{
 			name:     "Variable not used.",
 			resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"enforce-label-polex"},"spec":{"background":true,"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`),
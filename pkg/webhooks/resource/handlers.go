@@ -8,6 +8,7 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
@@ -60,7 +61,14 @@ type handlers struct {
 	pcBuilder      webhookutils.PolicyContextBuilder
 	urUpdater      webhookutils.UpdateRequestUpdater
 
-	admissionReports bool
+	// admissionReports persists admission reports, nil when admission reports are disabled
+	admissionReports admissionreports.Writer
+	// mutationReports persists the results of mutate rules, nil when mutate rules are not reported
+	mutationReports admissionreports.Writer
+	// mutationResults holds the results of the mutating webhook until the validating webhook reports them
+	mutationResults mutation.Results
+	// shadow evaluates shadow policies on sampled requests, nil when shadow policies are not evaluated
+	shadow validation.ShadowEvaluator
 }
 
 func NewHandlers(
@@ -78,9 +86,11 @@ func NewHandlers(
 	urGenerator webhookgenerate.Generator,
 	eventGen event.Interface,
 	openApiManager openapi.ValidateInterface,
-	admissionReports bool,
+	admissionReports admissionreports.Writer,
+	mutationReports admissionreports.Writer,
+	shadow validation.ShadowEvaluator,
 ) webhooks.ResourceHandlers {
-	return &handlers{
+	h := &handlers{
 		engine:           engine,
 		client:           client,
 		kyvernoClient:    kyvernoClient,
@@ -96,7 +106,13 @@ func NewHandlers(
 		pcBuilder:        webhookutils.NewPolicyContextBuilder(configuration, client, rbLister, crbLister),
 		urUpdater:        webhookutils.NewUpdateRequestUpdater(kyvernoClient, urLister),
 		admissionReports: admissionReports,
+		mutationReports:  mutationReports,
+		shadow:           shadow,
 	}
+	if mutationReports != nil {
+		h.mutationResults = mutation.NewResults()
+	}
+	return h
 }
 
 func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, failurePolicy string, startTime time.Time) *admissionv1.AdmissionResponse {
@@ -132,7 +148,12 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 		namespaceLabels = engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
 	}
 	policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
-	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.engine, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig, h.configuration)
+	// shadow policies are registered in the ignore webhook only, this evaluates them once per request
+	var shadow validation.ShadowEvaluator
+	if failurePolicy != "fail" {
+		shadow = h.shadow
+	}
+	vh := validation.NewValidationHandler(logger, h.engine, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig, h.configuration, shadow)
 
 	ok, msg, warnings := vh.HandleValidation(ctx, request, policies, policyContext, startTime)
 	if !ok {
@@ -141,6 +162,8 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 	}
 
 	defer h.handleDelete(logger, request)
+	// mutations are reported from the validating webhook, the resource has its final name and uid
+	go mutation.HandleAudit(ctx, logger, h.mutationReports, h.mutationResults, request, policyContext.NewResource())
 	go h.createUpdateRequests(logger, request, policyContext, generatePolicies, mutatePolicies, startTime)
 
 	return admissionutils.ResponseSuccess(request.UID, warnings...)
@@ -166,7 +189,7 @@ func (h *handlers) Mutate(ctx context.Context, logger logr.Logger, request *admi
 	if err := enginectx.MutateResourceWithImageInfo(request.Object.Raw, policyContext.JSONContext()); err != nil {
 		logger.Error(err, "failed to patch images info to resource, policies that mutate images may be impacted")
 	}
-	mh := mutation.NewMutationHandler(logger, h.engine, h.eventGen, h.openApiManager, h.nsLister, h.metricsConfig)
+	mh := mutation.NewMutationHandler(logger, h.engine, h.eventGen, h.openApiManager, h.nsLister, h.metricsConfig, h.mutationResults)
 	mutatePatches, mutateWarnings, err := mh.HandleMutation(ctx, request, mutatePolicies, policyContext, startTime)
 	if err != nil {
 		logger.Error(err, "mutation failed")
@@ -179,7 +202,7 @@ func (h *handlers) Mutate(ctx context.Context, logger logr.Logger, request *admi
 		logger.Error(err, "failed to build policy context")
 		return admissionutils.Response(request.UID, err)
 	}
-	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.engine, h.eventGen, h.admissionReports, h.configuration)
+	ivh := imageverification.NewImageVerificationHandler(logger, h.engine, h.eventGen, h.admissionReports, h.configuration)
 	imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
 	if err != nil {
 		logger.Error(err, "image verification failed")
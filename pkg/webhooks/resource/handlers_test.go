@@ -7,8 +7,12 @@ import (
 	"time"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	log "github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/mutation"
 	"gotest.tools/assert"
 	v1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -426,3 +430,97 @@ func makeKey(policy kyverno.PolicyInterface) string {
 
 	return namespace + "/" + name
 }
+
+var policyAddLabel = `{
+	"apiVersion": "kyverno.io/v1",
+	"kind": "ClusterPolicy",
+	"metadata": {
+	   "name": "add-label"
+	},
+	"spec": {
+	   "rules": [
+		  {
+			 "name": "add-team",
+			 "match": {
+				"resources": {
+				   "kinds": [
+					  "ConfigMap"
+				   ]
+				}
+			 },
+			 "mutate": {
+				"patchStrategicMerge": {
+				   "metadata": {
+					  "labels": {
+						 "team": "kyverno"
+					  }
+				   }
+				}
+			 }
+		  }
+	   ]
+	}
+ }
+`
+
+type fakeReportsWriter struct {
+	reports chan kyvernov1alpha2.ReportInterface
+}
+
+func (w *fakeReportsWriter) Create(_ context.Context, report kyvernov1alpha2.ReportInterface) (kyvernov1alpha2.ReportInterface, error) {
+	w.reports <- report
+	return report, nil
+}
+
+func Test_MutationReports(t *testing.T) {
+	policyCache := policycache.NewCache()
+	logger := log.WithName("Test_MutationReports")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	writer := &fakeReportsWriter{reports: make(chan kyvernov1alpha2.ReportInterface, 1)}
+	handlers := NewFakeHandlers(ctx, policyCache).(*handlers)
+	handlers.mutationReports = writer
+	handlers.mutationResults = mutation.NewResults()
+
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(policyAddLabel), &policy))
+	policyCache.Set(makeKey(&policy), &policy, map[string]string{})
+
+	// the name is generated after the mutating webhook, the uid too
+	request := &v1.AdmissionRequest{
+		UID:       "mutate-uid",
+		Operation: v1.Create,
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		Namespace: "default",
+		Object: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"generateName":"config-","namespace":"default"}}`),
+		},
+		RequestResource: &metav1.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+	}
+	response := handlers.Mutate(ctx, logger, request, "", time.Now())
+	assert.Equal(t, response.Allowed, true)
+	assert.Assert(t, len(response.Patch) > 0)
+
+	// the validating webhook receives the mutated resource, the patch applies again without changes
+	request = request.DeepCopy()
+	request.UID = "validate-uid"
+	request.Name = "config-abcde"
+	request.Object.Raw = []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"generateName":"config-","name":"config-abcde","namespace":"default","uid":"resource-uid","labels":{"team":"kyverno"}}}`)
+	response = handlers.Validate(ctx, logger, request, "", time.Now())
+	assert.Equal(t, response.Allowed, true)
+
+	select {
+	case report := <-writer.reports:
+		assert.Equal(t, string(reportutils.GetResourceUid(report)), "resource-uid")
+		results := report.GetResults()
+		assert.Equal(t, len(results), 1)
+		assert.Equal(t, results[0].Policy, "add-label")
+		assert.Equal(t, results[0].Rule, "add-team")
+		assert.Equal(t, results[0].Result, policyreportv1alpha2.PolicyResult(policyreportv1alpha2.StatusPass))
+	case <-time.After(10 * time.Second):
+		t.Fatal("mutation report not created")
+	}
+}
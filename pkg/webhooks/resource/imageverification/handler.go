@@ -8,7 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
@@ -30,24 +30,21 @@ type ImageVerificationHandler interface {
 }
 
 type imageVerificationHandler struct {
-	kyvernoClient    versioned.Interface
 	engine           engineapi.Engine
 	log              logr.Logger
 	eventGen         event.Interface
-	admissionReports bool
+	admissionReports admissionreports.Writer
 	cfg              config.Configuration
 }
 
 func NewImageVerificationHandler(
 	log logr.Logger,
-	kyvernoClient versioned.Interface,
 	engine engineapi.Engine,
 	eventGen event.Interface,
-	admissionReports bool,
+	admissionReports admissionreports.Writer,
 	cfg config.Configuration,
 ) ImageVerificationHandler {
 	return &imageVerificationHandler{
-		kyvernoClient:    kyvernoClient,
 		engine:           engine,
 		log:              log,
 		eventGen:         eventGen,
@@ -153,7 +150,7 @@ func (v *imageVerificationHandler) handleAudit(
 	namespaceLabels map[string]string,
 	engineResponses ...*engineapi.EngineResponse,
 ) {
-	if !v.admissionReports {
+	if v.admissionReports == nil {
 		return
 	}
 	if request.DryRun != nil && *request.DryRun {
@@ -179,7 +176,7 @@ func (v *imageVerificationHandler) handleAudit(
 				controllerutils.SetOwner(report, gv.String(), request.Kind.Kind, resource.GetName(), resource.GetUID())
 			}
 			if len(report.GetResults()) > 0 {
-				_, err := reportutils.CreateReport(context.Background(), report, v.kyvernoClient)
+				_, err := v.admissionReports.Create(context.Background(), report)
 				if err != nil {
 					v.log.Error(err, "failed to create report")
 				}
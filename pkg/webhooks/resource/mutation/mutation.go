@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/event"
@@ -17,11 +18,13 @@ import (
 	"github.com/kyverno/kyverno/pkg/utils"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"go.opentelemetry.io/otel/trace"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
@@ -39,6 +42,7 @@ func NewMutationHandler(
 	openApiManager openapi.ValidateInterface,
 	nsLister corev1listers.NamespaceLister,
 	metrics metrics.MetricsConfigManager,
+	results Results,
 ) MutationHandler {
 	return &mutationHandler{
 		log:            log,
@@ -47,6 +51,7 @@ func NewMutationHandler(
 		openApiManager: openApiManager,
 		nsLister:       nsLister,
 		metrics:        metrics,
+		results:        results,
 	}
 }
 
@@ -57,6 +62,8 @@ type mutationHandler struct {
 	openApiManager openapi.ValidateInterface
 	nsLister       corev1listers.NamespaceLister
 	metrics        metrics.MetricsConfigManager
+	// results records the responses reported by the validating webhook, nil when mutate rules are not reported
+	results Results
 }
 
 func (h *mutationHandler) HandleMutation(
@@ -66,10 +73,14 @@ func (h *mutationHandler) HandleMutation(
 	policyContext *engine.PolicyContext,
 	admissionRequestTimestamp time.Time,
 ) ([]byte, []string, error) {
+	resource := policyContext.NewResource()
 	mutatePatches, mutateEngineResponses, err := h.applyMutations(ctx, request, policies, policyContext)
 	if err != nil {
 		return nil, nil, err
 	}
+	if h.results != nil && isReported(request) {
+		h.results.Add(request, resource, mutateEngineResponses...)
+	}
 	h.log.V(6).Info("", "generated patches", string(mutatePatches))
 	return mutatePatches, webhookutils.GetWarningMessages(mutateEngineResponses), nil
 }
@@ -195,3 +206,49 @@ func isResourceDeleted(policyContext *engine.PolicyContext) bool {
 	}
 	return deletionTimeStamp != nil
 }
+
+// isReported returns true if the results of the mutate rules applied to a request are reported
+func isReported(request *admissionv1.AdmissionRequest) bool {
+	if request.DryRun != nil && *request.DryRun {
+		return false
+	}
+	// we don't need reports for deletions and when it's about sub resources
+	if request.Operation == admissionv1.Delete || request.SubResource != "" {
+		return false
+	}
+	// check if the resource supports reporting
+	return reportutils.IsGvkSupported(schema.GroupVersionKind(request.Kind))
+}
+
+// HandleAudit reports the results of the mutate rules applied by the mutating webhook to a request. It is called
+// from the validating webhook of the request, where the resource has its final name and uid.
+func HandleAudit(
+	ctx context.Context,
+	logger logr.Logger,
+	mutationReports admissionreports.Writer,
+	results Results,
+	request *admissionv1.AdmissionRequest,
+	resource unstructured.Unstructured,
+) {
+	if mutationReports == nil || results == nil || !isReported(request) {
+		return
+	}
+	responses := results.Take(request, resource)
+	if len(responses) == 0 {
+		return
+	}
+	tracing.Span(
+		context.Background(),
+		"",
+		fmt.Sprintf("AUDIT MUTATION %s %s", request.Operation, request.Kind),
+		func(ctx context.Context, span trace.Span) {
+			report := reportutils.BuildResourceAdmissionReport(string(request.UID)+"-mutate", resource, responses...)
+			if len(report.GetResults()) > 0 {
+				if _, err := mutationReports.Create(ctx, report); err != nil {
+					logger.Error(err, "failed to create report")
+				}
+			}
+		},
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+	)
+}
@@ -0,0 +1,128 @@
+package mutation
+
+import (
+	"sync"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// resultsTTL is how long the results of the mutating webhook wait for the validating webhook of the same request
+	resultsTTL = time.Minute
+	// maxResults is the maximum number of results waiting for the validating webhook
+	maxResults = 10000
+)
+
+// Results holds the engine responses produced by the mutating webhook until the validating webhook of the same
+// request reports them. The uid of an admission request changes with every webhook call, requests are correlated
+// with their operation, kind, namespace and name, or generate name as the name is not known yet when mutating a
+// resource created with a generate name.
+type Results interface {
+	// Add records the responses of the mutating webhook for a request on the given resource
+	Add(request *admissionv1.AdmissionRequest, resource unstructured.Unstructured, responses ...*engineapi.EngineResponse)
+	// Take returns and forgets the oldest responses recorded for a request on the given resource
+	Take(request *admissionv1.AdmissionRequest, resource unstructured.Unstructured) []*engineapi.EngineResponse
+}
+
+type resultsEntry struct {
+	responses []*engineapi.EngineResponse
+	expires   time.Time
+}
+
+type results struct {
+	lock    sync.Mutex
+	now     func() time.Time
+	count   int
+	entries map[string][]resultsEntry
+}
+
+// NewResults creates an in-memory Results, responses not taken within a minute are dropped
+func NewResults() Results {
+	return &results{
+		now:     time.Now,
+		entries: map[string][]resultsEntry{},
+	}
+}
+
+func resultsKey(request *admissionv1.AdmissionRequest, resource unstructured.Unstructured) string {
+	name := resource.GetName()
+	if request.Operation == admissionv1.Create && resource.GetGenerateName() != "" {
+		name = "generate:" + resource.GetGenerateName()
+	}
+	return string(request.Operation) + "/" + request.Kind.Group + "/" + request.Kind.Kind + "/" + request.Namespace + "/" + name
+}
+
+func (r *results) Add(request *admissionv1.AdmissionRequest, resource unstructured.Unstructured, responses ...*engineapi.EngineResponse) {
+	// a reinvocation of the mutating webhook skips the rules already applied, only responses with applied rules are kept
+	if !hasAppliedRules(responses...) {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := r.now()
+	if r.count >= maxResults {
+		r.prune(now)
+		if r.count >= maxResults {
+			return
+		}
+	}
+	key := resultsKey(request, resource)
+	r.entries[key] = append(r.entries[key], resultsEntry{responses: responses, expires: now.Add(resultsTTL)})
+	r.count++
+}
+
+func (r *results) Take(request *admissionv1.AdmissionRequest, resource unstructured.Unstructured) []*engineapi.EngineResponse {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	key := resultsKey(request, resource)
+	now := r.now()
+	entries := r.entries[key]
+	for len(entries) > 0 {
+		entry := entries[0]
+		entries = entries[1:]
+		r.count--
+		if now.Before(entry.expires) {
+			r.set(key, entries)
+			return entry.responses
+		}
+	}
+	r.set(key, entries)
+	return nil
+}
+
+func (r *results) set(key string, entries []resultsEntry) {
+	if len(entries) == 0 {
+		delete(r.entries, key)
+	} else {
+		r.entries[key] = entries
+	}
+}
+
+// prune drops the expired responses
+func (r *results) prune(now time.Time) {
+	for key, entries := range r.entries {
+		var kept []resultsEntry
+		for _, entry := range entries {
+			if now.Before(entry.expires) {
+				kept = append(kept, entry)
+			} else {
+				r.count--
+			}
+		}
+		r.set(key, kept)
+	}
+}
+
+func hasAppliedRules(responses ...*engineapi.EngineResponse) bool {
+	for _, response := range responses {
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status != engineapi.RuleStatusSkip {
+				return true
+			}
+		}
+	}
+	return false
+}
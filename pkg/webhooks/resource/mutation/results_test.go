@@ -0,0 +1,43 @@
+package mutation
+
+import (
+	"testing"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_Results(t *testing.T) {
+	now := time.Now()
+	r := NewResults().(*results)
+	r.now = func() time.Time { return now }
+	response := func(status engineapi.RuleStatus) *engineapi.EngineResponse {
+		return &engineapi.EngineResponse{PolicyResponse: engineapi.PolicyResponse{Rules: []engineapi.RuleResponse{{Name: "rule", Status: status}}}}
+	}
+	request := &admissionv1.AdmissionRequest{Operation: admissionv1.Create, Kind: metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, Namespace: "default"}
+	mutated := unstructured.Unstructured{}
+	mutated.SetGenerateName("config-")
+	validated := unstructured.Unstructured{}
+	validated.SetGenerateName("config-")
+	validated.SetName("config-abcde")
+	// skipped rules are not recorded
+	r.Add(request, mutated, response(engineapi.RuleStatusSkip))
+	assert.Equal(t, len(r.Take(request, validated)), 0)
+	// responses are correlated with the generate name and taken in order
+	first, second := response(engineapi.RuleStatusPass), response(engineapi.RuleStatusPass)
+	r.Add(request, mutated, first)
+	r.Add(request, mutated, second)
+	assert.Equal(t, r.Take(request, validated)[0], first)
+	assert.Equal(t, r.Take(request, validated)[0], second)
+	assert.Equal(t, len(r.Take(request, validated)), 0)
+	// expired responses are dropped
+	r.Add(request, mutated, first)
+	now = now.Add(resultsTTL)
+	assert.Equal(t, len(r.Take(request, validated)), 0)
+	assert.Equal(t, r.count, 0)
+	assert.Equal(t, len(r.entries), 0)
+}
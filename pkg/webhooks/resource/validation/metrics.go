@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+const (
+	shadowRequestEvaluated = "evaluated"
+	shadowRequestSkipped   = "skipped"
+	shadowRequestDropped   = "dropped"
+)
+
+type shadowMetrics struct {
+	requests syncint64.Counter
+	results  syncint64.Counter
+}
+
+func newShadowMetrics(logger logr.Logger) shadowMetrics {
+	meter := global.MeterProvider().Meter(metrics.MeterName)
+	requests, err := meter.SyncInt64().Counter(
+		"kyverno_shadow_requests",
+		instrument.WithDescription("can be used to track the admission requests matched by shadow policies that were evaluated, skipped by sampling or dropped because the evaluation queue was full"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_shadow_requests")
+	}
+	results, err := meter.SyncInt64().Counter(
+		"kyverno_shadow_policy_results",
+		instrument.WithDescription("can be used to compare the outcome of shadow policies with the outcome of the active policies they shadow on sampled admission requests"))
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_shadow_policy_results")
+	}
+	return shadowMetrics{
+		requests: requests,
+		results:  results,
+	}
+}
+
+func (m shadowMetrics) recordRequest(ctx context.Context, status string) {
+	if m.requests != nil {
+		m.requests.Add(ctx, 1, attribute.String("status", status))
+	}
+}
+
+func (m shadowMetrics) recordResult(ctx context.Context, policy kyvernov1.PolicyInterface, shadowOf string, request *admissionv1.AdmissionRequest, shadowResult, activeResult string) {
+	if m.results != nil {
+		operation, err := metrics.ParseResourceRequestOperation(string(request.Operation))
+		if err != nil {
+			operation = metrics.ResourceRequestOperation(request.Operation)
+		}
+		m.results.Add(
+			ctx,
+			1,
+			attribute.String("policy_namespace", policy.GetNamespace()),
+			attribute.String("policy_name", policy.GetName()),
+			attribute.String("shadow_of", shadowOf),
+			attribute.String("resource_kind", request.Kind.Kind),
+			attribute.String("resource_namespace", request.Namespace),
+			attribute.String("resource_request_operation", string(operation)),
+			attribute.String("shadow_result", shadowResult),
+			attribute.String("active_result", activeResult),
+		)
+	}
+}
@@ -0,0 +1,239 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"go.opentelemetry.io/otel/trace"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// outcomes of a policy evaluation, compared between shadow and active policies
+const (
+	outcomePass  = "pass"
+	outcomeFail  = "fail"
+	outcomeError = "error"
+	outcomeSkip  = "skip"
+	// outcomeNone means the active policy does not apply to the request
+	outcomeNone = "none"
+)
+
+// ShadowOptions configures the evaluation of shadow policies
+type ShadowOptions struct {
+	// SampleRate is the fraction of admission requests evaluated against shadow policies, between 0 and 1
+	SampleRate float64
+	// QueueSize is the number of sampled requests waiting for evaluation before requests get dropped
+	QueueSize int
+	// Workers is the number of sampled requests evaluated concurrently
+	Workers int
+}
+
+// ShadowEvaluator evaluates shadow policies on a sample of admission requests, off the request path.
+// Shadow policies are marked with the policies.kyverno.io/shadow annotation, their results are reported
+// in dedicated policy reports and compared with the active policy referenced by the
+// policies.kyverno.io/shadow-of annotation in the kyverno_shadow_policy_results metric.
+type ShadowEvaluator interface {
+	// Evaluate queues a request matched by shadow policies if it is sampled, it never blocks
+	// and the request is dropped when the queue is full
+	Evaluate(*admissionv1.AdmissionRequest, map[string]string)
+	// Run evaluates the queued requests until the context is cancelled
+	Run(context.Context)
+}
+
+type shadowRequest struct {
+	request         *admissionv1.AdmissionRequest
+	namespaceLabels map[string]string
+}
+
+type shadowEvaluator struct {
+	logger           logr.Logger
+	engine           engineapi.Engine
+	pCache           policycache.Cache
+	pcBuilder        webhookutils.PolicyContextBuilder
+	admissionReports admissionreports.Writer
+	options          ShadowOptions
+	queue            chan shadowRequest
+	sample           func() bool
+	metrics          shadowMetrics
+}
+
+// NewShadowEvaluator creates a shadow policies evaluator, it returns nil when the sample rate is zero
+func NewShadowEvaluator(
+	logger logr.Logger,
+	engine engineapi.Engine,
+	pCache policycache.Cache,
+	pcBuilder webhookutils.PolicyContextBuilder,
+	admissionReports admissionreports.Writer,
+	options ShadowOptions,
+) ShadowEvaluator {
+	if options.SampleRate <= 0 {
+		return nil
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = 1
+	}
+	if options.Workers <= 0 {
+		options.Workers = 1
+	}
+	return &shadowEvaluator{
+		logger:           logger,
+		engine:           engine,
+		pCache:           pCache,
+		pcBuilder:        pcBuilder,
+		admissionReports: admissionReports,
+		options:          options,
+		queue:            make(chan shadowRequest, options.QueueSize),
+		sample: func() bool {
+			return options.SampleRate >= 1 || rand.Float64() < options.SampleRate //nolint:gosec
+		},
+		metrics: newShadowMetrics(logger),
+	}
+}
+
+func (s *shadowEvaluator) Evaluate(request *admissionv1.AdmissionRequest, namespaceLabels map[string]string) {
+	if len(s.pCache.GetPolicies(policycache.ValidateShadow, request.Kind.Kind, request.Namespace)) == 0 {
+		return
+	}
+	ctx := context.Background()
+	if !s.sample() {
+		s.metrics.recordRequest(ctx, shadowRequestSkipped)
+		return
+	}
+	select {
+	case s.queue <- shadowRequest{request: request, namespaceLabels: namespaceLabels}:
+	default:
+		s.metrics.recordRequest(ctx, shadowRequestDropped)
+	}
+}
+
+func (s *shadowEvaluator) Run(ctx context.Context) {
+	logger := s.logger.WithName("shadow")
+	logger.Info("start")
+	defer logger.Info("stop")
+	var wg sync.WaitGroup
+	for i := 0; i < s.options.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case request := <-s.queue:
+					s.process(logger, request)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *shadowEvaluator) process(logger logr.Logger, r shadowRequest) {
+	request := r.request
+	tracing.Span(
+		context.Background(),
+		"",
+		fmt.Sprintf("SHADOW %s %s", request.Operation, request.Kind),
+		func(ctx context.Context, span trace.Span) {
+			policies := s.pCache.GetPolicies(policycache.ValidateShadow, request.Kind.Kind, request.Namespace)
+			if len(policies) == 0 {
+				return
+			}
+			policyContext, err := s.pcBuilder.Build(request)
+			if err != nil {
+				logger.Error(err, "failed to build policy context")
+				return
+			}
+			policyContext = policyContext.WithNamespaceLabels(r.namespaceLabels)
+			var responses []*engineapi.EngineResponse
+			for _, policy := range policies {
+				response := s.engine.Validate(ctx, policyContext.WithPolicy(policy))
+				shadowOf := policy.GetAnnotations()[kyvernov1.AnnotationPolicyShadowOf]
+				active := ""
+				if shadowOf != "" {
+					active = s.evaluateActive(ctx, policyContext, request, shadowOf)
+				}
+				s.metrics.recordResult(ctx, policy, shadowOf, request, policyOutcome(response), active)
+				if response != nil && !response.IsEmpty() {
+					responses = append(responses, response)
+				}
+			}
+			s.metrics.recordRequest(ctx, shadowRequestEvaluated)
+			s.report(ctx, logger, policyContext.NewResource(), request, responses...)
+		},
+	)
+}
+
+// evaluateActive evaluates the active policy a shadow policy is compared with
+func (s *shadowEvaluator) evaluateActive(ctx context.Context, policyContext *engine.PolicyContext, request *admissionv1.AdmissionRequest, key string) string {
+	for _, policyType := range []policycache.PolicyType{policycache.ValidateEnforce, policycache.ValidateAudit} {
+		for _, policy := range s.pCache.GetPolicies(policyType, request.Kind.Kind, request.Namespace) {
+			if policyKey, err := cache.MetaNamespaceKeyFunc(policy); err == nil && policyKey == key {
+				return policyOutcome(s.engine.Validate(ctx, policyContext.WithPolicy(policy)))
+			}
+		}
+	}
+	return outcomeNone
+}
+
+func (s *shadowEvaluator) report(ctx context.Context, logger logr.Logger, resource unstructured.Unstructured, request *admissionv1.AdmissionRequest, responses ...*engineapi.EngineResponse) {
+	if s.admissionReports == nil || len(responses) == 0 {
+		return
+	}
+	if request.DryRun != nil && *request.DryRun {
+		return
+	}
+	// we don't need reports for deletions
+	if request.Operation == admissionv1.Delete {
+		return
+	}
+	// check if the resource supports reporting
+	if !reportutils.IsGvkSupported(schema.GroupVersionKind(request.Kind)) {
+		return
+	}
+	report := reportutils.BuildAdmissionReport(resource, request, request.Kind, responses...)
+	// if it's not a creation, the resource already exists, we can set the owner
+	if request.Operation != admissionv1.Create {
+		gv := metav1.GroupVersion{Group: request.Kind.Group, Version: request.Kind.Version}
+		controllerutils.SetOwner(report, gv.String(), request.Kind.Kind, resource.GetName(), resource.GetUID())
+	}
+	if len(report.GetResults()) > 0 {
+		if _, err := s.admissionReports.Create(ctx, report); err != nil {
+			logger.Error(err, "failed to create report")
+		}
+	}
+}
+
+// policyOutcome summarizes the rules of a policy response, a failure takes precedence over an error
+// and an error over a pass
+func policyOutcome(response *engineapi.EngineResponse) string {
+	if response == nil {
+		return outcomeSkip
+	}
+	switch {
+	case response.IsFailed():
+		return outcomeFail
+	case response.IsError():
+		return outcomeError
+	case response.IsOneOf(engineapi.RuleStatusPass):
+		return outcomePass
+	default:
+		return outcomeSkip
+	}
+}
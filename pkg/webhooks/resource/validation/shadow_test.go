@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newShadowPolicy() *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "require-labels-v2",
+			Annotations: map[string]string{
+				kyvernov1.AnnotationPolicyShadow:   "true",
+				kyvernov1.AnnotationPolicyShadowOf: "require-labels",
+			},
+		},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "check-labels",
+				MatchResources: kyvernov1.MatchResources{
+					ResourceDescription: kyvernov1.ResourceDescription{Kinds: []string{"ConfigMap"}},
+				},
+				Validation: kyvernov1.Validation{
+					Message: "labels are required",
+					RawPattern: &apiextv1.JSON{
+						Raw: []byte(`{"metadata":{"labels":{"app":"?*"}}}`),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestNewShadowEvaluator(t *testing.T) {
+	assert.Assert(t, NewShadowEvaluator(logr.Discard(), nil, nil, nil, nil, ShadowOptions{}) == nil)
+	assert.Assert(t, NewShadowEvaluator(logr.Discard(), nil, nil, nil, nil, ShadowOptions{SampleRate: 0.5}) != nil)
+}
+
+func TestShadowEvaluator_Evaluate(t *testing.T) {
+	pCache := policycache.NewCache()
+	pCache.Set("require-labels-v2", newShadowPolicy(), nil)
+	evaluator := NewShadowEvaluator(logr.Discard(), nil, pCache, nil, nil, ShadowOptions{SampleRate: 1, QueueSize: 1}).(*shadowEvaluator)
+	configMap := &admissionv1.AdmissionRequest{Kind: metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, Namespace: "default"}
+	pod := &admissionv1.AdmissionRequest{Kind: metav1.GroupVersionKind{Version: "v1", Kind: "Pod"}, Namespace: "default"}
+	// requests not matched by shadow policies are not queued
+	evaluator.Evaluate(pod, nil)
+	assert.Equal(t, len(evaluator.queue), 0)
+	evaluator.Evaluate(configMap, nil)
+	assert.Equal(t, len(evaluator.queue), 1)
+	// the request is dropped when the queue is full, without blocking
+	evaluator.Evaluate(configMap, nil)
+	assert.Equal(t, len(evaluator.queue), 1)
+	// requests not sampled are not queued
+	<-evaluator.queue
+	evaluator.sample = func() bool { return false }
+	evaluator.Evaluate(configMap, nil)
+	assert.Equal(t, len(evaluator.queue), 0)
+}
+
+func TestPolicyOutcome(t *testing.T) {
+	response := func(status ...engineapi.RuleStatus) *engineapi.EngineResponse {
+		var rules []engineapi.RuleResponse
+		for _, s := range status {
+			rules = append(rules, engineapi.RuleResponse{Status: s})
+		}
+		return &engineapi.EngineResponse{PolicyResponse: engineapi.PolicyResponse{Rules: rules}}
+	}
+	assert.Equal(t, policyOutcome(nil), outcomeSkip)
+	assert.Equal(t, policyOutcome(response()), outcomeSkip)
+	assert.Equal(t, policyOutcome(response(engineapi.RuleStatusSkip)), outcomeSkip)
+	assert.Equal(t, policyOutcome(response(engineapi.RuleStatusPass, engineapi.RuleStatusSkip)), outcomePass)
+	assert.Equal(t, policyOutcome(response(engineapi.RuleStatusPass, engineapi.RuleStatusError)), outcomeError)
+	assert.Equal(t, policyOutcome(response(engineapi.RuleStatusError, engineapi.RuleStatusFail)), outcomeFail)
+}
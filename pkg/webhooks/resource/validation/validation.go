@@ -8,7 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/admissionreports"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
@@ -36,18 +36,17 @@ type ValidationHandler interface {
 
 func NewValidationHandler(
 	log logr.Logger,
-	kyvernoClient versioned.Interface,
 	engine engineapi.Engine,
 	pCache policycache.Cache,
 	pcBuilder webhookutils.PolicyContextBuilder,
 	eventGen event.Interface,
-	admissionReports bool,
+	admissionReports admissionreports.Writer,
 	metrics metrics.MetricsConfigManager,
 	cfg config.Configuration,
+	shadow ShadowEvaluator,
 ) ValidationHandler {
 	return &validationHandler{
 		log:              log,
-		kyvernoClient:    kyvernoClient,
 		engine:           engine,
 		pCache:           pCache,
 		pcBuilder:        pcBuilder,
@@ -55,19 +54,21 @@ func NewValidationHandler(
 		admissionReports: admissionReports,
 		metrics:          metrics,
 		cfg:              cfg,
+		shadow:           shadow,
 	}
 }
 
 type validationHandler struct {
 	log              logr.Logger
-	kyvernoClient    versioned.Interface
 	engine           engineapi.Engine
 	pCache           policycache.Cache
 	pcBuilder        webhookutils.PolicyContextBuilder
 	eventGen         event.Interface
-	admissionReports bool
+	admissionReports admissionreports.Writer
 	metrics          metrics.MetricsConfigManager
 	cfg              config.Configuration
+	// shadow evaluates shadow policies off the request path, nil when shadow policies are not evaluated
+	shadow ShadowEvaluator
 }
 
 func (v *validationHandler) HandleValidation(
@@ -129,6 +130,11 @@ func (v *validationHandler) HandleValidation(
 		)
 	}
 
+	// shadow policies are evaluated whatever the outcome of the active policies to compare them
+	if v.shadow != nil {
+		v.shadow.Evaluate(request, policyContext.NamespaceLabels())
+	}
+
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
 	if deletionTimeStamp == nil {
 		events := webhookutils.GenerateEvents(engineResponses, blocked)
@@ -179,7 +185,7 @@ func (v *validationHandler) handleAudit(
 	namespaceLabels map[string]string,
 	engineResponses ...*engineapi.EngineResponse,
 ) {
-	if !v.admissionReports {
+	if v.admissionReports == nil {
 		return
 	}
 	if request.DryRun != nil && *request.DryRun {
@@ -212,7 +218,7 @@ func (v *validationHandler) handleAudit(
 				controllerutils.SetOwner(report, gv.String(), request.Kind.Kind, resource.GetName(), resource.GetUID())
 			}
 			if len(report.GetResults()) > 0 {
-				_, err = reportutils.CreateReport(ctx, report, v.kyvernoClient)
+				_, err = v.admissionReports.Create(ctx, report)
 				if err != nil {
 					v.log.Error(err, "failed to create report")
 				}